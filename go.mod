@@ -1,6 +1,6 @@
 module github.com/flier/goutil
 
-go 1.18
+go 1.21
 
 require (
 	github.com/dolthub/maphash v0.1.0
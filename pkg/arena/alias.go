@@ -0,0 +1,214 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// ErrCrossArena is returned by [CheckAlias] when a pointer into the memory of an
+// arena is stored in the memory of another arena.
+var ErrCrossArena = errors.New("arena: pointer into another arena")
+
+// CheckAlias returns an error wrapping [ErrCrossArena] if p and q point into the
+// memory of different arenas, typically when a structure allocated at p stores the
+// pointer q. Resetting the arena of q would silently corrupt the structure at p.
+//
+// The blocks of the arenas are only tracked when built with the debug tag, so it
+// always returns nil otherwise. Addresses outside of the arenas, e.g. of the heap
+// or of an arena backed by a buffer, are ignored.
+func CheckAlias(p, q xunsafe.Addr[byte]) error {
+	if !debug.Enabled {
+		return nil
+	}
+
+	pa, qa := blocks.owner(p), blocks.owner(q)
+	if pa == 0 || qa == 0 || pa == qa || blocks.linked(pa, qa) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v of arena %#x points to %v of arena %#x", ErrCrossArena, p, pa, q, qa)
+}
+
+// AssertNoAlias panics with the error of [CheckAlias], if any, in debug builds.
+func AssertNoAlias(p, q xunsafe.Addr[byte]) {
+	if !debug.Enabled {
+		return
+	}
+
+	if err := CheckAlias(p, q); err != nil {
+		panic(err)
+	}
+}
+
+// ErrArenaMemory is returned by [CheckHeap] when a value holding pointers to the
+// Go heap lives in the memory of an arena.
+var ErrArenaMemory = errors.New("arena: heap pointers in arena memory")
+
+// CheckHeap returns an error wrapping [ErrArenaMemory] if p points into the memory
+// of an arena, typically when a structure allocated at p is about to store pointers
+// to the Go heap. The GC doesn't scan the memory of an arena, so the heap objects
+// could be collected while the structure still references them.
+//
+// The blocks of the arenas are only tracked when built with the debug tag, so it
+// always returns nil otherwise.
+func CheckHeap(p xunsafe.Addr[byte]) error {
+	if !debug.Enabled {
+		return nil
+	}
+
+	if a := blocks.owner(p); a != 0 {
+		return fmt.Errorf("%w: %v of arena %#x", ErrArenaMemory, p, a)
+	}
+
+	return nil
+}
+
+// AssertHeap panics with the error of [CheckHeap], if any, in debug builds.
+func AssertHeap(p xunsafe.Addr[byte]) {
+	if !debug.Enabled {
+		return
+	}
+
+	if err := CheckHeap(p); err != nil {
+		panic(err)
+	}
+}
+
+// LinkArenas declares that the memory of the given arenas may point into each other,
+// because they are reset together, e.g. the arenas of the shards of a structure built
+// in parallel, so that [CheckAlias] ignores the pointers between them.
+//
+// Linking is transitive, and only tracked when built with the debug tag.
+func LinkArenas(arenas ...*Arena) {
+	if !debug.Enabled {
+		return
+	}
+
+	addrs := make([]uintptr, len(arenas))
+	for i, a := range arenas {
+		addrs[i] = uintptr(xunsafe.AddrOf(a))
+	}
+
+	blocks.link(addrs)
+}
+
+// blocks tracks the blocks of all the arenas in debug builds.
+var blocks blockSet
+
+// block is the memory range [start, end) of a block of an arena.
+type block struct {
+	start, end xunsafe.Addr[byte]
+	owner      uintptr // Not a pointer, so that the arena can be collected.
+}
+
+// blockSet is a set of blocks sorted by their start address.
+type blockSet struct {
+	mu     sync.Mutex
+	blocks []block
+	groups map[uintptr]uint64 // The group of the linked arenas, see LinkArenas.
+	group  uint64             // The last group.
+}
+
+// add tracks a new block of the arena a.
+func (s *blockSet) add(a *Arena, p *byte, n int) {
+	start := xunsafe.AddrOf(p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.search(start)
+	s.blocks = append(s.blocks, block{})
+	copy(s.blocks[i+1:], s.blocks[i:])
+	s.blocks[i] = block{start, start.ByteAdd(n), uintptr(xunsafe.AddrOf(a))}
+}
+
+// remove stops tracking the block at p.
+func (s *blockSet) remove(p *byte) {
+	start := xunsafe.AddrOf(p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.search(start); i < len(s.blocks) && s.blocks[i].start == start {
+		s.blocks = append(s.blocks[:i], s.blocks[i+1:]...)
+	}
+}
+
+// removeArena stops tracking all the blocks of the arena at addr.
+func (s *blockSet) removeArena(addr uintptr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.groups, addr)
+
+	kept := s.blocks[:0]
+	for _, b := range s.blocks {
+		if b.owner != addr {
+			kept = append(kept, b)
+		}
+	}
+
+	s.blocks = kept
+}
+
+// link puts the arenas at addrs, and the arenas already linked to them, in the same group.
+func (s *blockSet) link(addrs []uintptr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.groups == nil {
+		s.groups = make(map[uintptr]uint64)
+	}
+
+	s.group++
+
+	merged := make(map[uint64]bool)
+
+	for _, addr := range addrs {
+		if g, ok := s.groups[addr]; ok {
+			merged[g] = true
+		}
+
+		s.groups[addr] = s.group
+	}
+
+	for addr, g := range s.groups {
+		if merged[g] {
+			s.groups[addr] = s.group
+		}
+	}
+}
+
+// linked returns true if the arenas at a and b are in the same group.
+func (s *blockSet) linked(a, b uintptr) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ga, ok := s.groups[a]
+
+	return ok && s.groups[b] == ga
+}
+
+// owner returns the address of the arena whose block contains p, or zero.
+func (s *blockSet) owner(p xunsafe.Addr[byte]) uintptr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.search(p + 1); i > 0 && p < s.blocks[i-1].end {
+		return s.blocks[i-1].owner
+	}
+
+	return 0
+}
+
+// search returns the index of the first block starting at or after p.
+func (s *blockSet) search(p xunsafe.Addr[byte]) int {
+	return sort.Search(len(s.blocks), func(i int) bool { return s.blocks[i].start >= p })
+}
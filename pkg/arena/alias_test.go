@@ -0,0 +1,96 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+func TestCheckAlias(t *testing.T) {
+	Convey("Given two arenas", t, func() {
+		a, b := new(arena.Arena), new(arena.Arena)
+
+		p := xunsafe.AddrOf(a.Alloc(64))
+		q := xunsafe.AddrOf(a.Alloc(64))
+		r := xunsafe.AddrOf(b.Alloc(64))
+		h := xunsafe.AddrOf(new(byte))
+
+		Convey("Then pointers within the same arena are allowed", func() {
+			So(arena.CheckAlias(p, q), ShouldBeNil)
+			So(func() { arena.AssertNoAlias(p, q) }, ShouldNotPanic)
+		})
+
+		Convey("Then pointers outside of the arenas are ignored", func() {
+			So(arena.CheckAlias(p, h), ShouldBeNil)
+			So(arena.CheckAlias(h, r), ShouldBeNil)
+		})
+
+		Convey("Then pointers into another arena are detected in debug builds", func() {
+			if debug.Enabled {
+				So(arena.CheckAlias(p, r), ShouldWrap, arena.ErrCrossArena)
+				So(func() { arena.AssertNoAlias(p, r) }, ShouldPanic)
+			} else {
+				So(arena.CheckAlias(p, r), ShouldBeNil)
+				So(func() { arena.AssertNoAlias(p, r) }, ShouldNotPanic)
+			}
+		})
+
+		Convey("Then pointers between linked arenas are allowed", func() {
+			c := new(arena.Arena)
+			s := xunsafe.AddrOf(c.Alloc(64))
+
+			if debug.Enabled {
+				So(arena.CheckAlias(r, s), ShouldWrap, arena.ErrCrossArena)
+			}
+
+			arena.LinkArenas(a, b)
+			arena.LinkArenas(c, b)
+
+			So(arena.CheckAlias(p, r), ShouldBeNil)
+			So(arena.CheckAlias(r, p), ShouldBeNil)
+			So(arena.CheckAlias(p, s), ShouldBeNil)
+		})
+
+		Convey("Then the discarded blocks are no longer tracked", func() {
+			for i := 0; i < 8; i++ {
+				b.Alloc(1 << 12)
+			}
+
+			b.ResetKeep(1)
+
+			So(arena.CheckAlias(p, r), ShouldBeNil)
+		})
+	})
+}
+
+func TestCheckHeap(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // p doesn't keep the arena alive.
+
+		p := xunsafe.AddrOf(a.Alloc(64))
+		h := xunsafe.AddrOf(new(byte))
+
+		Convey("Then pointers to the heap are allowed", func() {
+			So(arena.CheckHeap(h), ShouldBeNil)
+			So(func() { arena.AssertHeap(h) }, ShouldNotPanic)
+		})
+
+		Convey("Then pointers into the arena are detected in debug builds", func() {
+			if debug.Enabled {
+				So(arena.CheckHeap(p), ShouldWrap, arena.ErrArenaMemory)
+				So(func() { arena.AssertHeap(p) }, ShouldPanic)
+			} else {
+				So(arena.CheckHeap(p), ShouldBeNil)
+				So(func() { arena.AssertHeap(p) }, ShouldNotPanic)
+			}
+		})
+	})
+}
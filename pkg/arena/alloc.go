@@ -30,14 +30,30 @@ func SuggestSize(bytes int) int {
 	return n
 }
 
-func (a *Arena) allocChunk(size int) (*byte, int) {
+func (a *Arena) allocChunk(size int) (*byte, int, error) {
 	log := suggestSizeLog(size)
 	n := 1 << log
+
+	// Reuse the smallest block retained by ResetKeep which is large enough. The arena
+	// only grows into larger blocks, so the blocks larger than the request are unused.
+	for i := int(log); i < len(a.blocks); i++ {
+		if a.blocks[i] != nil {
+			return a.blocks[i], 1 << i, nil
+		}
+	}
+
+	if a.limit != nil {
+		if err := a.limit.charge(n, false); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	if int(log) < len(a.blocks) {
-		if a.blocks[log] == nil {
-			a.blocks[log] = allocTraceable(n, unsafe.Pointer(a))
+		a.blocks[log] = allocTraceable(n, unsafe.Pointer(a))
+		if debug.Enabled {
+			blocks.add(a, a.blocks[log], n)
 		}
-		return a.blocks[log], n
+		return a.blocks[log], n, nil
 	}
 
 	p := allocTraceable(n, unsafe.Pointer(a))
@@ -47,14 +63,18 @@ func (a *Arena) allocChunk(size int) (*byte, int) {
 			addr := xunsafe.AddrOf(a)
 			runtime.SetFinalizer(unsafe.SliceData(a.blocks), func(**byte) {
 				debug.Log(nil, "arena collected", "addr: %v", addr)
+				blocks.removeArena(uintptr(addr))
 			})
 		}
 	}
+	if debug.Enabled {
+		blocks.add(a, p, n)
+	}
 	a.blocks = a.blocks[:log+1]
 	debug.Log(nil, "saving block", "a.blocks[%d] = %p -> %p", log, a.blocks[log], p)
 	a.blocks[log] = p
 
-	return p, n
+	return p, n, nil
 }
 
 // allocTraceable allocates size bytes of garbage-collected memory and returns
@@ -140,6 +140,7 @@
 package arena
 
 import (
+	"math/bits"
 	"unsafe"
 
 	"github.com/flier/goutil/internal/debug"
@@ -232,7 +233,7 @@ import (
 //
 // The Allocator interface does not guarantee thread safety. If multiple
 // goroutines access the same allocator concurrently, external synchronization
-// must be provided by the caller.
+// must be provided by the caller, or use [RecycledSync] instead.
 //
 // # Error Handling
 //
@@ -322,7 +323,7 @@ type Arena struct {
 	// Exported to allow for open-coding of Alloc() in some hot callsites,
 	// because Go won't inline it >_>
 	next, end xunsafe.Addr[byte]
-	cap       int // Always a power of 2.
+	cap       int // Always a power of 2, unless backed by a buffer.
 
 	// Blocks of memory allocated by this arena. Indexed by their size log 2.
 	blocks []*byte
@@ -330,6 +331,23 @@ type Arena struct {
 	// Data to keep around for the GC to mark whenever it marks an arena.
 	// Holding any pointer to the arena will keep anything here alive, too.
 	keep []unsafe.Pointer
+
+	// Caller-provided backing buffer, see [FromBuffer]. When set, the arena
+	// never grows beyond it.
+	buf []byte
+
+	// Accounts for the memory of blocks, see [Arena.SetLimit].
+	limit *Limit
+
+	// Heap objects referenced from the arena, see [Arena.Roots].
+	roots Roots
+
+	// Functions run when the memory allocated after a mark is released, before it
+	// is reused, with the zero mark on reset, see [Typed].
+	releases []func(m Mark)
+
+	// Number of times the arena took the growth slow path, see [Arena.Grows].
+	grows int
 }
 
 var _ Allocator = (*Arena)(nil)
@@ -338,12 +356,16 @@ var _ Allocator = (*Arena)(nil)
 const Align = int(unsafe.Sizeof(uintptr(0)))
 
 // New allocates a new value of type T on an arena.
+//
+// When built with the arenastats tag, the values are counted per type, see [TypeStats].
 func New[T any](a Allocator, value T) *T {
 	layout := layout.Of[T]()
 	if layout.Align > Align {
 		panic("over-aligned object")
 	}
 
+	countType[T](layout.Size)
+
 	p := xunsafe.Cast[T](a.Alloc(layout.Size))
 	*p = value
 	return p
@@ -462,6 +484,34 @@ func (a *Arena) Reserve(size int) {
 	}
 }
 
+// Preallocate reserves a block of at least size bytes up front, so that allocating
+// that many bytes afterwards never takes the growth slow path of [Arena.Grow].
+//
+// Unlike [Arena.Reserve], it returns an error instead of panicking if the memory
+// can't be reserved: [ErrOutOfMemory] if the arena is backed by a buffer too small
+// for it, or reserving it would exceed the hard limit of the arena, see [Arena.SetLimit].
+// This allows capacity failures to surface at startup rather than mid-request.
+//
+// The block is the largest one of the arena, so it is retained by [Arena.Reset].
+// Use [Arena.Grows] to verify that the reservation was large enough.
+func (a *Arena) Preallocate(size int) error {
+	if size <= 0 {
+		return nil
+	}
+
+	size = alignUp(size)
+
+	if a.next.Add(size) <= a.end {
+		return nil
+	}
+
+	return a.grow(size)
+}
+
+// Grows returns the number of times the arena took the growth slow path to get
+// a new block, since it was created.
+func (a *Arena) Grows() int { return a.grows }
+
 // Reset resets this arena to an "empty" state, allowing all memory allocated by
 // it to be re-used.
 //
@@ -469,22 +519,79 @@ func (a *Arena) Reserve(size int) {
 // trades off safety: any memory allocated by the arena must not be referenced
 // after a call to Reset.
 func (a *Arena) Reset() {
-	if len(a.blocks) == 0 {
-		return
-	}
-
 	// Discard all but the largest block, which we clear. This means that as
 	// an arena is re-used, we will eventually wind up learning the size of the
 	// largest block we need to allocate, and use only that one, meaning that
 	// "average" calls should never have to call Grow().
-	end := len(a.blocks) - 1
-	clear(a.blocks[:end])
-	xunsafe.Clear(a.blocks[end], 1<<end)
+	a.ResetKeep(1)
+}
+
+// ResetKeep resets this arena like [Arena.Reset], but retains up to n of the
+// largest blocks allocated so far for reuse.
+//
+// The arena restarts from the smallest retained block, and growing it reuses
+// the larger ones before asking Go's allocator for more memory. This avoids
+// warming up again after every reset when the working set of each round, e.g.
+// a request in a server, spans several blocks.
+//
+// ResetKeep(0) discards all blocks, returning the arena to its zero state.
+//
+// The fini functions of the [Typed] facades of the arena run first, on their live
+// values, before the [Roots] of the arena are cleared.
+func (a *Arena) ResetKeep(n int) {
+	for _, release := range a.releases {
+		release(Mark{})
+	}
+
+	a.roots.Reset()
+
+	if a.buf != nil {
+		a.resetBuffer()
+		return
+	}
+
+	if len(a.blocks) == 0 {
+		return
+	}
 
-	// Set up next/end/cap to point to the largest block.
-	a.next = xunsafe.AddrOf(a.blocks[end])
-	a.end = a.next.Add(1 << end)
-	a.cap = 1 << end
+	// Find the smallest of the n largest blocks, then discard everything
+	// smaller and clear what is left.
+	first := len(a.blocks)
+	for i := len(a.blocks) - 1; i >= 0 && n > 0; i-- {
+		if a.blocks[i] != nil {
+			first = i
+			n--
+		}
+	}
+
+	if a.limit != nil {
+		a.limit.refund(a.blockBytes(0) - a.blockBytes(first))
+	}
+
+	if debug.Enabled {
+		for _, b := range a.blocks[:first] {
+			if b != nil {
+				blocks.remove(b)
+			}
+		}
+	}
+
+	clear(a.blocks[:first])
+
+	if first == len(a.blocks) {
+		a.next, a.end, a.cap = 0, 0, 0
+	} else {
+		for i := first; i < len(a.blocks); i++ {
+			if a.blocks[i] != nil {
+				xunsafe.Clear(a.blocks[i], 1<<i)
+			}
+		}
+
+		// Set up next/end/cap to point to the smallest retained block.
+		a.next = xunsafe.AddrOf(a.blocks[first])
+		a.end = a.next.Add(1 << first)
+		a.cap = 1 << first
+	}
 
 	// Order doesn't matter here: nothing in a.blocks can point into a.keep,
 	// because the only GC-visible pointers in a.blocks are pointers back to
@@ -500,25 +607,90 @@ func (a *Arena) Reset() {
 	a.keep = nil
 }
 
+// Compact releases the blocks retained by [Arena.ResetKeep] that are larger than
+// the current block, and so hold no live allocation, and returns their total size
+// in bytes. It is meant to be called from memory pressure handlers of long-lived
+// processes, which would otherwise keep those blocks until the next reset.
+//
+// On Linux, the whole pages of the blocks are returned to the OS right away with
+// madvise. Elsewhere, the blocks are ordinary Go memory, which is returned to the
+// OS once the GC has collected them and the runtime scavenged their pages; call
+// [runtime/debug.FreeOSMemory] afterwards to do so promptly.
+//
+// An arena backed by a buffer has nothing to release.
+func (a *Arena) Compact() int {
+	if a.buf != nil || a.cap == 0 {
+		return 0
+	}
+
+	first := bits.TrailingZeros(uint(a.cap)) + 1
+	if first >= len(a.blocks) {
+		return 0
+	}
+
+	n := a.blockBytes(first)
+
+	if a.limit != nil {
+		a.limit.refund(n)
+	}
+
+	for i, b := range a.blocks[first:] {
+		if b == nil {
+			continue
+		}
+
+		if debug.Enabled {
+			blocks.remove(b)
+		}
+
+		releasePages(b, 1<<(first+i))
+	}
+
+	clear(a.blocks[first:])
+	a.blocks = a.blocks[:first]
+	a.Log("compact", "%d bytes\n", n)
+
+	return n
+}
+
 // Grow allocates fresh memory onto next of at least the given size.
 //
+// It panics with [ErrOutOfMemory] if the arena is backed by a buffer, or growing
+// it would exceed the hard limit of the arena, see [Arena.SetLimit].
+//
 //go:nosplit
 func (a *Arena) Grow(size int) {
+	if err := a.grow(size); err != nil {
+		panic(err)
+	}
+}
+
+func (a *Arena) grow(size int) error {
+	if a.buf != nil {
+		return ErrOutOfMemory
+	}
+
 	xunsafe.Escape(a)
-	p, n := a.allocChunk(max(size, a.cap*2))
+	p, n, err := a.allocChunk(max(size, a.cap*2))
+	if err != nil {
+		return err
+	}
 	// No need to KeepAlive(p) this pointer, since allocChunk sticks it in the
 	// dedicated memory block array.
 
 	a.next = xunsafe.AddrOf(p)
 	a.end = a.next.Add(n)
 	a.cap = n
+	a.grows++
 	a.Log("grow", "%v:%v:%d\n", a.next, a.end, a.cap)
+
+	return nil
 }
 
 func (a *Arena) Next() xunsafe.Addr[byte] { return a.next }
 func (a *Arena) End() xunsafe.Addr[byte]  { return a.end }
 func (a *Arena) Cap() int                 { return a.cap }
-func (a *Arena) Advance(n int)            { a.next.Add(n) }
+func (a *Arena) Advance(n int)            { a.next = a.next.Add(n) }
 
 func (a *Arena) Log(op, format string, args ...any) {
 	debug.Log([]any{"%p %v:%v", a, a.next, a.end}, op, format, args...)
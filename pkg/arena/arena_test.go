@@ -9,6 +9,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
 )
 
 func TestArena_New(t *testing.T) {
@@ -138,6 +139,58 @@ func TestArena_Reserve(t *testing.T) {
 	})
 }
 
+func TestArena_Preallocate(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := &arena.Arena{}
+
+		Convey("When preallocating memory", func() {
+			So(a.Preallocate(1<<20), ShouldBeNil)
+			So(a.Cap(), ShouldBeGreaterThanOrEqualTo, 1<<20)
+
+			grows := a.Grows()
+
+			Convey("Then allocating it should never grow the arena", func() {
+				for i := 0; i < 1<<20/64; i++ {
+					a.Alloc(64)
+				}
+
+				So(a.Grows(), ShouldEqual, grows)
+
+				a.Alloc(64)
+
+				So(a.Grows(), ShouldEqual, grows+1)
+			})
+
+			Convey("Then the reservation should survive a reset", func() {
+				a.Reset()
+
+				So(a.Preallocate(1<<20), ShouldBeNil)
+				So(a.Grows(), ShouldEqual, grows)
+			})
+
+			Convey("Then preallocating less should be a no-op", func() {
+				So(a.Preallocate(1024), ShouldBeNil)
+				So(a.Preallocate(0), ShouldBeNil)
+				So(a.Grows(), ShouldEqual, grows)
+			})
+		})
+
+		Convey("When the hard limit is too low", func() {
+			a.SetLimit(arena.NewLimit(0, 4096, nil))
+
+			So(a.Preallocate(1<<20), ShouldEqual, arena.ErrOutOfMemory)
+			So(a.Grows(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given an arena backed by a buffer", t, func() {
+		a := arena.FromBuffer(make([]byte, 1024))
+
+		So(a.Preallocate(512), ShouldBeNil)
+		So(a.Preallocate(4096), ShouldEqual, arena.ErrOutOfMemory)
+	})
+}
+
 func TestArena_Grow(t *testing.T) {
 	Convey("Given an arena", t, func() {
 		a := &arena.Arena{}
@@ -194,6 +247,128 @@ func TestArena_Reset(t *testing.T) {
 	})
 }
 
+func TestArena_ResetKeep(t *testing.T) {
+	Convey("Given an arena grown over several blocks", t, func() {
+		a := &arena.Arena{}
+
+		for i := 0; i < 500; i++ {
+			a.Alloc(64)
+		}
+
+		largest := a.Cap()
+
+		Convey("When resetting with retained blocks", func() {
+			a.ResetKeep(3)
+
+			So(a.Cap(), ShouldEqual, largest/4)
+
+			Convey("Then growing should reuse them", func() {
+				blocks := map[xunsafe.Addr[byte]]bool{}
+
+				for i := 0; i < 400; i++ {
+					p := a.Alloc(64)
+					So(*p, ShouldEqual, 0)
+
+					blocks[a.End()] = true
+				}
+
+				So(a.Cap(), ShouldEqual, largest)
+				So(blocks, ShouldHaveLength, 3)
+
+				a.ResetKeep(3)
+
+				So(blocks[a.End()], ShouldBeTrue)
+			})
+		})
+
+		Convey("When resetting with one retained block", func() {
+			a.ResetKeep(1)
+
+			So(a.Cap(), ShouldEqual, largest)
+		})
+
+		Convey("When resetting without retained blocks", func() {
+			a.ResetKeep(0)
+
+			So(a.Cap(), ShouldEqual, 0)
+			So(a.Next(), ShouldEqual, a.End())
+
+			p := a.Alloc(64)
+			So(p, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an arena with a gap between its retained blocks", t, func() {
+		a := &arena.Arena{}
+
+		a.Alloc(64)
+		a.Alloc(1 << 20)
+
+		end := a.End()
+
+		a.ResetKeep(2)
+
+		So(a.Cap(), ShouldEqual, 64)
+
+		Convey("Then growing should reuse the larger block first", func() {
+			a.Alloc(64)
+			a.Alloc(64)
+
+			So(a.Cap(), ShouldEqual, 1<<20)
+			So(a.End(), ShouldEqual, end)
+		})
+	})
+}
+
+func TestArena_Compact(t *testing.T) {
+	Convey("Given an arena grown over several blocks", t, func() {
+		a := &arena.Arena{}
+		l := arena.NewLimit(0, 0, nil)
+		a.SetLimit(l)
+
+		for i := 0; i < 500; i++ {
+			a.Alloc(64)
+		}
+
+		largest := a.Cap()
+
+		Convey("When compacting it in use", func() {
+			So(a.Compact(), ShouldEqual, 0)
+			So(a.Cap(), ShouldEqual, largest)
+		})
+
+		Convey("When compacting it after resetting with retained blocks", func() {
+			a.ResetKeep(3)
+
+			used := l.Used()
+
+			So(a.Compact(), ShouldEqual, largest+largest/2)
+			So(l.Used(), ShouldEqual, used-largest-largest/2)
+			So(a.Cap(), ShouldEqual, largest/4)
+
+			Convey("Then compacting again should release nothing", func() {
+				So(a.Compact(), ShouldEqual, 0)
+			})
+
+			Convey("Then the arena should grow again", func() {
+				for i := 0; i < 400; i++ {
+					So(*a.Alloc(64), ShouldEqual, 0)
+				}
+
+				So(a.Cap(), ShouldEqual, largest)
+			})
+		})
+	})
+
+	Convey("Given an empty arena", t, func() {
+		So(new(arena.Arena).Compact(), ShouldEqual, 0)
+	})
+
+	Convey("Given an arena backed by a buffer", t, func() {
+		So(arena.FromBuffer(make([]byte, 1024)).Compact(), ShouldEqual, 0)
+	})
+}
+
 func TestArena_KeepAlive(t *testing.T) {
 	Convey("Given an arena", t, func() {
 		a := &arena.Arena{}
@@ -5,7 +5,9 @@ package art
 import (
 	"iter"
 
+	"github.com/flier/goutil/pkg/arena/art/node"
 	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/xunsafe"
 )
 
 // All iterates over all key-value pairs in the tree using Go 1.23+ iterators.
@@ -48,9 +50,9 @@ import (
 // For compatibility with earlier Go versions, use the Visit method instead.
 func (t *Tree[T]) All() iter.Seq2[[]byte, *T] {
 	return func(yield func([]byte, *T) bool) {
-		tree.RecursiveIter(t.root, func(key []byte, value *T) bool {
+		tree.RecursiveIter(t.root, t.guard(func(key []byte, value *T) bool {
 			return !yield(key, value)
-		})
+		}))
 	}
 }
 
@@ -108,7 +110,128 @@ func (t *Tree[T]) All() iter.Seq2[[]byte, *T] {
 // For compatibility with earlier Go versions, use the VisitPrefix method instead.
 func (t *Tree[T]) AllPrefix(prefix []byte) iter.Seq2[[]byte, *T] {
 	return func(yield func([]byte, *T) bool) {
-		tree.IterPrefix(t.root, prefix, func(key []byte, value *T) bool {
+		tree.IterPrefix(t.root, prefix, t.guard(func(key []byte, value *T) bool {
+			return !yield(key, value)
+		}))
+	}
+}
+
+// AllStrings iterates over all key-value pairs in the tree like [Tree.All], but
+// yields the keys as strings for callers whose APIs demand string keys.
+//
+// The keys are not copied, each string shares the memory of the key stored in
+// the tree, so it stays valid until the key is deleted from the tree, or the
+// arena is reset. Use strings.Clone to keep a key beyond that.
+//
+// Example:
+//
+//	for key, value := range tree.AllStrings() {
+//	    m[key] = *value // No allocation per key.
+//	}
+func (t *Tree[T]) AllStrings() iter.Seq2[string, *T] {
+	return func(yield func(string, *T) bool) {
+		tree.RecursiveIter(t.root, t.guard(func(key []byte, value *T) bool {
+			return !yield(xunsafe.SliceToString(key), value)
+		}))
+	}
+}
+
+// All iterates over all key-value pairs in the frozen tree.
+//
+// See [Tree.All] for details.
+func (f FrozenTree[T]) All() iter.Seq2[[]byte, *T] {
+	return f.t.All()
+}
+
+// AllPrefix iterates over key-value pairs with a specific prefix in the frozen tree.
+//
+// See [Tree.AllPrefix] for details.
+func (f FrozenTree[T]) AllPrefix(prefix []byte) iter.Seq2[[]byte, *T] {
+	return f.t.AllPrefix(prefix)
+}
+
+// AllStrings iterates over all key-value pairs in the frozen tree, with the keys as strings.
+//
+// See [Tree.AllStrings] for details.
+func (f FrozenTree[T]) AllStrings() iter.Seq2[string, *T] {
+	return f.t.AllStrings()
+}
+
+// AllPrefixDepth iterates over leaves with a specific prefix, together with their depth.
+//
+// See [Tree.VisitPrefixDepth] for the meaning of the depth.
+//
+// Example:
+//
+//	for leaf, depth := range tree.AllPrefixDepth([]byte("ap")) {
+//	    key := leaf.Key.Raw()
+//	    fmt.Printf("[%s]%s\n", key[:depth], key[depth:])
+//	}
+func (t *Tree[T]) AllPrefixDepth(prefix []byte) iter.Seq2[*node.Leaf[T], int] {
+	return func(yield func(*node.Leaf[T], int) bool) {
+		tree.IterPrefixDepth(t.root, prefix, func(l *node.Leaf[T], depth int) bool {
+			return !yield(l, depth)
+		})
+	}
+}
+
+// SearchAll iterates over the values associated with the key in insertion order.
+//
+// Example:
+//
+//	for doc := range index.SearchAll([]byte("term")) {
+//	    fmt.Println(*doc)
+//	}
+func (t *MultiTree[T]) SearchAll(key []byte) iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		values := t.Search(key)
+
+		for i := 0; i < values.Len(); i++ {
+			if !yield(values.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over all key-value pairs in the tree, in key order and then
+// insertion order.
+func (t *MultiTree[T]) All() iter.Seq2[[]byte, *T] {
+	return func(yield func([]byte, *T) bool) {
+		t.Visit(func(key []byte, value *T) bool {
+			return !yield(key, value)
+		})
+	}
+}
+
+// AllPrefix iterates over key-value pairs with a specific prefix, in key order and
+// then insertion order.
+func (t *MultiTree[T]) AllPrefix(prefix []byte) iter.Seq2[[]byte, *T] {
+	return func(yield func([]byte, *T) bool) {
+		t.VisitPrefix(prefix, func(key []byte, value *T) bool {
+			return !yield(key, value)
+		})
+	}
+}
+
+// All iterates over all key-value pairs in the tree in ascending key order.
+//
+// See [Tree.All] for details.
+func (t *U64Tree[T]) All() iter.Seq2[uint64, *T] {
+	return func(yield func(uint64, *T) bool) {
+		t.Visit(func(key uint64, value *T) bool {
+			return !yield(key, value)
+		})
+	}
+}
+
+// Range iterates over the key-value pairs between lo and hi (both inclusive)
+// in ascending key order.
+//
+// See [U64Tree.VisitRange] for details.
+func (t *U64Tree[T]) Range(lo, hi uint64) iter.Seq2[uint64, *T] {
+	return func(yield func(uint64, *T) bool) {
+		t.VisitRange(lo, hi, func(key uint64, value *T) bool {
 			return !yield(key, value)
 		})
 	}
@@ -5,6 +5,7 @@ package art_test
 import (
 	"fmt"
 	"maps"
+	"runtime"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -19,6 +20,7 @@ func TestTree_All(t *testing.T) {
 	Convey("Given an ART tree with values", t, func() {
 		tree := &art.Tree[int]{}
 		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 		// Insert some values
 		tree.Insert(a, []byte("apple"), 1)
@@ -183,6 +185,75 @@ func TestTree_AllPrefix(t *testing.T) {
 	})
 }
 
+// TestTree_AllStrings tests the AllStrings method
+func TestTree_AllStrings(t *testing.T) {
+	Convey("Given an ART tree with values", t, func() {
+		tree := &art.Tree[int]{}
+		a := new(arena.Arena)
+
+		tree.Insert(a, []byte("apple"), 1)
+		tree.Insert(a, []byte("banana"), 2)
+		tree.Insert(a, []byte("cherry"), 3)
+		tree.Insert(a, []byte{}, 0)
+
+		Convey("When iterating over all values with string keys", func() {
+			var keys []string
+
+			visited := make(map[string]int)
+
+			for key, value := range tree.AllStrings() {
+				keys = append(keys, key)
+				visited[key] = *value
+			}
+
+			Convey("Then all values should be visited in key order", func() {
+				So(keys, ShouldResemble, []string{"", "apple", "banana", "cherry"})
+				So(visited, ShouldResemble, map[string]int{
+					"":       0,
+					"apple":  1,
+					"banana": 2,
+					"cherry": 3,
+				})
+			})
+		})
+
+		Convey("When iterating with early termination", func() {
+			var keys []string
+
+			for key := range tree.AllStrings() {
+				keys = append(keys, key)
+
+				if key == "apple" {
+					break
+				}
+			}
+
+			So(keys, ShouldResemble, []string{"", "apple"})
+		})
+
+		Convey("When iterating over the frozen tree", func() {
+			visited := maps.Collect(xiter.MapValues(tree.Freeze().AllStrings(), func(value *int) int {
+				return *value
+			}))
+
+			So(visited, ShouldHaveLength, 4)
+			So(visited["banana"], ShouldEqual, 2)
+		})
+
+		Convey("When iterating over all values", func() {
+			allocs := testing.AllocsPerRun(10, func() {
+				for key := range tree.AllStrings() {
+					_ = key
+				}
+			})
+
+			Convey("Then the keys should not be copied", func() {
+				So(allocs, ShouldBeLessThanOrEqualTo, 2)
+			})
+		})
+	})
+}
+
 // TestTree_All_EdgeCases tests edge cases for Iter
 func TestTree_All_EdgeCases(t *testing.T) {
 	Convey("Given an ART tree", t, func() {
@@ -339,6 +410,7 @@ func TestTree_All_AdvancedScenarios(t *testing.T) {
 		Convey("When iterating with nested prefixes", func() {
 			tree := &art.Tree[int]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			// Insert values with nested prefix structure
 			tree.Insert(a, []byte("user"), 1)
@@ -383,6 +455,7 @@ func TestTree_All_AdvancedScenarios(t *testing.T) {
 		Convey("When iterating with mixed key types", func() {
 			tree := &art.Tree[int]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			// Insert various key types
 			tree.Insert(a, []byte(""), 0)              // Empty key
@@ -438,6 +511,7 @@ func TestTree_All_DifferentTypes(t *testing.T) {
 		Convey("When using string values", func() {
 			tree := &art.Tree[string]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			tree.Insert(a, []byte("key1"), "value1")
 			tree.Insert(a, []byte("key2"), "value2")
@@ -462,6 +536,7 @@ func TestTree_All_DifferentTypes(t *testing.T) {
 
 			tree := &art.Tree[TestStruct]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			tree.Insert(a, []byte("struct1"), TestStruct{ID: 1, Name: "test1"})
 			tree.Insert(a, []byte("struct2"), TestStruct{ID: 2, Name: "test2"})
@@ -503,6 +578,7 @@ func BenchmarkTree_All(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree
@@ -524,6 +600,7 @@ func BenchmarkTree_AllPrefix(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree with prefixed keys
@@ -545,6 +622,7 @@ func BenchmarkTree_AllPrefix_NoMatch(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree with prefixed keys
@@ -566,6 +644,7 @@ func BenchmarkTree_All_LargeTree(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree with many keys
@@ -587,6 +666,7 @@ func BenchmarkTree_AllPrefix_LargeTree(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree with many prefixed keys
@@ -608,6 +688,7 @@ func BenchmarkTree_All_StringValues(b *testing.B) {
 	b.ReportAllocs()
 
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 	tree := &art.Tree[int]{}
 
 	// Pre-populate tree with string values
@@ -643,6 +724,7 @@ func TestTree_All_ErrorHandling(t *testing.T) {
 		Convey("When iterating with non-existent prefix", func() {
 			tree := &art.Tree[int]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			// Insert some values
 			tree.Insert(a, []byte("hello"), 1)
@@ -659,6 +741,7 @@ func TestTree_All_ErrorHandling(t *testing.T) {
 		Convey("When iterating with very long prefix that doesn't match", func() {
 			tree := &art.Tree[int]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			// Insert a short key
 			tree.Insert(a, []byte("short"), 1)
@@ -0,0 +1,164 @@
+package art
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// SearchBatch searches for many keys at once, storing the value of keys[i] in out[i],
+// or nil if the key is not found.
+//
+// The keys are searched in sorted order, sorting a copy of their indexes first unless
+// they are already sorted, so that each search resumes from the deepest node on the path
+// of the previous key which is still on its own path, instead of descending from the
+// root again. Multi-get workloads whose keys share long prefixes skip most of the nodes,
+// but sorting the keys costs more than the skipped nodes in a shallow tree, so it pays
+// off most when the keys are already sorted.
+//
+// It panics if out is shorter than keys. The lookup cache of the tree is not used,
+// see [Tree.SetCache].
+func (t *Tree[T]) SearchBatch(keys [][]byte, out []*T) {
+	if len(out) < len(keys) {
+		panic(fmt.Errorf("art: batch output of length %d for %d keys", len(out), len(keys)))
+	}
+
+	var order []int
+
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) > 0 {
+			order = make([]int, len(keys))
+			for i := range order {
+				order[i] = i
+			}
+
+			sort.Sort(batchOrder{keys, order})
+
+			break
+		}
+	}
+
+	var s batchSearch[T]
+
+	for i := range keys {
+		if order != nil {
+			i = order[i]
+		}
+
+		out[i] = s.search(t, keys[i])
+	}
+}
+
+// batchOrder sorts the indexes of the keys by key.
+type batchOrder struct {
+	keys  [][]byte
+	order []int
+}
+
+func (o batchOrder) Len() int { return len(o.order) }
+func (o batchOrder) Less(i, j int) bool {
+	return bytes.Compare(o.keys[o.order[i]], o.keys[o.order[j]]) < 0
+}
+func (o batchOrder) Swap(i, j int) { o.order[i], o.order[j] = o.order[j], o.order[i] }
+
+// batchSearch remembers the path of the last key searched by [Tree.SearchBatch].
+type batchSearch[T any] struct {
+	prev []byte // The last key, owned by the caller.
+	path []batchFrame[T]
+}
+
+// batchFrame is an inner node of the path, entered after consuming depth bytes of the key.
+type batchFrame[T any] struct {
+	ref   node.Ref[T]
+	depth int
+}
+
+func (s *batchSearch[T]) search(t *Tree[T], key []byte) *T {
+	t.hit(key, false)
+
+	if !t.MaybeContains(key) {
+		return nil
+	}
+
+	// The nodes entered within the prefix shared with the previous key are on the path of
+	// this key too, since the descent only depends on the bytes consumed so far.
+	n := commonPrefixLen(key, s.prev)
+
+	k := len(s.path)
+	for k > 0 && s.path[k-1].depth > n {
+		k--
+	}
+
+	ref, depth := t.root, 0
+
+	if k > 0 {
+		k--
+		ref, depth = s.path[k].ref, s.path[k].depth
+	}
+
+	s.path = s.path[:k]
+	s.prev = key
+
+	for !ref.Empty() {
+		if l := ref.AsLeaf(); l != nil {
+			if l.Matches(key) {
+				return &l.Value
+			}
+
+			return nil
+		}
+
+		s.path = append(s.path, batchFrame[T]{ref, depth})
+
+		curr := ref.AsNode()
+
+		if partial := curr.Prefix(); partial.Len() > 0 {
+			if tree.CheckPrefix(partial, key, depth) != partial.Len() {
+				return nil
+			}
+
+			depth += partial.Len()
+		}
+
+		b := -1
+
+		if depth < len(key) {
+			b = int(key[depth])
+		}
+
+		child := curr.FindChild(b)
+		if child == nil {
+			return nil
+		}
+
+		ref = *child
+		depth++
+	}
+
+	return nil
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b []byte) (n int) {
+	m := len(a)
+	if len(b) < m {
+		m = len(b)
+	}
+
+	for ; n+8 <= m; n += 8 {
+		if x := binary.LittleEndian.Uint64(a[n:]) ^ binary.LittleEndian.Uint64(b[n:]); x != 0 {
+			return n + bits.TrailingZeros64(x)/8
+		}
+	}
+
+	for n < m && a[n] == b[n] {
+		n++
+	}
+
+	return n
+}
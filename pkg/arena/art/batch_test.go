@@ -0,0 +1,129 @@
+package art_test
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_SearchBatch(t *testing.T) {
+	Convey("Given a tree with keys sharing long prefixes", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for i := 0; i < 1000; i++ {
+			tree.Insert(a, []byte("/tenants/"+strconv.Itoa(i%10)+"/users/"+strconv.Itoa(i)), i)
+		}
+
+		tree.Insert(a, []byte(""), -1)
+		tree.Insert(a, []byte("a"), -2)
+		tree.Insert(a, []byte("a\x00"), -3)
+
+		keys := [][]byte{[]byte(""), []byte("a"), []byte("a\x00"), []byte("a\x00\x00"), []byte("missing")}
+
+		for i := 0; i < 1100; i += 7 {
+			keys = append(keys, []byte("/tenants/"+strconv.Itoa(i%10)+"/users/"+strconv.Itoa(i)))
+		}
+
+		keys = append(keys, keys[10], []byte("/tenants/1"))
+
+		check := func(keys [][]byte) {
+			out := make([]*int, len(keys))
+
+			tree.SearchBatch(keys, out)
+
+			for i, key := range keys {
+				if want := tree.Search(key); want == nil {
+					So(out[i], ShouldBeNil)
+				} else {
+					So(out[i], ShouldEqual, want)
+				}
+			}
+		}
+
+		Convey("When searching the keys in random order", func() {
+			rand.New(rand.NewSource(42)).Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+			check(keys)
+		})
+
+		Convey("When searching sorted keys", func() {
+			sorted := make([][]byte, 0, len(keys))
+
+			tree.Visit(func(key []byte, _ *int) bool {
+				sorted = append(sorted, append([]byte(nil), key...))
+
+				return false
+			})
+
+			check(sorted)
+		})
+
+		Convey("When the tree has a bloom filter", func() {
+			tree.SetFilter(a, 1000, 10)
+
+			check(keys)
+		})
+
+		Convey("When the output is too short", func() {
+			So(func() { tree.SearchBatch(keys, make([]*int, 1)) }, ShouldPanic)
+		})
+	})
+
+	Convey("Given an empty tree", t, func() {
+		out := []*int{new(int)}
+
+		(&art.Tree[int]{}).SearchBatch([][]byte{[]byte("key")}, out)
+
+		So(out[0], ShouldBeNil)
+	})
+}
+
+func BenchmarkTree_SearchBatch(b *testing.B) {
+	b.ReportAllocs()
+
+	a := new(arena.Arena)
+	tree := arena.New(a, art.Tree[int]{})
+
+	keys := make([][]byte, 10000)
+
+	for i := range keys {
+		keys[i] = []byte("/tenants/" + strconv.Itoa(i%10) + "/users/" + strconv.Itoa(i))
+		tree.Insert(a, keys[i], i)
+	}
+
+	batch := keys[:100]
+	out := make([]*int, len(batch))
+
+	b.Run("Search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j, key := range batch {
+				out[j] = tree.Search(key)
+			}
+		}
+	})
+
+	b.Run("SearchBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.SearchBatch(batch, out)
+		}
+	})
+
+	sorted := slices.Clone(batch)
+	slices.SortFunc(sorted, bytes.Compare)
+
+	b.Run("SearchBatch/Sorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.SearchBatch(sorted, out)
+		}
+	})
+}
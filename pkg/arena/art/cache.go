@@ -0,0 +1,141 @@
+package art
+
+import (
+	"hash/maphash"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/arena/slice"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// cacheProbes is the number of slots probed for a key in the lookup cache.
+const cacheProbes = 4
+
+// cache is an open-addressing cache of the leaves found by [Tree.Search].
+//
+// It is allocated in an arena, like the nodes, so that the tree itself can live
+// in the memory of an arena, which the GC doesn't scan.
+type cache[T any] struct {
+	slots        slice.Slice[cacheSlot[T]]
+	seed         maphash.Seed
+	gen          uint64 // The generation of the tree when the slots were filled.
+	hits, misses int
+}
+
+type cacheSlot[T any] struct {
+	hash uint64
+	leaf *node.Leaf[T] // Nil if the slot is empty.
+}
+
+// SetCache maintains a lookup cache of up to size recently found keys, rounded up to
+// a power of two, so that [Tree.Search] can return the hot keys of a skewed workload
+// without traversing any node.
+//
+// The cache maps the hash of a key to its leaf, and is consulted before descending
+// the tree. It is invalidated whenever a key is added or removed, so it pays off for
+// read-mostly trees only. Replacing the value of a key keeps its leaf, and so its
+// cache entry. The cache is allocated from a, like the nodes of the tree, and is
+// bypassed once the tree is frozen, since it isn't safe for concurrent use, see
+// [Tree.Freeze].
+//
+// A non-positive size removes the cache.
+//
+// Example:
+//
+//	t.SetCache(a, 1024)
+//
+//	v := t.Search(key)
+//
+//	hits, misses := t.CacheStats()
+func (t *Tree[T]) SetCache(a arena.Allocator, size int) {
+	if size <= 0 {
+		t.cache = nil
+		return
+	}
+
+	n := 1
+	for n < size {
+		n <<= 1
+	}
+
+	c := arena.New(a, cache[T]{slots: slice.Make[cacheSlot[T]](a, n), seed: maphash.MakeSeed(), gen: t.gen})
+	c.clear()
+
+	t.cache = c
+}
+
+// CacheStats returns the number of searches answered by the lookup cache, and the
+// number of searches which had to traverse the tree, see [Tree.SetCache].
+//
+// It returns zeros if the tree has no cache.
+func (t *Tree[T]) CacheStats() (hits, misses int) {
+	if t.cache == nil {
+		return 0, 0
+	}
+
+	return t.cache.hits, t.cache.misses
+}
+
+// search searches for a key through the cache, filling it on a miss.
+func (c *cache[T]) search(t *Tree[T], key []byte) *T {
+	if c.gen != t.gen {
+		c.clear()
+		c.gen = t.gen
+	}
+
+	slots := c.slots.Raw()
+	h := maphash.Bytes(c.seed, key)
+	mask := uint64(len(slots) - 1)
+
+	for i := uint64(0); i < cacheProbes; i++ {
+		s := &slots[(h+i)&mask]
+		if s.leaf == nil {
+			break
+		}
+
+		if s.hash == h && s.leaf.Matches(key) {
+			c.hits++
+
+			return &s.leaf.Value
+		}
+	}
+
+	c.misses++
+
+	p := tree.Search(t.root, key)
+	if p == nil {
+		return nil
+	}
+
+	// Take the first empty slot, or evict the home slot of the key.
+	s := &slots[h&mask]
+
+	for i := uint64(0); i < cacheProbes; i++ {
+		if e := &slots[(h+i)&mask]; e.leaf == nil {
+			s = e
+			break
+		}
+	}
+
+	*s = cacheSlot[T]{h, leafOf(p)}
+
+	return p
+}
+
+// clear empties the slots of the cache.
+func (c *cache[T]) clear() {
+	slots := c.slots.Raw()
+	for i := range slots {
+		slots[i] = cacheSlot[T]{}
+	}
+}
+
+// leafOf returns the leaf holding the given value.
+func leafOf[T any](p *T) *node.Leaf[T] {
+	var l node.Leaf[T]
+
+	return xunsafe.ByteAdd[node.Leaf[T]](p, -int(unsafe.Offsetof(l.Value)))
+}
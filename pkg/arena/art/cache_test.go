@@ -0,0 +1,114 @@
+package art_test
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_Cache(t *testing.T) {
+	Convey("Given a tree with a lookup cache", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for i := 0; i < 1000; i++ {
+			tree.Insert(a, []byte("key"+strconv.Itoa(i)), i)
+		}
+
+		tree.SetCache(a, 100)
+
+		Convey("When searching the hot keys repeatedly", func() {
+			for n := 0; n < 10; n++ {
+				for i := 0; i < 10; i++ {
+					So(*tree.Search([]byte("key" + strconv.Itoa(i))), ShouldEqual, i)
+				}
+			}
+
+			Convey("Then most searches should hit the cache", func() {
+				hits, misses := tree.CacheStats()
+
+				So(hits, ShouldEqual, 90)
+				So(misses, ShouldEqual, 10)
+			})
+
+			Convey("Then replacing a value should keep its entry", func() {
+				tree.Insert(a, []byte("key1"), -1)
+
+				So(*tree.Search([]byte("key1")), ShouldEqual, -1)
+
+				hits, _ := tree.CacheStats()
+				So(hits, ShouldEqual, 91)
+			})
+
+			Convey("Then deleting a key should invalidate the cache", func() {
+				tree.Delete(a, []byte("key1"))
+
+				So(tree.Search([]byte("key1")), ShouldBeNil)
+				So(*tree.Search([]byte("key2")), ShouldEqual, 2)
+
+				hits, misses := tree.CacheStats()
+				So(hits, ShouldEqual, 90)
+				So(misses, ShouldEqual, 12)
+			})
+
+			Convey("Then removing the cache should reset the stats", func() {
+				tree.SetCache(a, 0)
+
+				So(*tree.Search([]byte("key1")), ShouldEqual, 1)
+
+				hits, misses := tree.CacheStats()
+				So(hits, ShouldEqual, 0)
+				So(misses, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When searching more keys than the cache holds", func() {
+			for n := 0; n < 3; n++ {
+				for i := 0; i < 1000; i++ {
+					So(*tree.Search([]byte("key" + strconv.Itoa(i))), ShouldEqual, i)
+				}
+			}
+
+			So(tree.Search([]byte("missing")), ShouldBeNil)
+		})
+
+		Convey("When the tree is cleared", func() {
+			tree.Search([]byte("key1"))
+			tree.Clear(a)
+
+			So(tree.Search([]byte("key1")), ShouldBeNil)
+		})
+
+		Convey("When the tree lives in arena memory", func() {
+			tree := arena.New(a, art.Tree[int]{})
+			tree.Insert(a, []byte("key1"), 1)
+			tree.SetCache(a, 100)
+
+			runtime.GC()
+
+			Convey("Then the cache should be kept in the arena", func() {
+				So(*tree.Search([]byte("key1")), ShouldEqual, 1)
+				So(*tree.Search([]byte("key1")), ShouldEqual, 1)
+
+				hits, misses := tree.CacheStats()
+				So(hits, ShouldEqual, 1)
+				So(misses, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the tree is frozen", func() {
+			f := tree.Freeze()
+
+			So(*f.Search([]byte("key1")), ShouldEqual, 1)
+
+			hits, misses := tree.CacheStats()
+			So(hits+misses, ShouldEqual, 0)
+		})
+	})
+}
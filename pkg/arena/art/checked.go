@@ -0,0 +1,75 @@
+package art
+
+import (
+	"errors"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// ErrConcurrentModification is returned when a tree is modified while it is being iterated.
+var ErrConcurrentModification = errors.New("art: tree modified during iteration")
+
+// VisitChecked visits the tree like [Tree.Visit], but stops the iteration with
+// [ErrConcurrentModification] as soon as the callback function inserts or deletes a key.
+//
+// Adding or removing keys may split, grow or shrink the nodes being traversed, so the
+// iteration could silently skip keys or visit them twice. Replacing the value of an
+// existing key does not change the structure of the tree and is allowed.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *Tree[T]) VisitChecked(cb func(key []byte, value *T) bool) (stopped bool, err error) {
+	return tree.RecursiveIter(t.root, t.checked(cb, &err)) && err == nil, err
+}
+
+// VisitPrefixChecked visits the tree with a prefix like [Tree.VisitPrefix], but stops
+// the iteration with [ErrConcurrentModification] as soon as the callback function
+// inserts or deletes a key.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *Tree[T]) VisitPrefixChecked(prefix []byte, cb func(key []byte, value *T) bool) (stopped bool, err error) {
+	return tree.IterPrefix(t.root, prefix, t.checked(cb, &err)) && err == nil, err
+}
+
+// checked wraps the callback function to stop the iteration and report an error
+// once the tree has been modified.
+func (t *Tree[T]) checked(cb func(key []byte, value *T) bool, err *error) func(key []byte, value *T) bool {
+	gen := t.gen
+
+	return func(key []byte, value *T) bool {
+		if cb(key, value) {
+			return true
+		}
+
+		if t.gen != gen {
+			*err = ErrConcurrentModification
+			return true
+		}
+
+		return false
+	}
+}
+
+// guard wraps the callback function to panic in debug mode once the tree has been
+// modified during the iteration.
+func (t *Tree[T]) guard(cb func(key []byte, value *T) bool) func(key []byte, value *T) bool {
+	if !debug.Enabled {
+		return cb
+	}
+
+	gen := t.gen
+
+	return func(key []byte, value *T) bool {
+		if cb(key, value) {
+			return true
+		}
+
+		if t.gen != gen {
+			panic(ErrConcurrentModification)
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,92 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+// TestTree_VisitChecked tests detecting modifications during iteration
+func TestTree_VisitChecked(t *testing.T) {
+	Convey("Given an ART tree with values", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for i := 0; i < 100; i++ {
+			tree.Insert(a, []byte(fmt.Sprintf("key:%03d", i)), i)
+		}
+
+		Convey("When visiting without modification", func() {
+			var n int
+			stopped, err := tree.VisitChecked(func(key []byte, value *int) bool {
+				n++
+				return false
+			})
+
+			So(err, ShouldBeNil)
+			So(stopped, ShouldBeFalse)
+			So(n, ShouldEqual, 100)
+		})
+
+		Convey("When the callback stops the iteration", func() {
+			stopped, err := tree.VisitPrefixChecked([]byte("key:05"), func(key []byte, value *int) bool {
+				return *value == 55
+			})
+
+			So(err, ShouldBeNil)
+			So(stopped, ShouldBeTrue)
+		})
+
+		Convey("When replacing values during iteration", func() {
+			stopped, err := tree.VisitChecked(func(key []byte, value *int) bool {
+				tree.Insert(a, key, *value*2)
+				return false
+			})
+
+			So(err, ShouldBeNil)
+			So(stopped, ShouldBeFalse)
+			So(*tree.Search([]byte("key:042")), ShouldEqual, 84)
+		})
+
+		Convey("When inserting keys during iteration", func() {
+			var n int
+			stopped, err := tree.VisitChecked(func(key []byte, value *int) bool {
+				n++
+				tree.Insert(a, append(key, '!'), *value)
+				return false
+			})
+
+			So(err, ShouldEqual, art.ErrConcurrentModification)
+			So(stopped, ShouldBeFalse)
+			So(n, ShouldEqual, 1)
+		})
+
+		Convey("When deleting keys during prefix iteration", func() {
+			stopped, err := tree.VisitPrefixChecked([]byte("key:01"), func(key []byte, value *int) bool {
+				tree.Delete(a, []byte("key:099"))
+				return false
+			})
+
+			So(err, ShouldEqual, art.ErrConcurrentModification)
+			So(stopped, ShouldBeFalse)
+		})
+
+		if debug.Enabled {
+			Convey("Then modifying during Visit should panic in debug mode", func() {
+				So(func() {
+					tree.Visit(func(key []byte, value *int) bool {
+						tree.Delete(a, key)
+						return false
+					})
+				}, ShouldPanicWith, art.ErrConcurrentModification)
+			})
+		}
+	})
+}
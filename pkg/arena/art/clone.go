@@ -0,0 +1,41 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// CloneInto returns a copy of the tree whose nodes, keys and values are all
+// allocated from dst, e.g. to migrate a long-lived tree out of a short-lived
+// arena before resetting it.
+//
+// dst can be any allocator, such as an [arena.Arena] or an [arena.Recycled].
+// The values are copied shallowly with their flags, see [Tree.SetFlags], and the copy
+// keeps the maximum key length and the shrink policy of the source tree, but neither
+// its journal nor its frozen state.
+//
+// The nodes are rebuilt by inserting the keys in order, so the copy is as compact
+// as a freshly built tree even if the source has grown and shrunk its nodes.
+//
+// Example:
+//
+//	scratch := new(arena.Arena)
+//	t := buildIndex(scratch)
+//
+//	index := art.CloneInto(longLived, t)
+//	scratch.Reset()
+func CloneInto[T any](dst arena.Allocator, src *Tree[T]) *Tree[T] {
+	t := &Tree[T]{maxKeyLen: src.maxKeyLen, shrink: src.shrink, shrinkSet: src.shrinkSet}
+
+	tree.RecursiveIter(src.root, src.guard(func(key []byte, value *T) bool {
+		t.Insert(dst, key, *value)
+
+		if flags := leafOf(value).Flags; flags != 0 {
+			t.SetFlags(key, flags)
+		}
+
+		return false
+	}))
+
+	return t
+}
@@ -0,0 +1,83 @@
+package art_test
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestCloneInto(t *testing.T) {
+	Convey("Given a tree on a scratch arena", t, func() {
+		scratch := new(arena.Arena)
+		defer runtime.KeepAlive(scratch) // The trees are on the heap, keep their nodes alive.
+		src := &art.Tree[int]{}
+		src.SetMaxKeyLen(16)
+		src.SetShrinkPolicy(art.NeverShrink)
+
+		for i := 0; i < 1000; i++ {
+			src.Insert(scratch, []byte(strconv.Itoa(i)), i)
+		}
+
+		for i := 0; i < 1000; i += 3 {
+			src.Delete(scratch, []byte(strconv.Itoa(i)))
+		}
+
+		Convey("When cloning it into an arena", func() {
+			dst := new(arena.Arena)
+			defer runtime.KeepAlive(dst) // The trees are on the heap, keep their nodes alive.
+			t := art.CloneInto(dst, src)
+
+			Convey("Then it should have the same contents and settings", func() {
+				So(t.Len(), ShouldEqual, src.Len())
+				So(t.MaxKeyLen(), ShouldEqual, 16)
+				So(t.ShrinkPolicy(), ShouldResemble, art.NeverShrink)
+
+				src.Visit(func(key []byte, value *int) bool {
+					So(*t.Search(key), ShouldEqual, *value)
+
+					return false
+				})
+			})
+
+			Convey("Then it should survive resetting the source arena", func() {
+				want := src.Len()
+
+				scratch.Reset()
+
+				So(t.Len(), ShouldEqual, want)
+				So(*t.Search([]byte("1")), ShouldEqual, 1)
+				So(t.Search([]byte("3")), ShouldBeNil)
+				So(string(t.Maximum().Key.Raw()), ShouldEqual, "998")
+			})
+
+			Convey("Then it should be independent of the source", func() {
+				t.Insert(dst, []byte("new"), -1)
+				*t.Search([]byte("1")) = 100
+
+				So(src.Search([]byte("new")), ShouldBeNil)
+				So(*src.Search([]byte("1")), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When cloning it into a recycling allocator", func() {
+			r := new(arena.Recycled)
+			defer runtime.KeepAlive(r) // The trees are on the heap, keep their nodes alive.
+
+			t := art.CloneInto(r, src)
+
+			So(t.Len(), ShouldEqual, src.Len())
+		})
+	})
+
+	Convey("Given an empty tree", t, func() {
+		t := art.CloneInto(new(arena.Arena), &art.Tree[string]{})
+
+		So(t.Len(), ShouldEqual, 0)
+		So(t.Minimum(), ShouldBeNil)
+	})
+}
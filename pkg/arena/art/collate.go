@@ -0,0 +1,212 @@
+package art
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// Collation is a custom order of the 256 byte values, used by a [CollatedTree] to
+// order its keys.
+//
+// The zero Collation is not valid, use [NewCollation] or [FoldedASCII].
+type Collation struct {
+	rank  [256]byte // The rank of each byte in the order.
+	bytes [256]byte // The byte of each rank.
+}
+
+// NewCollation returns the collation ordering the given bytes first, in the given order,
+// followed by the remaining bytes in their natural order.
+//
+// It returns an error if a byte is listed more than once.
+func NewCollation(order []byte) (*Collation, error) {
+	var c Collation
+	var seen [256]bool
+
+	for i, b := range order {
+		if seen[b] {
+			return nil, fmt.Errorf("art: byte %q is listed twice in the collation order", b)
+		}
+
+		seen[b] = true
+		c.bytes[i] = b
+	}
+
+	i := len(order)
+
+	for b := 0; b < 256; b++ {
+		if !seen[b] {
+			c.bytes[i] = byte(b)
+			i++
+		}
+	}
+
+	for r, b := range c.bytes {
+		c.rank[b] = byte(r)
+	}
+
+	return &c, nil
+}
+
+// FoldedASCII returns the collation interleaving the upper and lower case ASCII letters,
+// each upper case letter coming right before its lower case, e.g. "Apple" < "apple" < "Banana".
+//
+// This is an uppercase-first interleaved order, not a case-insensitive one: the keys are
+// compared byte by byte, so the case of a letter decides before the following bytes, e.g.
+// "Az" < "aa" and "Apple2" < "apple1".
+//
+// The letters take the place of the lower case letters in the natural order.
+func FoldedASCII() *Collation {
+	order := make([]byte, 256)
+	for i := range order {
+		order[i] = byte(i)
+	}
+
+	fold := func(b byte) byte {
+		if 'A' <= b && b <= 'Z' {
+			return b + 'a' - 'A'
+		}
+
+		return b
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return fold(order[i]) < fold(order[j]) })
+
+	c, _ := NewCollation(order)
+
+	return c
+}
+
+// Compare compares a and b in the order of the collation, like [bytes.Compare].
+func (c *Collation) Compare(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if x, y := c.rank[a[i]], c.rank[b[i]]; x != y {
+			if x < y {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// encode appends the ranks of the bytes of key to dst.
+func (c *Collation) encode(dst, key []byte) []byte {
+	if key == nil {
+		return nil
+	}
+
+	for _, b := range key {
+		dst = append(dst, c.rank[b])
+	}
+
+	return dst
+}
+
+// decode appends the bytes of the ranks of key to dst.
+func (c *Collation) decode(dst, key []byte) []byte {
+	for _, r := range key {
+		dst = append(dst, c.bytes[r])
+	}
+
+	return dst
+}
+
+// CollatedTree is an Adaptive Radix Tree whose keys are ordered by a custom [Collation]
+// instead of their bytes, e.g. with the upper and lower case ASCII letters interleaved.
+//
+// The keys are stored with each byte replaced by its rank in the collation, so that the
+// nodes order them by collation without any change to their lookups, and are decoded
+// again when they are visited. The keys keep their exact bytes: "Apple" and "apple" are
+// distinct keys, only their order changes.
+//
+// Example:
+//
+//	t := art.NewCollatedTree[int](art.FoldedASCII())
+//
+//	t.Insert(a, []byte("banana"), 1)
+//	t.Insert(a, []byte("Apple"), 2) // Visited first.
+type CollatedTree[T any] struct {
+	tree Tree[T]
+	c    *Collation
+}
+
+// NewCollatedTree returns an empty tree ordering its keys by the given collation.
+func NewCollatedTree[T any](c *Collation) *CollatedTree[T] {
+	return &CollatedTree[T]{c: c}
+}
+
+// Collation returns the collation ordering the keys of the tree.
+func (t *CollatedTree[T]) Collation() *Collation { return t.c }
+
+// Len returns the number of elements in the tree.
+func (t *CollatedTree[T]) Len() int { return t.tree.Len() }
+
+// Search searches for a value in the tree.
+//
+// It returns the value if found, otherwise nil.
+func (t *CollatedTree[T]) Search(key []byte) *T {
+	var buf [128]byte
+
+	return t.tree.Search(t.c.encode(buf[:0], key))
+}
+
+// Insert inserts a new value into the tree.
+//
+// It returns the old value if the key matches the existing key, or nil if the key is inserted.
+func (t *CollatedTree[T]) Insert(a arena.Allocator, key []byte, value T) *T {
+	var buf [128]byte
+
+	return t.tree.Insert(a, t.c.encode(buf[:0], key), value)
+}
+
+// Delete deletes a value from the tree.
+//
+// It returns the old value if the key matches the existing key, or nil if the key is not found.
+func (t *CollatedTree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
+	var buf [128]byte
+
+	return t.tree.Delete(a, t.c.encode(buf[:0], key))
+}
+
+// Visit visits the tree in the order of its collation.
+//
+// The key passed to the callback function is only valid until it returns.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *CollatedTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	var buf []byte
+
+	return t.tree.Visit(func(key []byte, value *T) bool {
+		buf = t.c.decode(buf[:0], key)
+
+		return cb(buf, value)
+	})
+}
+
+// VisitPrefix visits the keys with a prefix in the order of its collation.
+//
+// The key passed to the callback function is only valid until it returns.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *CollatedTree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
+	var buf []byte
+
+	return t.tree.VisitPrefix(t.c.encode(nil, prefix), func(key []byte, value *T) bool {
+		buf = t.c.decode(buf[:0], key)
+
+		return cb(buf, value)
+	})
+}
@@ -0,0 +1,151 @@
+package art_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func ExampleCollatedTree() {
+	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+
+	t := art.NewCollatedTree[int](art.FoldedASCII())
+
+	for i, key := range []string{"banana", "apple", "Cherry", "Apple", "_"} {
+		t.Insert(a, []byte(key), i)
+	}
+
+	t.Visit(func(key []byte, value *int) bool {
+		fmt.Println(string(key), *value)
+
+		return false
+	})
+
+	// Output:
+	// _ 4
+	// Apple 3
+	// apple 1
+	// banana 0
+	// Cherry 2
+}
+
+func TestCollation(t *testing.T) {
+	Convey("Given a custom collation", t, func() {
+		c, err := art.NewCollation([]byte("zyx"))
+		So(err, ShouldBeNil)
+
+		So(c.Compare([]byte("z"), []byte("a")), ShouldEqual, -1)
+		So(c.Compare([]byte("x"), []byte("y")), ShouldEqual, 1)
+		So(c.Compare([]byte("a"), []byte("b")), ShouldEqual, -1)
+		So(c.Compare([]byte("ab"), []byte("ab")), ShouldEqual, 0)
+		So(c.Compare([]byte("ab"), []byte("abz")), ShouldEqual, -1)
+		So(c.Compare([]byte("abz"), []byte("ab")), ShouldEqual, 1)
+	})
+
+	Convey("Given a collation listing a byte twice", t, func() {
+		_, err := art.NewCollation([]byte("abca"))
+
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Given the folded ASCII collation", t, func() {
+		c := art.FoldedASCII()
+
+		So(c.Compare([]byte("Apple"), []byte("apple")), ShouldEqual, -1)
+		So(c.Compare([]byte("apple"), []byte("Banana")), ShouldEqual, -1)
+		So(c.Compare([]byte("Z"), []byte("{")), ShouldEqual, -1)
+		So(c.Compare([]byte("["), []byte("A")), ShouldEqual, -1)
+
+		// The case is compared first, byte by byte.
+		So(c.Compare([]byte("Az"), []byte("aa")), ShouldEqual, -1)
+		So(c.Compare([]byte("Apple2"), []byte("apple1")), ShouldEqual, -1)
+	})
+}
+
+func TestCollatedTree(t *testing.T) {
+	Convey("Given a collated tree with random keys", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		c := art.FoldedASCII()
+		tree := art.NewCollatedTree[int](c)
+
+		So(tree.Collation(), ShouldEqual, c)
+
+		r := rand.New(rand.NewSource(42))
+		keys := map[string]int{"": -1}
+
+		for i := 0; i < 500; i++ {
+			key := make([]byte, r.Intn(6))
+			for j := range key {
+				key[j] = "aAbBzZ_\x00\xff"[r.Intn(9)]
+			}
+
+			keys[string(key)] = i
+			tree.Insert(a, key, i)
+		}
+
+		So(tree.Len(), ShouldEqual, len(keys))
+
+		Convey("Then the keys should be visited in collation order", func() {
+			var want []string
+			for key := range keys {
+				want = append(want, key)
+			}
+
+			sort.Slice(want, func(i, j int) bool { return c.Compare([]byte(want[i]), []byte(want[j])) < 0 })
+
+			var got []string
+
+			tree.Visit(func(key []byte, value *int) bool {
+				got = append(got, string(key))
+				So(*value, ShouldEqual, keys[string(key)])
+
+				return false
+			})
+
+			So(got, ShouldResemble, want)
+		})
+
+		Convey("Then the keys should be found by their exact bytes", func() {
+			for key, v := range keys {
+				So(*tree.Search([]byte(key)), ShouldEqual, v)
+			}
+		})
+
+		Convey("When visiting a prefix", func() {
+			var got []string
+
+			tree.VisitPrefix([]byte("aB"), func(key []byte, _ *int) bool {
+				got = append(got, string(key))
+
+				return false
+			})
+
+			So(got, ShouldNotBeEmpty)
+
+			for i, key := range got {
+				So(key[:2], ShouldEqual, "aB")
+
+				if i > 0 {
+					So(c.Compare([]byte(got[i-1]), []byte(key)), ShouldEqual, -1)
+				}
+			}
+		})
+
+		Convey("When deleting the keys", func() {
+			for key, v := range keys {
+				So(*tree.Delete(a, []byte(key)), ShouldEqual, v)
+			}
+
+			So(tree.Len(), ShouldEqual, 0)
+		})
+	})
+}
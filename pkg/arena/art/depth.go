@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// VisitPrefixDepth visits the tree with a prefix, reporting the depth of each leaf.
+//
+// The depth is the number of leading key bytes that were matched inside the
+// compressed prefixes and branch bytes of the inner nodes on the path to the leaf,
+// the rest of the key is only stored in the leaf itself. Autocomplete UIs can use
+// it to highlight key[:depth] without recomputing common prefix lengths for every
+// result.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *Tree[T]) VisitPrefixDepth(prefix []byte, cb func(key []byte, value *T, depth int) bool) bool {
+	return tree.IterPrefixDepth(t.root, prefix, func(l *node.Leaf[T], depth int) bool {
+		return cb(l.Key.Raw(), &l.Value, depth)
+	})
+}
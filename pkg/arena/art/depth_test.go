@@ -0,0 +1,93 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+// TestTree_VisitPrefixDepth tests the VisitPrefixDepth and AllPrefixDepth methods
+func TestTree_VisitPrefixDepth(t *testing.T) {
+	Convey("Given an ART tree with values", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		tree.Insert(a, []byte("apple"), 1)
+		tree.Insert(a, []byte("apply"), 2)
+		tree.Insert(a, []byte("apricot"), 3)
+		tree.Insert(a, []byte("banana"), 4)
+		tree.Insert(a, []byte("ban"), 5)
+
+		Convey("When visiting a prefix with depth", func() {
+			depths := make(map[string]int)
+
+			interrupted := tree.VisitPrefixDepth([]byte("ap"), func(key []byte, value *int, depth int) bool {
+				depths[string(key)] = depth
+				return false
+			})
+
+			So(interrupted, ShouldBeFalse)
+			So(depths, ShouldResemble, map[string]int{
+				"apple":   5,
+				"apply":   5,
+				"apricot": 3,
+			})
+		})
+
+		Convey("When visiting a key which ends inside the tree", func() {
+			depths := make(map[string]int)
+
+			tree.VisitPrefixDepth([]byte("ban"), func(key []byte, value *int, depth int) bool {
+				depths[string(key)] = depth
+				return false
+			})
+
+			So(depths, ShouldResemble, map[string]int{
+				"ban":    3,
+				"banana": 4,
+			})
+		})
+
+		Convey("When visiting a missing prefix", func() {
+			visited := tree.VisitPrefixDepth([]byte("cherry"), func(key []byte, value *int, depth int) bool {
+				panic("unexpected")
+			})
+
+			So(visited, ShouldBeFalse)
+		})
+
+		Convey("When iterating a prefix with depth", func() {
+			depths := make(map[string]int)
+
+			for leaf, depth := range tree.AllPrefixDepth([]byte("app")) {
+				So(depth, ShouldBeLessThanOrEqualTo, leaf.Key.Len())
+				depths[string(leaf.Key.Raw())] = depth
+			}
+
+			So(depths, ShouldResemble, map[string]int{
+				"apple": 5,
+				"apply": 5,
+			})
+		})
+
+		Convey("When iterating with early termination", func() {
+			var n int
+
+			for range tree.AllPrefixDepth(nil) {
+				n++
+				if n == 2 {
+					break
+				}
+			}
+
+			So(n, ShouldEqual, 2)
+		})
+	})
+}
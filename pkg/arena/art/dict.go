@@ -0,0 +1,188 @@
+package art
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// DictTree is an Adaptive Radix Tree which stores the keys starting with a registered
+// prefix as a reference to the prefix followed by the rest of the key.
+//
+// Deeply namespaced keys, such as "/tenants/{uuid}/resources/...", repeat the same
+// long prefix in every leaf. Registering the prefix once with [DictTree.AddDictPrefix]
+// cuts the storage of each of those keys down to a few bytes plus its suffix.
+//
+// The keys are encoded with the longest registered prefix they start with, and are
+// decoded again when they are visited. As a consequence, the keys are visited in
+// order within each registered prefix, but the keys without a registered prefix come
+// first, followed by the keys of each registered prefix in registration order.
+//
+// Example:
+//
+//	var t art.DictTree[int]
+//
+//	t.AddDictPrefix(a, []byte("/tenants/6ba7b810-9dad-11d1-80b4-00c04fd430c8/resources/"))
+//	t.Insert(a, []byte("/tenants/6ba7b810-9dad-11d1-80b4-00c04fd430c8/resources/disk0"), 1)
+type DictTree[T any] struct {
+	tree Tree[T]
+	dict [][]byte
+}
+
+// Len returns the number of elements in the tree.
+func (t *DictTree[T]) Len() int {
+	return t.tree.Len()
+}
+
+// DictPrefixes returns the registered prefixes in registration order.
+func (t *DictTree[T]) DictPrefixes() [][]byte {
+	return t.dict
+}
+
+// AddDictPrefix registers a prefix whose keys are stored as a reference to it.
+//
+// The keys already in the tree which start with the prefix are re-encoded, unless
+// they start with a longer registered prefix. Registering a prefix again does nothing.
+func (t *DictTree[T]) AddDictPrefix(a arena.AllocatorExt, prefix []byte) {
+	for _, p := range t.dict {
+		if bytes.Equal(p, prefix) {
+			return
+		}
+	}
+
+	// All the keys whose longest registered prefix will be the new one share its current encoding.
+	type entry struct {
+		key   []byte
+		value T
+	}
+
+	var moved []entry
+
+	t.tree.VisitPrefix(t.encode(nil, prefix), func(key []byte, value *T) bool {
+		moved = append(moved, entry{t.decode(nil, key), *value})
+
+		return false
+	})
+
+	for _, e := range moved {
+		t.tree.Delete(a, t.encode(nil, e.key))
+	}
+
+	t.dict = append(t.dict, bytes.Clone(prefix))
+
+	for _, e := range moved {
+		t.tree.Insert(a, t.encode(nil, e.key), e.value)
+	}
+}
+
+// Search searches for a value in the tree.
+//
+// It returns the value if found, otherwise nil.
+func (t *DictTree[T]) Search(key []byte) *T {
+	var buf [128]byte
+
+	return t.tree.Search(t.encode(buf[:0], key))
+}
+
+// Insert inserts a new value into the tree.
+//
+// It returns the old value if the key matches the existing key, or nil if the key is inserted.
+func (t *DictTree[T]) Insert(a arena.Allocator, key []byte, value T) *T {
+	var buf [128]byte
+
+	return t.tree.Insert(a, t.encode(buf[:0], key), value)
+}
+
+// Delete deletes a value from the tree.
+//
+// It returns the old value if the key matches the existing key, or nil if the key is not found.
+func (t *DictTree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
+	var buf [128]byte
+
+	return t.tree.Delete(a, t.encode(buf[:0], key))
+}
+
+// Visit visits the tree, see [DictTree] for the order of the keys.
+//
+// The key passed to the callback function is only valid until it returns.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *DictTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	var buf []byte
+
+	return t.tree.Visit(func(key []byte, value *T) bool {
+		buf = t.decode(buf[:0], key)
+
+		return cb(buf, value)
+	})
+}
+
+// VisitPrefix visits the keys with a prefix, see [DictTree] for the order of the keys.
+//
+// The key passed to the callback function is only valid until it returns.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *DictTree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
+	var buf []byte
+
+	visit := func(key []byte, value *T) bool {
+		buf = t.decode(buf[:0], key)
+
+		return cb(buf, value)
+	}
+
+	// The keys with the prefix may be encoded with the longest registered prefix
+	// of the prefix itself, or with any longer registered prefix starting with it.
+	if t.tree.VisitPrefix(t.encode(nil, prefix), visit) {
+		return true
+	}
+
+	for i, p := range t.dict {
+		if len(p) > len(prefix) && bytes.HasPrefix(p, prefix) {
+			if t.tree.VisitPrefix(binary.AppendUvarint(nil, uint64(i)+1), visit) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// longest returns the index of the longest registered prefix of key, or -1 if none.
+func (t *DictTree[T]) longest(key []byte) int {
+	n := -1
+
+	for i, p := range t.dict {
+		if bytes.HasPrefix(key, p) && (n < 0 || len(p) > len(t.dict[n])) {
+			n = i
+		}
+	}
+
+	return n
+}
+
+// encode appends the encoded key to dst, which is the uvarint of the index of its
+// longest registered prefix plus one, or zero if none, followed by the rest of the key.
+func (t *DictTree[T]) encode(dst, key []byte) []byte {
+	i := t.longest(key)
+	if i < 0 {
+		return append(append(dst, 0), key...)
+	}
+
+	dst = binary.AppendUvarint(dst, uint64(i)+1)
+
+	return append(dst, key[len(t.dict[i]):]...)
+}
+
+// decode appends the key of an encoded key to dst.
+func (t *DictTree[T]) decode(dst, key []byte) []byte {
+	i, n := binary.Uvarint(key)
+	if i > 0 {
+		dst = append(dst, t.dict[i-1]...)
+	}
+
+	return append(dst, key[n:]...)
+}
@@ -0,0 +1,134 @@
+package art_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func ExampleDictTree() {
+	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+
+	var t art.DictTree[int]
+
+	t.AddDictPrefix(a, []byte("/tenants/42/resources/"))
+
+	t.Insert(a, []byte("/tenants/42/resources/disk0"), 1)
+	t.Insert(a, []byte("/tenants/42/resources/disk1"), 2)
+	t.Insert(a, []byte("/tenants/7/resources/disk0"), 3)
+
+	fmt.Println(*t.Search([]byte("/tenants/42/resources/disk1")))
+
+	t.Visit(func(key []byte, value *int) bool {
+		fmt.Println(string(key), *value)
+
+		return false
+	})
+
+	// Output:
+	// 2
+	// /tenants/7/resources/disk0 3
+	// /tenants/42/resources/disk0 1
+	// /tenants/42/resources/disk1 2
+}
+
+func TestDictTree(t *testing.T) {
+	Convey("Given a dict tree with random keys", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		r := rand.New(rand.NewSource(42))
+
+		var tree art.DictTree[int]
+
+		parts := []string{"/t/", "a", "b", "/r/", "x"}
+		want := make(map[string]int)
+
+		randomKey := func() string {
+			var b strings.Builder
+			for n := r.Intn(6); n > 0; n-- {
+				b.WriteString(parts[r.Intn(len(parts))])
+			}
+
+			return b.String()
+		}
+
+		insert := func(n int) {
+			for i := 0; i < n; i++ {
+				k := randomKey()
+				tree.Insert(a, []byte(k), i)
+				want[k] = i
+			}
+		}
+
+		check := func() {
+			So(tree.Len(), ShouldEqual, len(want))
+
+			for k, v := range want {
+				p := tree.Search([]byte(k))
+				So(p, ShouldNotBeNil)
+				So(*p, ShouldEqual, v)
+			}
+
+			for _, prefix := range []string{"", "/t/", "/t/a", "/t/a/r/", "a", "ab", "/r/x"} {
+				var keys, got []string
+
+				for k := range want {
+					if strings.HasPrefix(k, prefix) {
+						keys = append(keys, k)
+					}
+				}
+
+				tree.VisitPrefix([]byte(prefix), func(key []byte, value *int) bool {
+					So(*value, ShouldEqual, want[string(key)])
+
+					got = append(got, string(key))
+
+					return false
+				})
+
+				sort.Strings(keys)
+				sort.Strings(got)
+
+				So(got, ShouldResemble, keys)
+			}
+		}
+
+		insert(200)
+
+		Convey("The keys are re-encoded when prefixes are registered", func() {
+			for _, prefix := range []string{"/t/a", "/t/", "/t/a/r/", "ab", "/t/"} {
+				tree.AddDictPrefix(a, []byte(prefix))
+
+				check()
+			}
+
+			So(tree.DictPrefixes(), ShouldHaveLength, 4)
+
+			Convey("And the keys can be inserted and deleted", func() {
+				insert(200)
+				check()
+
+				for k := range want {
+					if r.Intn(2) == 0 {
+						So(*tree.Delete(a, []byte(k)), ShouldEqual, want[k])
+
+						delete(want, k)
+					}
+				}
+
+				check()
+
+				So(tree.Delete(a, []byte("/t/zzz")), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// Equal returns true if both trees contain the same keys with equal values according to eq.
+//
+// Nodes shared by both trees are not traversed.
+func Equal[T any](a, b *Tree[T], eq func(T, T) bool) bool {
+	if a.n != b.n {
+		return false
+	}
+
+	return !tree.RecursiveDiff(a.root, b.root, eq, func(key []byte, old, new *T) bool { return true })
+}
+
+// Diff calls the callback function for every key whose value differs between the
+// trees a and b, in key order.
+//
+// old is nil if the key was added in b, new is nil if the key was removed from a,
+// otherwise the value of the key has been changed.
+//
+// Example:
+//
+//	art.Diff(prev, curr, func(key []byte, old, new *string) {
+//	    switch {
+//	    case old == nil:
+//	        fmt.Printf("+ %s = %s\n", key, *new)
+//	    case new == nil:
+//	        fmt.Printf("- %s\n", key)
+//	    default:
+//	        fmt.Printf("~ %s = %s -> %s\n", key, *old, *new)
+//	    }
+//	})
+func Diff[T comparable](a, b *Tree[T], cb func(key []byte, old, new *T)) {
+	DiffFunc(a, b, func(x, y T) bool { return x == y }, cb)
+}
+
+// DiffFunc is like [Diff] but compares the values with the eq function.
+func DiffFunc[T any](a, b *Tree[T], eq func(T, T) bool, cb func(key []byte, old, new *T)) {
+	tree.RecursiveDiff(a.root, b.root, eq, func(key []byte, old, new *T) bool {
+		cb(key, old, new)
+		return false
+	})
+}
@@ -0,0 +1,153 @@
+package art_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+type change struct {
+	Key      string
+	Old, New int
+}
+
+func diff(a, b *art.Tree[int]) (changes []change) {
+	art.Diff(a, b, func(key []byte, old, new *int) {
+		c := change{Key: string(key), Old: -1, New: -1}
+		if old != nil {
+			c.Old = *old
+		}
+		if new != nil {
+			c.New = *new
+		}
+		changes = append(changes, c)
+	})
+
+	return
+}
+
+// TestDiff tests comparing two trees
+func TestDiff(t *testing.T) {
+	eq := func(x, y int) bool { return x == y }
+
+	Convey("Given two ART trees", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		prev, curr := &art.Tree[int]{}, &art.Tree[int]{}
+
+		for _, key := range []string{"app", "apple", "banana", "band", "can"} {
+			prev.Insert(a, []byte(key), len(key))
+			curr.Insert(a, []byte(key), len(key))
+		}
+
+		Convey("When the trees have the same values", func() {
+			So(art.Equal(prev, curr, eq), ShouldBeTrue)
+			So(art.Equal(prev, prev, eq), ShouldBeTrue)
+			So(diff(prev, curr), ShouldBeEmpty)
+		})
+
+		Convey("When the trees have different values", func() {
+			curr.Insert(a, []byte("apple"), 0)
+			curr.Insert(a, []byte("ban"), 3)
+			curr.Insert(a, []byte("bandana"), 7)
+			curr.Delete(a, []byte("can"))
+			curr.Delete(a, []byte("app"))
+
+			So(art.Equal(prev, curr, eq), ShouldBeFalse)
+			So(diff(prev, curr), ShouldResemble, []change{
+				{"app", 3, -1},
+				{"apple", 5, 0},
+				{"ban", -1, 3},
+				{"bandana", -1, 7},
+				{"can", 3, -1},
+			})
+
+			Convey("Then the reverse diff should be symmetric", func() {
+				So(diff(curr, prev), ShouldResemble, []change{
+					{"app", -1, 3},
+					{"apple", 0, 5},
+					{"ban", 3, -1},
+					{"bandana", 7, -1},
+					{"can", -1, 3},
+				})
+			})
+		})
+
+		Convey("When comparing with an empty tree", func() {
+			So(art.Equal(prev, &art.Tree[int]{}, eq), ShouldBeFalse)
+			So(diff(&art.Tree[int]{}, prev), ShouldHaveLength, 5)
+			So(diff(prev, &art.Tree[int]{}), ShouldHaveLength, 5)
+		})
+
+		Convey("When comparing with a custom function", func() {
+			curr.Insert(a, []byte("apple"), 15)
+
+			mod10 := func(x, y int) bool { return x%10 == y%10 }
+
+			So(art.Equal(prev, curr, mod10), ShouldBeTrue)
+
+			var n int
+			art.DiffFunc(prev, curr, mod10, func(key []byte, old, new *int) { n++ })
+			So(n, ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given two random ART trees", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		r := rand.New(rand.NewSource(42))
+		prev, curr := &art.Tree[int]{}, &art.Tree[int]{}
+		want := map[string]change{}
+
+		for i := 0; i < 2000; i++ {
+			key := fmt.Sprintf("%x", r.Intn(4096))
+
+			switch r.Intn(3) {
+			case 0:
+				prev.Insert(a, []byte(key), i)
+			case 1:
+				curr.Insert(a, []byte(key), i)
+			default:
+				prev.Insert(a, []byte(key), i)
+				curr.Insert(a, []byte(key), i)
+			}
+		}
+
+		prev.Visit(func(key []byte, value *int) bool {
+			want[string(key)] = change{string(key), *value, -1}
+			return false
+		})
+		curr.Visit(func(key []byte, value *int) bool {
+			c, ok := want[string(key)]
+			if !ok {
+				c = change{string(key), -1, -1}
+			}
+			if c.New = *value; c.Old == c.New {
+				delete(want, string(key))
+			} else {
+				want[string(key)] = c
+			}
+			return false
+		})
+
+		Convey("Then the diff should match a full comparison", func() {
+			changes := diff(prev, curr)
+
+			So(changes, ShouldHaveLength, len(want))
+
+			for i, c := range changes {
+				So(c, ShouldResemble, want[c.Key])
+
+				if i > 0 {
+					So(c.Key, ShouldBeGreaterThan, changes[i-1].Key)
+				}
+			}
+		})
+	})
+}
@@ -79,6 +79,10 @@
 //	    return false
 //	})
 //
+// Keys must not be inserted or deleted while iterating, which panics when built
+// with the debug tag. Use [Tree.VisitChecked] to get [ErrConcurrentModification]
+// instead.
+//
 // ## Go 1.23+ Iterators (Optional)
 //
 //	// For Go 1.23+ users, more ergonomic iteration is available
@@ -114,6 +118,9 @@
 // The Tree type is not thread-safe. If multiple goroutines access the same tree
 // concurrently, external synchronization must be provided by the caller.
 //
+// A tree that is built once and then only read can be frozen with [Tree.Freeze].
+// The returned [FrozenTree] is safe for concurrent readers without synchronization.
+//
 // # Memory Safety
 //
 //   - All memory allocated through the arena must not be accessed after calling `arena.Reset()`
@@ -0,0 +1,231 @@
+package art
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// ErrBinaryKey is returned when encoding a key which is not valid UTF-8 as a JSON string.
+var ErrBinaryKey = errors.New("art: key is not valid UTF-8")
+
+// JSONKeys selects how the keys of a tree are encoded as the names of a JSON object.
+type JSONKeys int
+
+const (
+	// StringKeys encodes the keys as JSON strings, which requires valid UTF-8 keys.
+	StringKeys JSONKeys = iota
+	// Base64Keys encodes the keys in standard base64, which supports any binary key.
+	Base64Keys
+)
+
+// MarshalJSON implements [json.Marshaler].
+//
+// The tree is encoded as a JSON object of key to value, in key order, with the keys
+// encoded as [StringKeys]. It returns [ErrBinaryKey] if a key is not valid UTF-8,
+// use [Tree.AppendJSON] with [Base64Keys] for binary keys.
+//
+// There is no UnmarshalJSON method, since the tree can't allocate its nodes
+// without an arena, use [DecodeJSON] instead.
+func (t *Tree[T]) MarshalJSON() ([]byte, error) {
+	return t.AppendJSON(nil, StringKeys)
+}
+
+// AppendJSON appends the JSON encoding of the tree to b, see [Tree.MarshalJSON],
+// with the keys encoded as selected by keys.
+func (t *Tree[T]) AppendJSON(b []byte, keys JSONKeys) ([]byte, error) {
+	var err error
+
+	b = append(b, '{')
+
+	first := true
+
+	t.Visit(func(key []byte, value *T) bool {
+		if !first {
+			b = append(b, ',')
+		}
+
+		first = false
+
+		var name string
+
+		switch keys {
+		case Base64Keys:
+			name = base64.StdEncoding.EncodeToString(key)
+		default:
+			if !utf8.Valid(key) {
+				err = fmt.Errorf("%w: %q", ErrBinaryKey, key)
+
+				return true
+			}
+
+			name = string(key)
+		}
+
+		var v []byte
+
+		if v, err = json.Marshal(name); err != nil {
+			return true
+		}
+
+		b = append(append(b, v...), ':')
+
+		if v, err = json.Marshal(value); err != nil {
+			err = fmt.Errorf("art: marshal value of %q, %w", key, err)
+
+			return true
+		}
+
+		b = append(b, v...)
+
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '}'), nil
+}
+
+// DecodeJSON inserts the key-value pairs of a JSON object, encoded by [Tree.MarshalJSON]
+// or [Tree.AppendJSON] with the same keys encoding, into the tree.
+//
+// A JSON null is an empty tree. The existing keys of the tree are kept, unless replaced.
+func DecodeJSON[T any](a arena.Allocator, t *Tree[T], data []byte, keys JSONKeys) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := d.Token()
+	if err != nil {
+		return fmt.Errorf("art: decode JSON, %w", err)
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if tok != json.Delim('{') {
+		return fmt.Errorf("art: decode JSON, expected object, got %v", tok)
+	}
+
+	for d.More() {
+		if tok, err = d.Token(); err != nil {
+			return fmt.Errorf("art: decode JSON, %w", err)
+		}
+
+		name, _ := tok.(string)
+
+		var key []byte
+
+		switch keys {
+		case Base64Keys:
+			if key, err = base64.StdEncoding.DecodeString(name); err != nil {
+				return fmt.Errorf("art: decode JSON key %q, %w", name, err)
+			}
+
+			if key == nil {
+				key = []byte{}
+			}
+		default:
+			key = []byte(name)
+		}
+
+		var v T
+
+		if err = d.Decode(&v); err != nil {
+			return fmt.Errorf("art: decode JSON value of %q, %w", key, err)
+		}
+
+		if _, err = t.InsertE(a, key, v); err != nil {
+			return err
+		}
+	}
+
+	if _, err = d.Token(); err != nil {
+		return fmt.Errorf("art: decode JSON, %w", err)
+	}
+
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder].
+//
+// The tree is encoded as the number of keys, followed by the key and value of each
+// key in key order. The values must be encodable by [encoding/gob].
+//
+// There is no GobDecode method, since the tree can't allocate its nodes
+// without an arena, use [DecodeGob] instead.
+func (t *Tree[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	e := gob.NewEncoder(&buf)
+
+	if err := e.Encode(t.n); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	t.Visit(func(key []byte, value *T) bool {
+		if err = e.Encode(key); err != nil {
+			return true
+		}
+
+		if err = e.Encode(value); err != nil {
+			err = fmt.Errorf("art: encode value of %q, %w", key, err)
+
+			return true
+		}
+
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeGob inserts the key-value pairs encoded by [Tree.GobEncode] into the tree.
+//
+// The existing keys of the tree are kept, unless replaced.
+func DecodeGob[T any](a arena.Allocator, t *Tree[T], data []byte) error {
+	d := gob.NewDecoder(bytes.NewReader(data))
+
+	var n int
+
+	if err := d.Decode(&n); err != nil {
+		return fmt.Errorf("art: decode gob, %w", err)
+	}
+
+	for i := 0; i < n; i++ {
+		var key []byte
+
+		if err := d.Decode(&key); err != nil {
+			return fmt.Errorf("art: decode gob key, %w", err)
+		}
+
+		if key == nil {
+			key = []byte{}
+		}
+
+		var v T
+
+		if err := d.Decode(&v); err != nil {
+			return fmt.Errorf("art: decode gob value of %q, %w", key, err)
+		}
+
+		if _, err := t.InsertE(a, key, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package art_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+type fruit struct {
+	Name  string
+	Price int
+}
+
+func TestTree_JSON(t *testing.T) {
+	Convey("Given a tree of fruits", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[fruit]{}
+
+		for _, f := range []fruit{{"cherry", 7}, {"apple", 3}, {"banana", 1}} {
+			tree.Insert(a, []byte(f.Name), f)
+		}
+
+		golden, err := os.ReadFile("testdata/fruits.golden.json")
+		So(err, ShouldBeNil)
+
+		Convey("Then it should be encoded as the golden file", func() {
+			b, err := json.Marshal(tree)
+
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, string(bytes.TrimSpace(golden)))
+		})
+
+		Convey("Then the golden file should be decoded into an equal tree", func() {
+			decoded := &art.Tree[fruit]{}
+
+			So(art.DecodeJSON(a, decoded, golden, art.StringKeys), ShouldBeNil)
+			So(decoded.Len(), ShouldEqual, 3)
+			So(*decoded.Search([]byte("cherry")), ShouldResemble, fruit{"cherry", 7})
+		})
+	})
+
+	Convey("Given a tree with binary keys", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		tree.Insert(a, []byte{}, 1)
+		tree.Insert(a, []byte{0xff, 0x00}, 2)
+
+		Convey("Then encoding string keys should fail", func() {
+			_, err := json.Marshal(tree)
+
+			So(err, ShouldWrap, art.ErrBinaryKey)
+		})
+
+		Convey("Then the keys should be encoded in base64", func() {
+			b, err := tree.AppendJSON(nil, art.Base64Keys)
+
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `{"":1,"/wA=":2}`)
+
+			decoded := &art.Tree[int]{}
+
+			So(art.DecodeJSON(a, decoded, b, art.Base64Keys), ShouldBeNil)
+			So(decoded.Len(), ShouldEqual, 2)
+			So(*decoded.Search([]byte{}), ShouldEqual, 1)
+			So(*decoded.Search([]byte{0xff, 0x00}), ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given an invalid JSON", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		So(art.DecodeJSON(a, tree, []byte(`null`), art.StringKeys), ShouldBeNil)
+		So(art.DecodeJSON(a, tree, []byte(`[1]`), art.StringKeys), ShouldNotBeNil)
+		So(art.DecodeJSON(a, tree, []byte(`{"a":"b"}`), art.StringKeys), ShouldNotBeNil)
+		So(art.DecodeJSON(a, tree, []byte(`{"a":1`), art.StringKeys), ShouldNotBeNil)
+		So(art.DecodeJSON(a, tree, []byte(`{"!":1}`), art.Base64Keys), ShouldNotBeNil)
+	})
+}
+
+func TestTree_Gob(t *testing.T) {
+	Convey("Given a tree of fruits", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[fruit]{}
+
+		tree.Insert(a, []byte("apple"), fruit{"apple", 3})
+		tree.Insert(a, []byte{}, fruit{"", 0})
+		tree.Insert(a, []byte{0xff, 0x00}, fruit{"binary", 1})
+
+		Convey("Then it should round trip through gob", func() {
+			b, err := tree.GobEncode()
+			So(err, ShouldBeNil)
+
+			decoded := &art.Tree[fruit]{}
+
+			So(art.DecodeGob(a, decoded, b), ShouldBeNil)
+			So(decoded.Len(), ShouldEqual, 3)
+			So(*decoded.Search([]byte("apple")), ShouldResemble, fruit{"apple", 3})
+			So(*decoded.Search([]byte{}), ShouldResemble, fruit{"", 0})
+			So(*decoded.Search([]byte{0xff, 0x00}), ShouldResemble, fruit{"binary", 1})
+		})
+
+		Convey("Then a truncated encoding should fail", func() {
+			b, err := tree.GobEncode()
+			So(err, ShouldBeNil)
+
+			So(art.DecodeGob(a, &art.Tree[fruit]{}, b[:len(b)-1]), ShouldNotBeNil)
+		})
+	})
+}
@@ -4,6 +4,7 @@ package art_test
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
 
 	"github.com/flier/goutil/pkg/arena"
@@ -14,6 +15,7 @@ import (
 // This example requires Go 1.23 or later to compile and run.
 func ExampleTree_go123Iterators() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[int]{}
 
@@ -48,6 +50,7 @@ func ExampleTree_go123Iterators() {
 // ExampleTree_earlyTermination demonstrates early termination during iteration.
 func ExampleTree_earlyTermination() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[string]{}
 
@@ -2,6 +2,7 @@ package art_test
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/flier/goutil/pkg/arena"
 	"github.com/flier/goutil/pkg/arena/art"
@@ -11,6 +12,7 @@ import (
 func ExampleTree_basic() {
 	// Create a new arena for memory management
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	// Create a tree that stores string values
 	tree := &art.Tree[string]{}
@@ -46,6 +48,7 @@ func ExampleTree_basic() {
 // ExampleTree_prefix demonstrates prefix-based operations and iteration.
 func ExampleTree_prefix() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[string]{}
 
@@ -87,6 +90,7 @@ func ExampleTree_prefix() {
 // ExampleTree_minMax demonstrates finding minimum and maximum keys in the tree.
 func ExampleTree_minMax() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[int]{}
 
@@ -114,6 +118,7 @@ func ExampleTree_minMax() {
 // ExampleTree_differentTypes demonstrates using the tree with different value types.
 func ExampleTree_differentTypes() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	// Tree with integer values
 	intTree := &art.Tree[int]{}
@@ -147,6 +152,7 @@ func ExampleTree_differentTypes() {
 // ExampleTree_insertNoReplace demonstrates inserting without replacing existing values.
 func ExampleTree_insertNoReplace() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[string]{}
 
@@ -175,6 +181,7 @@ func ExampleTree_insertNoReplace() {
 // ExampleTree_delete demonstrates deleting values from the tree.
 func ExampleTree_delete() {
 	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 	tree := &art.Tree[string]{}
 
@@ -0,0 +1,151 @@
+package art
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// filter is a bloom filter of the keys inserted into a tree.
+//
+// It is allocated in an arena, like the nodes, so that the tree itself can live
+// in the memory of an arena, which the GC doesn't scan.
+type filter struct {
+	bits       slice.Slice[uint64]
+	k          int // The number of probes per key.
+	bitsPerKey int
+	expected   int
+}
+
+// SetFilter maintains a bloom filter of the keys, sized for the expected number of keys
+// with bitsPerKey bits each, so that [Tree.Search] and [Tree.MaybeContains] can reject
+// most of the missing keys without traversing any node.
+//
+// With 10 bits per key, about 1% of the missing keys pass the filter. The filter is
+// updated by every insert, but it can't forget the deleted keys nor grow, so the false
+// positive rate rises after many deletes or once the tree holds more keys than expected,
+// see [Tree.RebuildFilter]. The filter is allocated from a, like the nodes of the tree.
+//
+// The filter is built from the keys already in the tree. A non-positive bitsPerKey
+// removes the filter.
+//
+// Example:
+//
+//	t.SetFilter(a, 1_000_000, 10)
+//
+//	if t.MaybeContains(key) {
+//	    v := t.Search(key)
+//	    ...
+//	}
+func (t *Tree[T]) SetFilter(a arena.Allocator, expectedKeys, bitsPerKey int) {
+	if bitsPerKey <= 0 {
+		t.filter = nil
+		return
+	}
+
+	t.filter = arena.New(a, filter{bitsPerKey: bitsPerKey, expected: expectedKeys})
+	t.RebuildFilter(a)
+}
+
+// RebuildFilter rebuilds the bloom filter from the keys in the tree, dropping the
+// deleted keys, and resizing it for the larger of the expected and the current number
+// of keys, see [Tree.SetFilter]. A resized filter is allocated from a.
+//
+// It does nothing if the tree has no filter.
+func (t *Tree[T]) RebuildFilter(a arena.Allocator) {
+	f := t.filter
+	if f == nil {
+		return
+	}
+
+	n := f.expected
+	if n < t.n {
+		n = t.n
+	}
+
+	if n < 1 {
+		n = 1
+	}
+
+	if m := (n*f.bitsPerKey + 63) / 64; m != f.bits.Len() {
+		f.bits = slice.Make[uint64](a, m)
+	}
+
+	f.clear()
+
+	f.k = int(math.Round(float64(f.bitsPerKey) * math.Ln2))
+	if f.k < 1 {
+		f.k = 1
+	} else if f.k > 16 {
+		f.k = 16
+	}
+
+	tree.RecursiveIter(t.root, func(key []byte, _ *T) bool {
+		f.add(key)
+
+		return false
+	})
+}
+
+// MaybeContains returns false if the key is definitely not in the tree, according to
+// its bloom filter, see [Tree.SetFilter].
+//
+// It returns true if the key may be in the tree, or if the tree has no filter.
+func (t *Tree[T]) MaybeContains(key []byte) bool {
+	return t.filter == nil || t.filter.contains(key)
+}
+
+func (f *filter) add(key []byte) {
+	h1, h2 := f.hash(key)
+	words := f.bits.Raw()
+	m := uint64(len(words)) * 64
+
+	for i := 0; i < f.k; i++ {
+		b := (h1 + uint64(i)*h2) % m
+		words[b/64] |= 1 << (b % 64)
+	}
+}
+
+func (f *filter) contains(key []byte) bool {
+	h1, h2 := f.hash(key)
+	words := f.bits.Raw()
+	m := uint64(len(words)) * 64
+
+	for i := 0; i < f.k; i++ {
+		b := (h1 + uint64(i)*h2) % m
+		if words[b/64]&(1<<(b%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hash returns the two hashes combined into the probes of a key.
+func (f *filter) hash(key []byte) (h1, h2 uint64) {
+	h := slice.Hash(slice.Wrap(key), 0)
+
+	return h, bits.RotateLeft64(h, 32) | 1
+}
+
+// insert adds a key to the filter, if any.
+func (f *filter) insert(key []byte) {
+	if f != nil {
+		f.add(key)
+	}
+}
+
+// clear removes all the keys from the filter, if any.
+func (f *filter) clear() {
+	if f == nil {
+		return
+	}
+
+	words := f.bits.Raw()
+	for i := range words {
+		words[i] = 0
+	}
+}
@@ -0,0 +1,105 @@
+package art_test
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_Filter(t *testing.T) {
+	Convey("Given a tree with a bloom filter", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		tree.Insert(a, []byte("existing"), -1)
+		tree.SetFilter(a, 1000, 10)
+
+		So(tree.MaybeContains([]byte("existing")), ShouldBeTrue)
+
+		for i := 0; i < 1000; i++ {
+			tree.Insert(a, []byte("key"+strconv.Itoa(i)), i)
+		}
+
+		Convey("Then every inserted key should pass the filter", func() {
+			for i := 0; i < 1000; i++ {
+				key := []byte("key" + strconv.Itoa(i))
+
+				So(tree.MaybeContains(key), ShouldBeTrue)
+				So(*tree.Search(key), ShouldEqual, i)
+			}
+
+			So(*tree.Freeze().Search([]byte("existing")), ShouldEqual, -1)
+		})
+
+		Convey("Then most missing keys should be rejected", func() {
+			passed := 0
+
+			for i := 0; i < 10000; i++ {
+				if tree.MaybeContains([]byte("missing" + strconv.Itoa(i))) {
+					passed++
+				}
+			}
+
+			So(passed, ShouldBeLessThan, 300)
+		})
+
+		Convey("When keys are deleted and the filter is rebuilt", func() {
+			for i := 0; i < 1000; i++ {
+				tree.Delete(a, []byte("key"+strconv.Itoa(i)))
+			}
+
+			tree.RebuildFilter(a)
+
+			Convey("Then the deleted keys should be rejected", func() {
+				passed := 0
+
+				for i := 0; i < 1000; i++ {
+					if tree.MaybeContains([]byte("key" + strconv.Itoa(i))) {
+						passed++
+					}
+				}
+
+				So(passed, ShouldBeLessThan, 30)
+				So(tree.MaybeContains([]byte("existing")), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the tree is cleared", func() {
+			tree.Clear(a)
+
+			Convey("Then no key should pass the filter", func() {
+				So(tree.MaybeContains([]byte("existing")), ShouldBeFalse)
+
+				tree.Emplace(a, []byte("new"))
+
+				So(tree.MaybeContains([]byte("new")), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the tree lives in arena memory", func() {
+			tree := arena.New(a, art.Tree[int]{})
+			tree.Insert(a, []byte("existing"), -1)
+			tree.SetFilter(a, 1000, 10)
+
+			runtime.GC()
+
+			Convey("Then the filter should be kept in the arena", func() {
+				So(tree.MaybeContains([]byte("existing")), ShouldBeTrue)
+				So(*tree.Search([]byte("existing")), ShouldEqual, -1)
+			})
+		})
+
+		Convey("When the filter is removed", func() {
+			tree.SetFilter(a, 0, 0)
+
+			So(tree.MaybeContains([]byte("missing")), ShouldBeTrue)
+			So(tree.Search([]byte("missing")), ShouldBeNil)
+		})
+	})
+}
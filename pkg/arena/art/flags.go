@@ -0,0 +1,60 @@
+package art
+
+// SetFlags sets the bits of mask in the 8 user flag bits of the entry of the key.
+//
+// The flags mark entries without changing the value type, e.g. a tombstone bit for the
+// keys deleted lazily, or a dirty bit for the keys to flush, see [Tree.VisitFlags].
+// They are not recorded by the journal.
+//
+// It returns false if the key is not found.
+func (t *Tree[T]) SetFlags(key []byte, mask uint8) bool {
+	return t.updateFlags(key, func(flags uint8) uint8 { return flags | mask })
+}
+
+// ClearFlags clears the bits of mask in the user flags of the key.
+//
+// It returns false if the key is not found.
+func (t *Tree[T]) ClearFlags(key []byte, mask uint8) bool {
+	return t.updateFlags(key, func(flags uint8) uint8 { return flags &^ mask })
+}
+
+// Flags returns the user flags of the key, and false if the key is not found.
+func (t *Tree[T]) Flags(key []byte) (flags uint8, ok bool) {
+	p := t.Search(key)
+	if p == nil {
+		return 0, false
+	}
+
+	return leafOf(p).Flags, true
+}
+
+// VisitFlags visits the entries of the tree whose flags, masked by mask, equal want.
+//
+// For example, VisitFlags(dirty, dirty, cb) visits the entries with the dirty bit,
+// and VisitFlags(tombstone, 0, cb) skips the entries with the tombstone bit.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *Tree[T]) VisitFlags(mask, want uint8, cb func(key []byte, value *T) bool) bool {
+	return t.Visit(func(key []byte, value *T) bool {
+		if leafOf(value).Flags&mask != want {
+			return false
+		}
+
+		return cb(key, value)
+	})
+}
+
+func (t *Tree[T]) updateFlags(key []byte, f func(uint8) uint8) bool {
+	p := t.Search(key)
+	if p == nil {
+		return false
+	}
+
+	t.checkWritable()
+
+	l := leafOf(p)
+	l.Flags = f(l.Flags)
+
+	return true
+}
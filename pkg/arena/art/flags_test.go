@@ -0,0 +1,110 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_Flags(t *testing.T) {
+	const (
+		tombstone = 1 << iota
+		dirty
+	)
+
+	Convey("Given a tree with values", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := arena.New(a, art.Tree[int]{})
+
+		for i := 0; i < 100; i++ {
+			tree.Insert(a, []byte(fmt.Sprintf("key:%02d", i)), i)
+		}
+
+		Convey("Then the flags are initially cleared", func() {
+			flags, ok := tree.Flags([]byte("key:42"))
+
+			So(ok, ShouldBeTrue)
+			So(flags, ShouldEqual, 0)
+
+			_, ok = tree.Flags([]byte("missing"))
+
+			So(ok, ShouldBeFalse)
+			So(tree.SetFlags([]byte("missing"), dirty), ShouldBeFalse)
+			So(tree.ClearFlags([]byte("missing"), dirty), ShouldBeFalse)
+		})
+
+		Convey("When setting flags", func() {
+			for i := 0; i < 100; i += 10 {
+				So(tree.SetFlags([]byte(fmt.Sprintf("key:%02d", i)), dirty), ShouldBeTrue)
+			}
+
+			So(tree.SetFlags([]byte("key:20"), tombstone), ShouldBeTrue)
+
+			flags, _ := tree.Flags([]byte("key:20"))
+
+			So(flags, ShouldEqual, tombstone|dirty)
+
+			Convey("Then the entries can be filtered by their flags", func() {
+				var keys []string
+
+				tree.VisitFlags(dirty|tombstone, dirty, func(key []byte, value *int) bool {
+					keys = append(keys, string(key))
+
+					return false
+				})
+
+				So(keys, ShouldResemble, []string{
+					"key:00", "key:10", "key:30", "key:40", "key:50", "key:60", "key:70", "key:80", "key:90",
+				})
+
+				n := 0
+
+				So(tree.VisitFlags(tombstone, 0, func(key []byte, value *int) bool {
+					n++
+
+					return n == 50
+				}), ShouldBeTrue)
+				So(n, ShouldEqual, 50)
+			})
+
+			Convey("Then replacing the value keeps the flags", func() {
+				So(*tree.Insert(a, []byte("key:20"), -1), ShouldEqual, 20)
+
+				flags, _ := tree.Flags([]byte("key:20"))
+
+				So(flags, ShouldEqual, tombstone|dirty)
+			})
+
+			Convey("Then clearing flags only clears the bits of the mask", func() {
+				So(tree.ClearFlags([]byte("key:20"), dirty), ShouldBeTrue)
+
+				flags, _ := tree.Flags([]byte("key:20"))
+
+				So(flags, ShouldEqual, tombstone)
+			})
+
+			Convey("Then deleting the key drops its flags", func() {
+				tree.Delete(a, []byte("key:20"))
+				tree.Insert(a, []byte("key:20"), 20)
+
+				flags, _ := tree.Flags([]byte("key:20"))
+
+				So(flags, ShouldEqual, 0)
+			})
+
+			Convey("Then cloning the tree copies the flags", func() {
+				clone := art.CloneInto(a, tree)
+
+				flags, _ := clone.Flags([]byte("key:20"))
+
+				So(flags, ShouldEqual, tombstone|dirty)
+			})
+		})
+	})
+}
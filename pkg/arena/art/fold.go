@@ -0,0 +1,23 @@
+package art
+
+// FoldPrefix folds the values of the keys with the given prefix, in key order, into
+// an accumulator starting at init, in a single traversal of the subtree.
+//
+// It is a function rather than a method, since methods can't have type parameters.
+//
+// Example:
+//
+//	total := art.FoldPrefix(t, []byte("tenant:42/"), 0, func(sum int, _ []byte, v *Order) int {
+//	    return sum + v.Amount
+//	})
+func FoldPrefix[T, A any](t *Tree[T], prefix []byte, init A, fn func(A, []byte, *T) A) A {
+	acc := init
+
+	t.VisitPrefix(prefix, func(key []byte, value *T) bool {
+		acc = fn(acc, key, value)
+
+		return false
+	})
+
+	return acc
+}
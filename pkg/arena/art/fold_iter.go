@@ -0,0 +1,62 @@
+//go:build go1.23
+
+package art
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+)
+
+// GroupByPrefixLen returns an iterator over the groups of keys sharing their first n
+// bytes, in key order, yielding the prefix of each group with the fold of its values,
+// like [FoldPrefix], in a single traversal of the tree.
+//
+// A key shorter than n bytes is a group of its own. A zero n makes a single group of
+// all the keys. Each group starts from a copy of init, so an accumulator which is a
+// reference type, such as a map, would be shared by all the groups.
+//
+// Example:
+//
+//	// Keys are a 4-byte tenant ID followed by the order ID.
+//	for tenant, n := range art.GroupByPrefixLen(t, 4, 0, func(n int, _ []byte, _ *Order) int {
+//	    return n + 1
+//	}) {
+//	    fmt.Printf("tenant %x has %d orders\n", tenant, n)
+//	}
+func GroupByPrefixLen[T, A any](t *Tree[T], n int, init A, fn func(A, []byte, *T) A) iter.Seq2[[]byte, A] {
+	if n < 0 {
+		panic(fmt.Errorf("art: negative prefix length %d", n))
+	}
+
+	return func(yield func([]byte, A) bool) {
+		var (
+			group []byte
+			acc   A
+			ok    bool
+		)
+
+		for key, value := range t.All() {
+			m := min(n, len(key))
+			prefix := key[:m:m]
+
+			if ok && !bytes.Equal(prefix, group) {
+				if !yield(group, acc) {
+					return
+				}
+
+				ok = false
+			}
+
+			if !ok {
+				group, acc, ok = prefix, init, true
+			}
+
+			acc = fn(acc, key, value)
+		}
+
+		if ok {
+			yield(group, acc)
+		}
+	}
+}
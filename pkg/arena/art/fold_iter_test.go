@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestGroupByPrefixLen(t *testing.T) {
+	Convey("Given a tree of orders per tenant", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for k, v := range map[string]int{"t1/a": 1, "t1/b": 2, "t2/a": 10, "t3/a": 100, "t3/b": 200, "t": 1000} {
+			tree.Insert(a, []byte(k), v)
+		}
+
+		sum := func(acc int, _ []byte, v *int) int { return acc + *v }
+
+		collect := func(n int) (groups []string, sums []int) {
+			for g, s := range art.GroupByPrefixLen(tree, n, 0, sum) {
+				groups = append(groups, string(g))
+				sums = append(sums, s)
+			}
+
+			return
+		}
+
+		Convey("Then the values should be folded per group", func() {
+			groups, sums := collect(2)
+
+			So(groups, ShouldResemble, []string{"t", "t1", "t2", "t3"})
+			So(sums, ShouldResemble, []int{1000, 3, 10, 300})
+		})
+
+		Convey("Then a zero length should make a single group", func() {
+			groups, sums := collect(0)
+
+			So(groups, ShouldResemble, []string{""})
+			So(sums, ShouldResemble, []int{1313})
+		})
+
+		Convey("Then a length longer than the keys should make a group per key", func() {
+			groups, _ := collect(10)
+
+			So(groups, ShouldResemble, []string{"t", "t1/a", "t1/b", "t2/a", "t3/a", "t3/b"})
+		})
+
+		Convey("Then stopping early should stop the traversal", func() {
+			var groups []string
+
+			for g := range art.GroupByPrefixLen(tree, 2, 0, sum) {
+				groups = append(groups, string(g))
+
+				if len(groups) == 2 {
+					break
+				}
+			}
+
+			So(groups, ShouldResemble, []string{"t", "t1"})
+		})
+
+		Convey("Then an empty tree should yield nothing", func() {
+			for range art.GroupByPrefixLen(&art.Tree[int]{}, 2, 0, sum) {
+				t.Fail()
+			}
+		})
+
+		Convey("Then a negative length should panic", func() {
+			So(func() { art.GroupByPrefixLen(tree, -1, 0, sum) }, ShouldPanic)
+		})
+	})
+}
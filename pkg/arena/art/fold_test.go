@@ -0,0 +1,45 @@
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestFoldPrefix(t *testing.T) {
+	Convey("Given a tree of orders per tenant", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		tree.Insert(a, []byte("t1/a"), 1)
+		tree.Insert(a, []byte("t1/b"), 2)
+		tree.Insert(a, []byte("t2/a"), 10)
+		tree.Insert(a, []byte("t3/a"), 100)
+		tree.Insert(a, []byte("t3/b"), 200)
+
+		sum := func(acc int, _ []byte, v *int) int { return acc + *v }
+
+		Convey("Then the values of a prefix should be folded", func() {
+			So(art.FoldPrefix(tree, []byte("t1/"), 0, sum), ShouldEqual, 3)
+			So(art.FoldPrefix(tree, []byte("t3/"), 1000, sum), ShouldEqual, 1300)
+			So(art.FoldPrefix(tree, []byte(""), 0, sum), ShouldEqual, 313)
+		})
+
+		Convey("Then the keys should be folded in order", func() {
+			keys := art.FoldPrefix(tree, []byte("t"), nil, func(acc []string, key []byte, _ *int) []string {
+				return append(acc, string(key))
+			})
+
+			So(keys, ShouldResemble, []string{"t1/a", "t1/b", "t2/a", "t3/a", "t3/b"})
+		})
+
+		Convey("Then a missing prefix should fold nothing", func() {
+			So(art.FoldPrefix(tree, []byte("t4/"), 42, sum), ShouldEqual, 42)
+		})
+	})
+}
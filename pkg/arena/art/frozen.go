@@ -0,0 +1,91 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// FrozenTree is a read-only view of a [Tree] that has been frozen with [Tree.Freeze].
+//
+// None of the read operations of an ART tree modify the nodes they traverse, so once
+// no more writes can happen, every method of FrozenTree is safe to call from many
+// goroutines concurrently without any synchronization.
+//
+// The underlying arena must outlive the frozen tree, and must not be reset while
+// any reader is still running.
+//
+// Example:
+//
+//	a := new(arena.Arena)
+//	t := &art.Tree[int]{}
+//	t.Insert(a, []byte("key"), 1)
+//
+//	index := t.Freeze()
+//
+//	for i := 0; i < 8; i++ {
+//	    go func() {
+//	        _ = index.Search([]byte("key"))
+//	    }()
+//	}
+type FrozenTree[T any] struct {
+	t *Tree[T]
+}
+
+// Freeze marks the tree as immutable and returns a read-only view of it.
+//
+// After freezing, any call to [Tree.Insert], [Tree.InsertNoReplace] or [Tree.Delete]
+// panics when built with the debug tag.
+func (t *Tree[T]) Freeze() FrozenTree[T] {
+	t.frozen = true
+
+	return FrozenTree[T]{t}
+}
+
+// Frozen returns true if the tree has been frozen with [Tree.Freeze].
+func (t *Tree[T]) Frozen() bool {
+	return t.frozen
+}
+
+// Len returns the number of elements in the tree.
+func (f FrozenTree[T]) Len() int {
+	return f.t.n
+}
+
+// Search searches for a value in the tree.
+//
+// It returns the value if found, otherwise nil.
+//
+// The returned value must not be modified while other goroutines may read it.
+func (f FrozenTree[T]) Search(key []byte) *T {
+	return f.t.Search(key)
+}
+
+// Minimum returns the minimum leaf in the tree.
+//
+// It returns nil if the tree is empty.
+func (f FrozenTree[T]) Minimum() *node.Leaf[T] {
+	return f.t.Minimum()
+}
+
+// Maximum returns the maximum leaf in the tree.
+//
+// It returns nil if the tree is empty.
+func (f FrozenTree[T]) Maximum() *node.Leaf[T] {
+	return f.t.Maximum()
+}
+
+// Visit visits the tree.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (f FrozenTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	return tree.RecursiveIter(f.t.root, cb)
+}
+
+// VisitPrefix visits the tree with a prefix.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (f FrozenTree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
+	return tree.IterPrefix(f.t.root, prefix, cb)
+}
@@ -0,0 +1,95 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+// TestTree_Freeze tests the Freeze method and the FrozenTree view
+func TestTree_Freeze(t *testing.T) {
+	Convey("Given an ART tree with values", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for i := 0; i < 1000; i++ {
+			tree.Insert(a, []byte(fmt.Sprintf("key:%04d", i)), i)
+		}
+
+		Convey("When freezing the tree", func() {
+			frozen := tree.Freeze()
+
+			So(tree.Frozen(), ShouldBeTrue)
+
+			Convey("Then the frozen tree should see all values", func() {
+				So(frozen.Len(), ShouldEqual, 1000)
+				So(*frozen.Search([]byte("key:0042")), ShouldEqual, 42)
+				So(frozen.Search([]byte("missing")), ShouldBeNil)
+				So(string(frozen.Minimum().Key.Raw()), ShouldEqual, "key:0000")
+				So(string(frozen.Maximum().Key.Raw()), ShouldEqual, "key:0999")
+
+				var n int
+				frozen.VisitPrefix([]byte("key:00"), func(key []byte, value *int) bool {
+					n++
+					return false
+				})
+				So(n, ShouldEqual, 100)
+			})
+
+			Convey("Then concurrent readers should not race", func() {
+				var wg sync.WaitGroup
+				errs := make(chan error, 16)
+
+				for g := 0; g < 16; g++ {
+					wg.Add(1)
+					go func(g int) {
+						defer wg.Done()
+
+						for i := g; i < 1000; i += 16 {
+							if v := frozen.Search([]byte(fmt.Sprintf("key:%04d", i))); v == nil || *v != i {
+								errs <- fmt.Errorf("key:%04d not found", i)
+								return
+							}
+						}
+
+						var n int
+						frozen.Visit(func(key []byte, value *int) bool {
+							n++
+							return false
+						})
+						if n != 1000 {
+							errs <- fmt.Errorf("visited %d values", n)
+						}
+					}(g)
+				}
+
+				wg.Wait()
+				close(errs)
+
+				for err := range errs {
+					So(err, ShouldBeNil)
+				}
+			})
+
+			if debug.Enabled {
+				Convey("Then writes should panic in debug mode", func() {
+					So(func() { tree.Insert(a, []byte("new"), 1) }, ShouldPanic)
+					So(func() { tree.InsertNoReplace(a, []byte("new"), 1) }, ShouldPanic)
+					So(func() { tree.Delete(a, []byte("key:0001")) }, ShouldPanic)
+				})
+			}
+		})
+
+		Convey("When the tree is not frozen", func() {
+			So(tree.Frozen(), ShouldBeFalse)
+		})
+	})
+}
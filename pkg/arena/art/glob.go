@@ -0,0 +1,21 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// VisitGlob visits the keys matching a glob pattern in order.
+//
+// The pattern supports '*' matching any sequence of bytes, '?' matching a single
+// byte, and '\' escaping the next byte, e.g. "sensors/*/temp?" or "user:\*". The
+// subtrees whose shared prefix can't match the pattern are skipped, so a pattern
+// with a literal head only visits the keys under that prefix.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+//
+// The callback function must not insert or delete keys, which panics when built
+// with the debug tag.
+func (t *Tree[T]) VisitGlob(pattern []byte, cb func(key []byte, value *T) bool) bool {
+	return tree.IterGlob(t.root, pattern, t.guard(cb))
+}
@@ -0,0 +1,121 @@
+package art_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func ExampleTree_VisitGlob() {
+	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+	t := new(art.Tree[int])
+
+	for i, s := range []string{"sensors/kitchen/temp1", "sensors/kitchen/humidity", "sensors/garage/temp2", "status"} {
+		t.Insert(a, []byte(s), i)
+	}
+
+	t.VisitGlob([]byte("sensors/*/temp?"), func(key []byte, value *int) bool {
+		fmt.Println(string(key), *value)
+
+		return false
+	})
+
+	// Output:
+	// sensors/garage/temp2 2
+	// sensors/kitchen/temp1 0
+}
+
+// matchGlob is a naive backtracking glob matcher.
+func matchGlob(pattern, key string) bool {
+	if pattern == "" {
+		return key == ""
+	}
+
+	switch pattern[0] {
+	case '*':
+		for i := 0; i <= len(key); i++ {
+			if matchGlob(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+
+		return false
+	case '?':
+		return key != "" && matchGlob(pattern[1:], key[1:])
+	case '\\':
+		if len(pattern) > 1 {
+			return key != "" && key[0] == pattern[1] && matchGlob(pattern[2:], key[1:])
+		}
+	}
+
+	return key != "" && key[0] == pattern[0] && matchGlob(pattern[1:], key[1:])
+}
+
+func TestTree_VisitGlob(t *testing.T) {
+	Convey("Given a tree with random keys", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := new(art.Tree[int])
+
+		r := rand.New(rand.NewSource(42))
+
+		var keys []string
+
+		for i := 0; i < 2000; i++ {
+			b := make([]byte, r.Intn(8))
+			for j := range b {
+				b[j] = "ab*?\\"[r.Intn(5)]
+			}
+
+			if tree.Insert(a, b, i) == nil {
+				keys = append(keys, string(b))
+			}
+		}
+
+		Convey("The matching keys are visited in order", func() {
+			patterns := []string{
+				"", "*", "**", "?", "a*", "*a", "a?b", "*b*", "a*b*a", "?*?", "\\*", "*\\?*", "ab\\\\", "\\", "a*\\",
+			}
+
+			for _, pattern := range patterns {
+				var want []string
+
+				tree.Visit(func(key []byte, value *int) bool {
+					if matchGlob(pattern, string(key)) {
+						want = append(want, string(key))
+					}
+
+					return false
+				})
+
+				var got []string
+
+				tree.VisitGlob([]byte(pattern), func(key []byte, value *int) bool {
+					got = append(got, string(key))
+
+					return false
+				})
+
+				So(got, ShouldResemble, want)
+			}
+		})
+
+		Convey("The iteration can be stopped", func() {
+			n := 0
+
+			So(tree.VisitGlob([]byte("*"), func(key []byte, value *int) bool {
+				n++
+
+				return n == 3
+			}), ShouldBeTrue)
+			So(n, ShouldEqual, 3)
+		})
+	})
+}
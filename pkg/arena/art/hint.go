@@ -0,0 +1,29 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// NewWithHint returns an empty tree whose root is pre-sized for the given number
+// of expected keys.
+//
+// Without a hint, the root starts as a Node4 and grows to a Node16, Node48 and
+// Node256 while the first keys are inserted, copying its children each time. With
+// enough expected keys to likely use many distinct leading bytes, the root is
+// allocated as a Node48 or Node256 up front instead.
+//
+// Only the root is pre-sized, the inner nodes still grow on demand. The root may
+// shrink again when keys are deleted, according to the [ShrinkPolicy] of the tree.
+func NewWithHint[T any](a arena.Allocator, expectedKeys int) *Tree[T] {
+	t := new(Tree[T])
+
+	switch {
+	case expectedKeys >= 1024:
+		t.root = arena.New(a, node.Node256[T]{}).Ref()
+	case expectedKeys >= 128:
+		t.root = arena.New(a, node.Node48[T]{}).Ref()
+	}
+
+	return t
+}
@@ -0,0 +1,79 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestNewWithHint(t *testing.T) {
+	for _, n := range []int{0, 128, 1024} {
+		Convey(fmt.Sprintf("Given a tree with a hint of %d keys", n), t, func() {
+			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+			tree := art.NewWithHint[int](a, n)
+
+			So(tree.Len(), ShouldEqual, 0)
+			So(tree.Minimum(), ShouldBeNil)
+			So(tree.Search([]byte("foo")), ShouldBeNil)
+			So(tree.Delete(a, []byte("foo")), ShouldBeNil)
+
+			keys := []string{"", "a", "ab", "b", "foo", "foobar", "zzz"}
+
+			Convey("When inserting keys", func() {
+				for i, k := range keys {
+					So(tree.Insert(a, []byte(k), i), ShouldBeNil)
+				}
+
+				Convey("Then they should be found in order", func() {
+					So(tree.Len(), ShouldEqual, len(keys))
+					So(string(tree.Maximum().Key.Raw()), ShouldEqual, "zzz")
+					So(tree.Rank([]byte("b")), ShouldEqual, 3)
+
+					var got []string
+					tree.Visit(func(key []byte, value *int) bool {
+						got = append(got, string(key))
+
+						return false
+					})
+
+					So(got, ShouldResemble, keys)
+
+					for i, k := range keys {
+						So(*tree.Search([]byte(k)), ShouldEqual, i)
+					}
+				})
+
+				Convey("Then they can all be deleted and inserted again", func() {
+					for i, k := range keys {
+						So(*tree.Delete(a, []byte(k)), ShouldEqual, i)
+					}
+
+					So(tree.Len(), ShouldEqual, 0)
+					So(tree.Minimum(), ShouldBeNil)
+
+					for i, k := range keys {
+						So(tree.Insert(a, []byte(k), i), ShouldBeNil)
+					}
+
+					So(tree.Len(), ShouldEqual, len(keys))
+					So(*tree.Search([]byte("foobar")), ShouldEqual, 5)
+				})
+			})
+
+			Convey("When inserting many keys", func() {
+				for i := 0; i < 5000; i++ {
+					tree.Insert(a, []byte(fmt.Sprintf("%05d", i*7919%100000)), i)
+				}
+
+				So(tree.Len(), ShouldEqual, 5000)
+				So(*tree.Search([]byte(fmt.Sprintf("%05d", 42*7919%100000))), ShouldEqual, 42)
+			})
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package art
+
+// Op is a change of a tree reported to the hooks of [Tree.OnChange].
+type Op int
+
+const (
+	// OpInsert reports a key inserted into the tree, with a nil old value.
+	OpInsert Op = iota
+	// OpReplace reports the value of an existing key replaced by [Tree.Insert].
+	OpReplace
+	// OpDelete reports a key deleted from the tree, with a nil new value.
+	OpDelete
+)
+
+// String implements [fmt.Stringer].
+func (op Op) String() string {
+	switch op {
+	case OpInsert:
+		return "insert"
+	case OpReplace:
+		return "replace"
+	case OpDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// hook is a function called on the changes of a tree, see Tree.OnChange.
+type hook[T any] func(op Op, key []byte, old, new *T)
+
+// OnChange adds a hook called after every key inserted, replaced or deleted, e.g. to
+// keep a secondary index keyed by a field of the values in sync with the tree.
+//
+// The hooks are called in the order they were added, with the key and the old and new
+// values, which are only valid during the call. [Tree.Clear] reports the deletion of
+// every key, and the insertion of a key by [Tree.Emplace] is reported by [Tree.Commit],
+// once its value has been initialized. Values modified in place are not reported.
+//
+// The hooks must not modify the tree itself. A nil hook removes all the hooks.
+//
+// The hooks are functions on the Go heap, which the GC doesn't see from the memory of
+// an arena, so a tree with hooks must itself live on the heap, not be allocated in
+// an arena. This is checked in debug builds.
+//
+// Example:
+//
+//	byName := &art.Tree[uint64]{}
+//
+//	users.OnChange(func(op art.Op, key []byte, old, new *User) {
+//	    if old != nil {
+//	        byName.Delete(a, []byte(old.Name))
+//	    }
+//
+//	    if new != nil {
+//	        byName.Insert(a, []byte(new.Name), new.ID)
+//	    }
+//	})
+func (t *Tree[T]) OnChange(hook func(op Op, key []byte, old, new *T)) {
+	if hook == nil {
+		t.hooks = nil
+		return
+	}
+
+	t.checkHeap()
+
+	t.hooks = append(t.hooks, hook)
+}
+
+// notify calls the hooks of the tree, if any.
+func (t *Tree[T]) notify(op Op, key []byte, old, new *T) {
+	for _, hook := range t.hooks {
+		hook(op, key, old, new)
+	}
+}
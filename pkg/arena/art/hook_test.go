@@ -0,0 +1,128 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_OnChange(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	Convey("Given a tree with a secondary index maintained by a hook", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		users := &art.Tree[user]{}
+		byName := &art.Tree[int]{}
+
+		var ops []string
+
+		users.OnChange(func(op art.Op, key []byte, old, new *user) {
+			ops = append(ops, fmt.Sprintf("%v %s", op, key))
+
+			if old != nil {
+				byName.Delete(a, []byte(old.Name))
+			}
+
+			if new != nil {
+				byName.Insert(a, []byte(new.Name), new.ID)
+			}
+		})
+
+		users.Insert(a, []byte("1"), user{1, "alice"})
+		users.Insert(a, []byte("2"), user{2, "bob"})
+
+		Convey("Then the inserted keys are reported", func() {
+			So(ops, ShouldResemble, []string{"insert 1", "insert 2"})
+			So(*byName.Search([]byte("alice")), ShouldEqual, 1)
+			So(*byName.Search([]byte("bob")), ShouldEqual, 2)
+		})
+
+		Convey("When replacing a value", func() {
+			users.Insert(a, []byte("1"), user{1, "carol"})
+
+			Convey("Then the old and new values are reported", func() {
+				So(ops[2:], ShouldResemble, []string{"replace 1"})
+				So(byName.Search([]byte("alice")), ShouldBeNil)
+				So(*byName.Search([]byte("carol")), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When inserting an existing key without replacing it", func() {
+			users.InsertNoReplace(a, []byte("1"), user{1, "dave"})
+
+			Convey("Then nothing is reported", func() {
+				So(ops, ShouldHaveLength, 2)
+				So(byName.Len(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When adding a hook to a tree in arena memory", func() {
+			tree := arena.New(a, art.Tree[int]{})
+			hook := func(art.Op, []byte, *int, *int) {}
+
+			Convey("Then it should panic in debug builds", func() {
+				if debug.Enabled {
+					So(func() { tree.OnChange(hook) }, ShouldPanic)
+				} else {
+					So(func() { tree.OnChange(hook) }, ShouldNotPanic)
+				}
+			})
+		})
+
+		Convey("When deleting a key", func() {
+			users.Delete(a, []byte("2"))
+			users.Delete(a, []byte("missing"))
+
+			Convey("Then the deleted value is reported", func() {
+				So(ops[2:], ShouldResemble, []string{"delete 2"})
+				So(byName.Search([]byte("bob")), ShouldBeNil)
+			})
+		})
+
+		Convey("When clearing the tree", func() {
+			users.Clear(a)
+
+			Convey("Then every key is reported as deleted", func() {
+				So(ops[2:], ShouldResemble, []string{"delete 1", "delete 2"})
+				So(byName.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When emplacing a key", func() {
+			p, _ := users.Emplace(a, []byte("3"))
+
+			Convey("Then nothing is reported before the value is committed", func() {
+				So(ops, ShouldHaveLength, 2)
+			})
+
+			Convey("Then the initialized value is reported once committed", func() {
+				p.ID, p.Name = 3, "erin"
+				users.Commit([]byte("3"))
+
+				So(ops[2:], ShouldResemble, []string{"insert 3"})
+				So(byName.Search([]byte("")), ShouldBeNil)
+				So(*byName.Search([]byte("erin")), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When removing the hooks", func() {
+			users.OnChange(nil)
+			users.Delete(a, []byte("1"))
+
+			Convey("Then nothing is reported", func() {
+				So(ops, ShouldHaveLength, 2)
+				So(byName.Len(), ShouldEqual, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,11 @@
+package art
+
+// HotSpot is the number of operations on a top-level branch of a tree, see [Tree.HotSpots].
+type HotSpot struct {
+	Prefix   []byte // The shared prefix of the keys of the branch.
+	Searches uint64
+	Inserts  uint64
+}
+
+// Total returns the number of operations on the branch.
+func (h HotSpot) Total() uint64 { return h.Searches + h.Inserts }
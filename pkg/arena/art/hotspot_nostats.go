@@ -0,0 +1,18 @@
+//go:build !artstats
+
+package art
+
+// HotSpotsEnabled is true if the tree is built with the artstats tag, which counts
+// the operations on each top-level branch, see [Tree.HotSpots].
+const HotSpotsEnabled = false
+
+type hotSpots struct{}
+
+// HotSpots returns the n busiest top-level branches of the tree, by number of operations,
+// or all of them if n is negative.
+//
+// The counters are only maintained when built with the artstats tag, see [HotSpotsEnabled];
+// otherwise it returns nil.
+func (t *Tree[T]) HotSpots(n int) []HotSpot { return nil }
+
+func (t *Tree[T]) hit([]byte, bool) {}
@@ -0,0 +1,173 @@
+//go:build artstats
+
+package art
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// HotSpotsEnabled is true if the tree is built with the artstats tag, which counts
+// the operations on each top-level branch, see [Tree.HotSpots].
+const HotSpotsEnabled = true
+
+// hotSpots counts the operations per top-level branch of a tree.
+//
+// The tree may live in the memory of an arena, which the GC doesn't scan, so it
+// references its counters by their index in hotTable instead of a pointer.
+type hotSpots struct {
+	i int // The index of the counters in hotTable plus one, zero until the first insert.
+}
+
+// hotTable holds the counters of all the trees, which are never freed.
+var hotTable struct {
+	mu    sync.Mutex
+	stats []*hotStats
+}
+
+type hotStats struct {
+	mu sync.Mutex
+	m  map[string]*HotSpot // Keyed by the prefix of the branch when the operation happened.
+}
+
+// HotSpots returns the n busiest top-level branches of the tree, by number of operations,
+// or all of them if n is negative.
+//
+// A top-level branch holds the keys sharing the prefix of the root node followed by
+// the same byte, so the branches are the children of the root node. The operations
+// are counted by searches and inserts, including those of a [FrozenTree]. As the root
+// node changes while the tree grows, the operations counted before are reported under
+// the current branch holding their keys.
+//
+// The counters are only maintained when built with the artstats tag, see [HotSpotsEnabled];
+// otherwise it returns nil. They live on the Go heap and are never freed, even once
+// the tree is discarded, so that a tree allocated in an arena can keep them.
+func (t *Tree[T]) HotSpots(n int) []HotSpot {
+	s := t.stats.get()
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	root := t.rootPrefix()
+
+	merged := make(map[string]*HotSpot)
+
+	for _, h := range s.m {
+		prefix := h.Prefix
+		if bytes.HasPrefix(prefix, root) && len(prefix) > len(root) {
+			prefix = prefix[:len(root)+1]
+		} else {
+			prefix = root
+		}
+
+		m := merged[string(prefix)]
+		if m == nil {
+			m = &HotSpot{Prefix: bytes.Clone(prefix)}
+			merged[string(prefix)] = m
+		}
+
+		m.Searches += h.Searches
+		m.Inserts += h.Inserts
+	}
+
+	spots := make([]HotSpot, 0, len(merged))
+	for _, h := range merged {
+		spots = append(spots, *h)
+	}
+
+	sort.Slice(spots, func(i, j int) bool {
+		if a, b := spots[i].Total(), spots[j].Total(); a != b {
+			return a > b
+		}
+
+		return bytes.Compare(spots[i].Prefix, spots[j].Prefix) < 0
+	})
+
+	if n >= 0 && n < len(spots) {
+		spots = spots[:n]
+	}
+
+	return spots
+}
+
+// hit counts a search or an insert of the key.
+func (t *Tree[T]) hit(key []byte, insert bool) {
+	s := t.stats.get()
+	if s == nil {
+		if !insert {
+			return
+		}
+
+		s = t.stats.init()
+	}
+
+	// The branch of the key is the prefix it shares with the root, plus the next byte.
+	root := t.rootPrefix()
+	if t.root.Empty() {
+		root = key
+	}
+
+	n := 0
+	for n < len(root) && n < len(key) && root[n] == key[n] {
+		n++
+	}
+
+	prefix := key[:min(n+1, len(key))]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.m[string(prefix)]
+	if h == nil {
+		h = &HotSpot{Prefix: bytes.Clone(prefix)}
+		s.m[string(prefix)] = h
+	}
+
+	if insert {
+		h.Inserts++
+	} else {
+		h.Searches++
+	}
+}
+
+// get returns the counters of the tree, or nil before the first insert.
+func (h *hotSpots) get() *hotStats {
+	if h.i == 0 {
+		return nil
+	}
+
+	hotTable.mu.Lock()
+	defer hotTable.mu.Unlock()
+
+	return hotTable.stats[h.i-1]
+}
+
+// init allocates the counters of the tree.
+func (h *hotSpots) init() *hotStats {
+	s := &hotStats{m: make(map[string]*HotSpot)}
+
+	hotTable.mu.Lock()
+	defer hotTable.mu.Unlock()
+
+	hotTable.stats = append(hotTable.stats, s)
+	h.i = len(hotTable.stats)
+
+	return s
+}
+
+// rootPrefix returns the prefix of the root node, or the key of the root leaf.
+func (t *Tree[T]) rootPrefix() []byte {
+	if l := t.root.AsLeaf(); l != nil {
+		return l.Key.Raw()
+	}
+
+	if t.root.IsNode() {
+		return t.root.AsNode().Prefix().Raw()
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestHotSpots(t *testing.T) {
+	Convey("Given a tree with keys under a shared prefix", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		So(tree.HotSpots(10), ShouldBeEmpty)
+
+		for i, key := range []string{"/a/1", "/a/2", "/b/1", "/c/1", "/c/2", "/c/3"} {
+			tree.Insert(a, []byte(key), i)
+		}
+
+		for i := 0; i < 5; i++ {
+			tree.Search([]byte("/b/1"))
+		}
+
+		tree.Freeze().Search([]byte("/a/1"))
+
+		if !art.HotSpotsEnabled {
+			Convey("Then no hot spot should be reported without the artstats tag", func() {
+				So(tree.HotSpots(10), ShouldBeEmpty)
+			})
+
+			return
+		}
+
+		Convey("Then the busiest branches should come first", func() {
+			spots := tree.HotSpots(2)
+
+			So(spots, ShouldHaveLength, 2)
+			So(string(spots[0].Prefix), ShouldEqual, "/b")
+			So(spots[0].Searches, ShouldEqual, 5)
+			So(spots[0].Inserts, ShouldEqual, 1)
+			So(string(spots[1].Prefix), ShouldEqual, "/a")
+			So(spots[1].Total(), ShouldEqual, 3)
+		})
+
+		Convey("Then all the branches should be reported with a negative n", func() {
+			spots := tree.HotSpots(-1)
+
+			So(spots, ShouldHaveLength, 3)
+			So(string(spots[2].Prefix), ShouldEqual, "/c")
+			So(spots[2].Inserts, ShouldEqual, 3)
+		})
+
+		Convey("Then a tree in arena memory should keep its counters", func() {
+			tree := arena.New(a, art.Tree[int]{})
+			tree.Insert(a, []byte("/a/1"), 1)
+
+			runtime.GC()
+
+			tree.Search([]byte("/a/1"))
+
+			spots := tree.HotSpots(-1)
+
+			So(spots, ShouldHaveLength, 1)
+			So(spots[0].Searches, ShouldEqual, 1)
+			So(spots[0].Inserts, ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,200 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// InternTree is an Adaptive Radix Tree which stores a single arena copy of equal values.
+//
+// Every leaf holds the index of its value in a table of interned values, which counts
+// the keys referencing each of them. Inserting a value equal to an interned one only
+// adds a reference to it, and the copy is released once the last key referencing it is
+// replaced or deleted. This saves most of the arena when many keys share a few large
+// values, such as configuration blobs.
+//
+// The values are compared with the user-provided hash and equality functions, and must
+// not be modified through the pointers returned by the tree, since they are shared.
+//
+// Example:
+//
+//	t := art.NewInternTree(
+//	    func(v *Config) uint64 { return v.Hash() },
+//	    func(x, y *Config) bool { return *x == *y },
+//	)
+//
+//	t.Insert(a, []byte("/hosts/a"), cfg)
+//	t.Insert(a, []byte("/hosts/b"), cfg) // Shares the copy of /hosts/a.
+type InternTree[T any] struct {
+	tree   Tree[uint32]
+	values []internedValue[T]
+	free   []uint32            // The indexes of the released values.
+	index  map[uint64][]uint32 // The indexes of the interned values by hash.
+
+	hash  func(v *T) uint64
+	equal func(x, y *T) bool
+}
+
+// internedValue is an entry of the table of interned values of an [InternTree].
+type internedValue[T any] struct {
+	p    *T // In the arena, nil once released.
+	hash uint64
+	refs int
+}
+
+// NewInternTree returns an empty tree which interns the values with the given hash
+// and equality functions. Equal values must have the same hash.
+func NewInternTree[T any](hash func(v *T) uint64, equal func(x, y *T) bool) *InternTree[T] {
+	return &InternTree[T]{
+		index: make(map[uint64][]uint32),
+		hash:  hash,
+		equal: equal,
+	}
+}
+
+// Len returns the number of elements in the tree.
+func (t *InternTree[T]) Len() int { return t.tree.Len() }
+
+// Values returns the number of distinct values in the tree.
+func (t *InternTree[T]) Values() int { return len(t.values) - len(t.free) }
+
+// Refs returns the number of keys referencing the interned value equal to v,
+// or zero if there is none.
+func (t *InternTree[T]) Refs(v *T) int {
+	if i, ok := t.lookup(t.hash(v), v); ok {
+		return t.values[i].refs
+	}
+
+	return 0
+}
+
+// Search searches for a value in the tree.
+//
+// It returns the shared value if found, otherwise nil.
+func (t *InternTree[T]) Search(key []byte) *T {
+	if i := t.tree.Search(key); i != nil {
+		return t.values[*i].p
+	}
+
+	return nil
+}
+
+// Insert inserts a new value into the tree, or replaces the value of an existing key.
+//
+// It returns a copy of the old value if the key already exists, or nil if the key is inserted.
+func (t *InternTree[T]) Insert(a arena.AllocatorExt, key []byte, value T) *T {
+	i := t.intern(a, &value)
+
+	p := t.tree.Insert(a, key, i)
+	if p == nil {
+		return nil
+	}
+
+	old := *t.values[*p].p
+
+	t.release(a, *p)
+
+	return &old
+}
+
+// Delete deletes a value from the tree.
+//
+// It returns a copy of the old value if the key is found, or nil if the key is not found.
+func (t *InternTree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
+	p := t.tree.Delete(a, key)
+	if p == nil {
+		return nil
+	}
+
+	old := *t.values[*p].p
+
+	t.release(a, *p)
+
+	return &old
+}
+
+// Visit visits the tree.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *InternTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	return t.tree.Visit(func(key []byte, i *uint32) bool {
+		return cb(key, t.values[*i].p)
+	})
+}
+
+// VisitPrefix visits the tree with a prefix.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *InternTree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
+	return t.tree.VisitPrefix(prefix, func(key []byte, i *uint32) bool {
+		return cb(key, t.values[*i].p)
+	})
+}
+
+// intern returns the index of the interned value equal to v, copying it into
+// the arena first if there is none, and adds a reference to it.
+func (t *InternTree[T]) intern(a arena.Allocator, v *T) uint32 {
+	h := t.hash(v)
+
+	i, ok := t.lookup(h, v)
+	if !ok {
+		e := internedValue[T]{p: arena.New(a, *v), hash: h}
+
+		if n := len(t.free); n > 0 {
+			i = t.free[n-1]
+			t.free = t.free[:n-1]
+			t.values[i] = e
+		} else {
+			i = uint32(len(t.values))
+			t.values = append(t.values, e)
+		}
+
+		t.index[h] = append(t.index[h], i)
+	}
+
+	t.values[i].refs++
+
+	return i
+}
+
+// release removes a reference to the interned value at index i, and releases
+// it back to the arena once nothing references it anymore.
+func (t *InternTree[T]) release(a arena.AllocatorExt, i uint32) {
+	e := &t.values[i]
+
+	if e.refs--; e.refs > 0 {
+		return
+	}
+
+	bucket := t.index[e.hash]
+	for j, k := range bucket {
+		if k == i {
+			bucket = append(bucket[:j], bucket[j+1:]...)
+
+			break
+		}
+	}
+
+	if len(bucket) == 0 {
+		delete(t.index, e.hash)
+	} else {
+		t.index[e.hash] = bucket
+	}
+
+	arena.Free(a, e.p)
+
+	*e = internedValue[T]{}
+	t.free = append(t.free, i)
+}
+
+// lookup returns the index of the interned value equal to v with the hash h.
+func (t *InternTree[T]) lookup(h uint64, v *T) (uint32, bool) {
+	for _, i := range t.index[h] {
+		if t.equal(t.values[i].p, v) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
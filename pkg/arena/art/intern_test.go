@@ -0,0 +1,129 @@
+package art_test
+
+import (
+	"hash/maphash"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestInternTree(t *testing.T) {
+	type blob [256]byte
+
+	seed := maphash.MakeSeed()
+
+	Convey("Given an intern tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := art.NewInternTree(
+			func(v *blob) uint64 { return maphash.Bytes(seed, v[:]) },
+			func(x, y *blob) bool { return *x == *y },
+		)
+
+		x, y := blob{'x'}, blob{'y'}
+
+		So(tree.Insert(a, []byte("a"), x), ShouldBeNil)
+		So(tree.Insert(a, []byte("b"), x), ShouldBeNil)
+		So(tree.Insert(a, []byte("c"), y), ShouldBeNil)
+
+		Convey("When the keys share a value", func() {
+			So(tree.Len(), ShouldEqual, 3)
+			So(tree.Values(), ShouldEqual, 2)
+			So(tree.Refs(&x), ShouldEqual, 2)
+			So(tree.Refs(&y), ShouldEqual, 1)
+
+			Convey("Then they should reference the same copy", func() {
+				So(tree.Search([]byte("a")), ShouldEqual, tree.Search([]byte("b")))
+				So(*tree.Search([]byte("a")), ShouldEqual, x)
+				So(tree.Search([]byte("c")), ShouldNotEqual, tree.Search([]byte("a")))
+				So(tree.Search([]byte("d")), ShouldBeNil)
+			})
+		})
+
+		Convey("When replacing the value of a key", func() {
+			So(*tree.Insert(a, []byte("c"), x), ShouldEqual, y)
+
+			Convey("Then the unreferenced value should be released", func() {
+				So(tree.Values(), ShouldEqual, 1)
+				So(tree.Refs(&x), ShouldEqual, 3)
+				So(tree.Refs(&y), ShouldEqual, 0)
+			})
+
+			Convey("Then replacing it with the same value should keep it", func() {
+				So(*tree.Insert(a, []byte("c"), x), ShouldEqual, x)
+				So(tree.Refs(&x), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When deleting the keys", func() {
+			So(*tree.Delete(a, []byte("a")), ShouldEqual, x)
+			So(tree.Refs(&x), ShouldEqual, 1)
+			So(tree.Delete(a, []byte("a")), ShouldBeNil)
+
+			So(*tree.Delete(a, []byte("b")), ShouldEqual, x)
+			So(tree.Refs(&x), ShouldEqual, 0)
+			So(tree.Values(), ShouldEqual, 1)
+
+			Convey("Then the released slots should be reused", func() {
+				z := blob{'z'}
+
+				So(tree.Insert(a, []byte("d"), z), ShouldBeNil)
+				So(tree.Values(), ShouldEqual, 2)
+				So(*tree.Search([]byte("d")), ShouldEqual, z)
+				So(*tree.Search([]byte("c")), ShouldEqual, y)
+			})
+		})
+
+		Convey("When visiting the tree", func() {
+			var keys []string
+			var values []byte
+
+			tree.Visit(func(key []byte, value *blob) bool {
+				keys = append(keys, string(key))
+				values = append(values, value[0])
+
+				return false
+			})
+
+			So(keys, ShouldResemble, []string{"a", "b", "c"})
+			So(string(values), ShouldEqual, "xxy")
+
+			keys = nil
+
+			tree.VisitPrefix([]byte("b"), func(key []byte, value *blob) bool {
+				keys = append(keys, string(key))
+
+				return false
+			})
+
+			So(keys, ShouldResemble, []string{"b"})
+		})
+	})
+
+	Convey("Given values with colliding hashes", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := art.NewInternTree(
+			func(v *int) uint64 { return 0 },
+			func(x, y *int) bool { return *x == *y },
+		)
+
+		for i := 0; i < 10; i++ {
+			tree.Insert(a, []byte{byte(i)}, i%3)
+		}
+
+		So(tree.Values(), ShouldEqual, 3)
+		So(*tree.Search([]byte{7}), ShouldEqual, 1)
+
+		tree.Delete(a, []byte{2})
+		tree.Delete(a, []byte{5})
+		tree.Delete(a, []byte{8})
+
+		So(tree.Values(), ShouldEqual, 2)
+		So(*tree.Search([]byte{9}), ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,193 @@
+package art
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// ErrCorruptJournal is returned by [Replay] when the journal contains an invalid record.
+var ErrCorruptJournal = errors.New("art: corrupt journal")
+
+// The operations recorded in a journal.
+const (
+	journalInsert byte = 'I'
+	journalDelete byte = 'D'
+	journalClear  byte = 'C'
+)
+
+// journal writes the operations applied to a tree.
+type journal[T any] struct {
+	w      io.Writer
+	encode func(value T) ([]byte, error)
+	buf    []byte
+	err    error // The first error, which stops the journal.
+}
+
+// SetJournal records every operation which modifies the tree to w, so that the
+// tree can be rebuilt incrementally with [Replay] instead of being serialized as
+// a whole after every change.
+//
+// Every record is written with a single call to w, as the operation, the key and
+// the value encoded by encode, each prefixed with its length as an uvarint:
+//
+//	'I' len(key) key len(value) value   // Insert or replace.
+//	'D' len(key) key                    // Delete.
+//	'C'                                 // Clear.
+//
+// Only the operations which change the tree are recorded, e.g. inserting an existing
+// key with [Tree.InsertNoReplace] or deleting a missing key writes nothing. A key
+// inserted by [Tree.Emplace] is recorded by [Tree.Commit]. Values modified in place,
+// through the pointers returned by [Tree.Search] or [Tree.Emplace], are not recorded,
+// insert them again to journal the change.
+//
+// The first error returned by w or encode stops the journal, see [Tree.JournalErr].
+// A nil w removes the journal.
+//
+// The journal references w and encode on the Go heap, which the GC doesn't see from
+// the memory of an arena, so a tree with a journal must itself live on the heap, not
+// be allocated with [arena.New]. This is checked in debug builds.
+//
+// Example:
+//
+//	f, _ := os.OpenFile("index.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+//
+//	t.SetJournal(bufio.NewWriter(f), func(v int) ([]byte, error) {
+//	    return binary.AppendVarint(nil, int64(v)), nil
+//	})
+func (t *Tree[T]) SetJournal(w io.Writer, encode func(value T) ([]byte, error)) {
+	if w == nil {
+		t.journal = nil
+		return
+	}
+
+	t.checkHeap()
+
+	t.journal = &journal[T]{w: w, encode: encode}
+}
+
+// JournalErr returns the error which stopped the journal of the tree, if any.
+func (t *Tree[T]) JournalErr() error {
+	if t.journal == nil {
+		return nil
+	}
+
+	return t.journal.err
+}
+
+// record writes an operation to the journal of the tree, if any.
+func (t *Tree[T]) record(op byte, key []byte, value *T) {
+	j := t.journal
+	if j == nil || j.err != nil {
+		return
+	}
+
+	b := append(j.buf[:0], op)
+
+	if op != journalClear {
+		b = binary.AppendUvarint(b, uint64(len(key)))
+		b = append(b, key...)
+	}
+
+	if value != nil {
+		v, err := j.encode(*value)
+		if err != nil {
+			j.err = fmt.Errorf("art: encode journal value of %q, %w", key, err)
+			return
+		}
+
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		b = append(b, v...)
+	}
+
+	j.buf = b
+
+	if _, err := j.w.Write(b); err != nil {
+		j.err = fmt.Errorf("art: write journal, %w", err)
+	}
+}
+
+// Replay applies the operations of a journal written by [Tree.SetJournal] to the tree,
+// decoding the values with decode.
+//
+// It returns [io.ErrUnexpectedEOF] if the journal ends in the middle of a record, e.g.
+// when the last write was torn by a crash, after applying all the complete records.
+//
+// The operations are not recorded to the journal of the tree while replaying.
+func Replay[T any](a arena.AllocatorExt, t *Tree[T], r io.Reader, decode func(b []byte) (T, error)) error {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		b := bufio.NewReader(r)
+		r, br = b, b
+	}
+
+	j := t.journal
+	t.journal = nil
+	defer func() { t.journal = j }()
+
+	for {
+		op, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch op {
+		case journalInsert:
+			key, err := readJournalBytes(r, br)
+			if err != nil {
+				return err
+			}
+
+			b, err := readJournalBytes(r, br)
+			if err != nil {
+				return err
+			}
+
+			v, err := decode(b)
+			if err != nil {
+				return fmt.Errorf("art: decode journal value of %q, %w", key, err)
+			}
+
+			t.Insert(a, key, v)
+
+		case journalDelete:
+			key, err := readJournalBytes(r, br)
+			if err != nil {
+				return err
+			}
+
+			t.Delete(a, key)
+
+		case journalClear:
+			t.Clear(a)
+
+		default:
+			return fmt.Errorf("unknown operation %q, %w", op, ErrCorruptJournal)
+		}
+	}
+}
+
+// readJournalBytes reads a length-prefixed byte string of a journal record.
+func readJournalBytes(r io.Reader, br io.ByteReader) ([]byte, error) {
+	n, err := binary.ReadUvarint(br)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,164 @@
+package art_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"runtime"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func encodeInt(v int) ([]byte, error) { return binary.AppendVarint(nil, int64(v)), nil }
+
+func decodeInt(b []byte) (int, error) {
+	v, n := binary.Varint(b)
+	if n <= 0 {
+		return 0, errors.New("invalid varint")
+	}
+
+	return int(v), nil
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if w.n == 0 {
+		return 0, io.ErrShortWrite
+	}
+
+	w.n--
+
+	return len(b), nil
+}
+
+func TestTree_SetJournal(t *testing.T) {
+	Convey("Given a tree with a journal", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		var buf bytes.Buffer
+
+		tree.SetJournal(&buf, encodeInt)
+
+		Convey("When modifying the tree", func() {
+			tree.Insert(a, []byte("apple"), 1)
+			tree.Insert(a, []byte("banana"), 2)
+			tree.InsertNoReplace(a, []byte("banana"), 3)
+			tree.Insert(a, []byte("apple"), 4)
+			tree.Delete(a, []byte("cherry"))
+			tree.Delete(a, []byte("banana"))
+
+			for i := 0; i < 100; i++ {
+				tree.Insert(a, []byte(strconv.Itoa(i)), i)
+			}
+
+			p, _ := tree.Emplace(a, []byte("date"))
+			*p = 5
+			tree.Commit([]byte("date"))
+
+			So(tree.JournalErr(), ShouldBeNil)
+
+			Convey("Then only the changes should be recorded", func() {
+				So(buf.String()[:36], ShouldEqual, "I\x05apple\x01\x02I\x06banana\x01\x04I\x05apple\x01\x08D\x06banana")
+			})
+
+			Convey("Then replaying the journal should rebuild the tree", func() {
+				replayed := &art.Tree[int]{}
+
+				So(art.Replay(a, replayed, &buf, decodeInt), ShouldBeNil)
+
+				So(replayed.Len(), ShouldEqual, tree.Len())
+				So(*replayed.Search([]byte("apple")), ShouldEqual, 4)
+				So(replayed.Search([]byte("banana")), ShouldBeNil)
+				So(*replayed.Search([]byte("42")), ShouldEqual, 42)
+				So(*replayed.Search([]byte("date")), ShouldEqual, 5)
+			})
+
+			Convey("Then clearing the tree should be recorded", func() {
+				tree.Clear(a)
+				tree.Insert(a, []byte("elderberry"), 6)
+
+				replayed := &art.Tree[int]{}
+
+				So(art.Replay(a, replayed, bytes.NewReader(buf.Bytes()), decodeInt), ShouldBeNil)
+				So(replayed.Len(), ShouldEqual, 1)
+				So(*replayed.Search([]byte("elderberry")), ShouldEqual, 6)
+			})
+
+			Convey("Then a torn record should be reported after replaying the others", func() {
+				replayed := &art.Tree[int]{}
+
+				err := art.Replay(a, replayed, bytes.NewReader(buf.Bytes()[:buf.Len()-1]), decodeInt)
+
+				So(err, ShouldEqual, io.ErrUnexpectedEOF)
+				So(replayed.Len(), ShouldEqual, tree.Len()-1)
+			})
+
+			Convey("Then an invalid record should be reported", func() {
+				err := art.Replay(a, &art.Tree[int]{}, bytes.NewReader([]byte("X")), decodeInt)
+
+				So(err, ShouldWrap, art.ErrCorruptJournal)
+			})
+		})
+
+		Convey("When replaying into a tree with a journal", func() {
+			tree.Insert(a, []byte("apple"), 1)
+
+			var other bytes.Buffer
+
+			replayed := &art.Tree[int]{}
+			replayed.SetJournal(&other, encodeInt)
+
+			So(art.Replay(a, replayed, &buf, decodeInt), ShouldBeNil)
+
+			Convey("Then the replayed operations should not be recorded", func() {
+				So(other.Len(), ShouldEqual, 0)
+
+				replayed.Delete(a, []byte("apple"))
+				So(other.String(), ShouldEqual, "D\x05apple")
+			})
+		})
+
+		Convey("When the journal fails", func() {
+			tree.SetJournal(&failingWriter{n: 1}, encodeInt)
+
+			tree.Insert(a, []byte("apple"), 1)
+			tree.Insert(a, []byte("banana"), 2)
+			tree.Insert(a, []byte("cherry"), 3)
+
+			Convey("Then the error should be kept", func() {
+				So(tree.JournalErr(), ShouldWrap, io.ErrShortWrite)
+				So(tree.Len(), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When removing the journal", func() {
+			tree.SetJournal(nil, nil)
+			tree.Insert(a, []byte("apple"), 1)
+
+			So(buf.Len(), ShouldEqual, 0)
+			So(tree.JournalErr(), ShouldBeNil)
+		})
+
+		Convey("When setting a journal on a tree in arena memory", func() {
+			tree := arena.New(a, art.Tree[int]{})
+
+			Convey("Then it should panic in debug builds", func() {
+				if debug.Enabled {
+					So(func() { tree.SetJournal(&buf, encodeInt) }, ShouldPanic)
+				} else {
+					So(func() { tree.SetJournal(&buf, encodeInt) }, ShouldNotPanic)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package art
+
+import (
+	"errors"
+	"math"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+var (
+	// ErrNilKey is returned when inserting a nil key.
+	ErrNilKey = errors.New("art: nil key")
+
+	// ErrKeyTooLong is returned when inserting a key longer than the maximum key length.
+	ErrKeyTooLong = errors.New("art: key too long")
+)
+
+// MaxKeyLen is the default maximum key length, which keeps the length of a key
+// within the range of an arena slice on every platform.
+const MaxKeyLen = math.MaxInt32
+
+// SetMaxKeyLen limits the length of the keys inserted into the tree.
+//
+// Zero or a negative value restores the default limit [MaxKeyLen]. Keys already in
+// the tree are not affected.
+func (t *Tree[T]) SetMaxKeyLen(n int) {
+	if n <= 0 || n > MaxKeyLen {
+		n = 0
+	}
+
+	t.maxKeyLen = n
+}
+
+// MaxKeyLen returns the maximum length of the keys inserted into the tree.
+func (t *Tree[T]) MaxKeyLen() int {
+	if t.maxKeyLen == 0 {
+		return MaxKeyLen
+	}
+
+	return t.maxKeyLen
+}
+
+// InsertE inserts a new value into the tree like [Tree.Insert], but validates the key first.
+//
+// It returns [ErrNilKey] if the key is nil, or [ErrKeyTooLong] if the key is longer than
+// [Tree.MaxKeyLen], without modifying the tree.
+func (t *Tree[T]) InsertE(a arena.Allocator, key []byte, value T) (*T, error) {
+	if key == nil {
+		return nil, ErrNilKey
+	}
+
+	if err := t.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	return t.Insert(a, key, value), nil
+}
+
+// InsertNoReplaceE inserts a new value into the tree like [Tree.InsertNoReplace], but
+// validates the key first.
+//
+// It returns [ErrNilKey] if the key is nil, or [ErrKeyTooLong] if the key is longer than
+// [Tree.MaxKeyLen], without modifying the tree.
+func (t *Tree[T]) InsertNoReplaceE(a arena.Allocator, key []byte, value T) (*T, error) {
+	if key == nil {
+		return nil, ErrNilKey
+	}
+
+	if err := t.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	return t.InsertNoReplace(a, key, value), nil
+}
+
+func (t *Tree[T]) checkKey(key []byte) error {
+	if len(key) > t.MaxKeyLen() {
+		return ErrKeyTooLong
+	}
+
+	return nil
+}
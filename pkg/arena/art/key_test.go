@@ -0,0 +1,91 @@
+package art_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+// TestTree_InsertE tests inserting with key validation
+func TestTree_InsertE(t *testing.T) {
+	Convey("Given an ART tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		So(tree.MaxKeyLen(), ShouldEqual, art.MaxKeyLen)
+
+		Convey("When inserting valid keys", func() {
+			old, err := tree.InsertE(a, []byte("foo"), 1)
+
+			So(err, ShouldBeNil)
+			So(old, ShouldBeNil)
+
+			old, err = tree.InsertE(a, []byte("foo"), 2)
+
+			So(err, ShouldBeNil)
+			So(*old, ShouldEqual, 1)
+
+			old, err = tree.InsertNoReplaceE(a, []byte("foo"), 3)
+
+			So(err, ShouldBeNil)
+			So(*old, ShouldEqual, 2)
+			So(*tree.Search([]byte("foo")), ShouldEqual, 2)
+
+			_, err = tree.InsertE(a, []byte{}, 4)
+
+			So(err, ShouldBeNil)
+			So(tree.Len(), ShouldEqual, 2)
+		})
+
+		Convey("When inserting a nil key", func() {
+			_, err := tree.InsertE(a, nil, 1)
+
+			So(err, ShouldEqual, art.ErrNilKey)
+
+			_, err = tree.InsertNoReplaceE(a, nil, 1)
+
+			So(err, ShouldEqual, art.ErrNilKey)
+			So(tree.Len(), ShouldEqual, 0)
+		})
+
+		Convey("When limiting the key length", func() {
+			tree.SetMaxKeyLen(8)
+
+			So(tree.MaxKeyLen(), ShouldEqual, 8)
+
+			_, err := tree.InsertE(a, bytes.Repeat([]byte("x"), 8), 1)
+
+			So(err, ShouldBeNil)
+
+			_, err = tree.InsertE(a, bytes.Repeat([]byte("x"), 9), 2)
+
+			So(err, ShouldEqual, art.ErrKeyTooLong)
+
+			_, err = tree.InsertNoReplaceE(a, bytes.Repeat([]byte("x"), 9), 2)
+
+			So(err, ShouldEqual, art.ErrKeyTooLong)
+			So(tree.Len(), ShouldEqual, 1)
+
+			Convey("Then Insert should panic with the error", func() {
+				So(func() { tree.Insert(a, bytes.Repeat([]byte("y"), 9), 3) }, ShouldPanicWith, art.ErrKeyTooLong)
+				So(tree.Len(), ShouldEqual, 1)
+			})
+
+			Convey("Then resetting the limit should restore the default", func() {
+				tree.SetMaxKeyLen(0)
+
+				So(tree.MaxKeyLen(), ShouldEqual, art.MaxKeyLen)
+
+				_, err := tree.InsertE(a, bytes.Repeat([]byte("x"), 9), 2)
+
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,160 @@
+//go:build go1.21
+
+package art
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/xunsafe"
+	"github.com/flier/goutil/pkg/xunsafe/layout"
+)
+
+// CacheLineSize is the cache line size assumed by [AnalyzeLayout].
+const CacheLineSize = 64
+
+// LayoutNode is a node record of a memory layout dump written by [Tree.WriteHeapProfileHint].
+type LayoutNode struct {
+	Addr   xunsafe.Addr[byte] // The address of the node.
+	Parent xunsafe.Addr[byte] // The address of the parent node, zero for the root.
+	Type   node.Type          // The type of the node.
+	Size   int                // The size of the node in bytes, excluding its key or prefix.
+}
+
+// WriteHeapProfileHint writes the memory layout of the tree nodes to w.
+//
+// Every node is written on its own line in pre-order, as its address, the address
+// of its parent, its type and its size:
+//
+//	# addr parent type size
+//	0xc000120000 0x0 node4 80
+//	0xc000120050 0xc000120000 leaf 48
+//
+// The dump can be read back with [ReadLayout] and analyzed with [AnalyzeLayout],
+// e.g. to evaluate the locality of the nodes for different arena block sizes.
+func (t *Tree[T]) WriteHeapProfileHint(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "# addr parent type size")
+
+	tree.Walk(t.root, func(ref, parent node.Ref[T]) {
+		fmt.Fprintf(bw, "%#x %#x %s %d\n", uintptr(ref.Addr()), uintptr(parent.Addr()), ref.Type(), nodeSize(ref))
+	})
+
+	return bw.Flush()
+}
+
+func nodeSize[T any](ref node.Ref[T]) int {
+	switch ref.Type() {
+	case node.TypeLeaf:
+		return layout.Size[node.Leaf[T]]()
+	case node.TypeNode4:
+		return layout.Size[node.Node4[T]]()
+	case node.TypeNode16:
+		return layout.Size[node.Node16[T]]()
+	case node.TypeNode48:
+		return layout.Size[node.Node48[T]]()
+	case node.TypeNode256:
+		return layout.Size[node.Node256[T]]()
+	default:
+		return 0
+	}
+}
+
+// ReadLayout reads a memory layout dump written by [Tree.WriteHeapProfileHint].
+func ReadLayout(r io.Reader) (nodes []LayoutNode, err error) {
+	s := bufio.NewScanner(r)
+
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var n LayoutNode
+		var addr, parent uintptr
+		var typ string
+
+		if _, err = fmt.Sscanf(text, "%v %v %s %d", &addr, &parent, &typ, &n.Size); err != nil {
+			return nil, fmt.Errorf("art: invalid layout at line %d, %w", line, err)
+		}
+
+		if n.Type = node.ParseType(typ); n.Type == node.TypeUnknown {
+			return nil, fmt.Errorf("art: invalid layout at line %d, unknown node type %q", line, typ)
+		}
+
+		n.Addr, n.Parent = xunsafe.Addr[byte](addr), xunsafe.Addr[byte](parent)
+
+		nodes = append(nodes, n)
+	}
+
+	return nodes, s.Err()
+}
+
+// Locality is the locality metrics of a memory layout computed by [AnalyzeLayout].
+type Locality struct {
+	Nodes int // The number of nodes.
+	Bytes int // The total size of the nodes in bytes.
+	Span  int // The distance in bytes between the lowest and the highest node address.
+
+	// CacheLines is the number of distinct cache lines touched by the nodes.
+	CacheLines int
+	// NodesPerCacheLine is the average number of nodes per touched cache line.
+	NodesPerCacheLine float64
+
+	// AvgDistance is the average distance in bytes between a node and its parent.
+	AvgDistance float64
+	// SameCacheLine is the number of nodes starting in the cache line of their parent.
+	SameCacheLine int
+}
+
+// AnalyzeLayout computes the locality metrics of a memory layout.
+func AnalyzeLayout(nodes []LayoutNode) (l Locality) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	lines := make(map[xunsafe.Addr[byte]]struct{})
+	lo, hi := nodes[0].Addr, nodes[0].Addr
+
+	var edges, distance int
+
+	for _, n := range nodes {
+		l.Nodes++
+		l.Bytes += n.Size
+
+		lo, hi = min(lo, n.Addr), max(hi, n.Addr.Add(n.Size))
+
+		for p := n.Addr &^ (CacheLineSize - 1); p < n.Addr.Add(max(n.Size, 1)); p += CacheLineSize {
+			lines[p] = struct{}{}
+		}
+
+		if n.Parent != 0 {
+			edges++
+
+			if n.Addr > n.Parent {
+				distance += int(n.Addr - n.Parent)
+			} else {
+				distance += int(n.Parent - n.Addr)
+			}
+
+			if n.Addr/CacheLineSize == n.Parent/CacheLineSize {
+				l.SameCacheLine++
+			}
+		}
+	}
+
+	l.Span = int(hi - lo)
+	l.CacheLines = len(lines)
+	l.NodesPerCacheLine = float64(l.Nodes) / float64(l.CacheLines)
+
+	if edges > 0 {
+		l.AvgDistance = float64(distance) / float64(edges)
+	}
+
+	return
+}
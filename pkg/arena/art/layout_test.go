@@ -0,0 +1,129 @@
+//go:build go1.21
+
+package art_test
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// TestTree_WriteHeapProfileHint tests dumping and reading the memory layout
+func TestTree_WriteHeapProfileHint(t *testing.T) {
+	Convey("Given an ART tree with values", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		for i := 0; i < 100; i++ {
+			tree.Insert(a, []byte(fmt.Sprintf("key:%03d", i)), i)
+		}
+
+		Convey("When dumping its memory layout", func() {
+			var buf bytes.Buffer
+
+			So(tree.WriteHeapProfileHint(&buf), ShouldBeNil)
+			So(buf.String(), ShouldStartWith, "# addr parent type size\n")
+
+			nodes, err := art.ReadLayout(&buf)
+
+			So(err, ShouldBeNil)
+
+			Convey("Then every node should be read back", func() {
+				var leaves int
+				addrs := map[uintptr]bool{}
+
+				for _, n := range nodes {
+					if n.Type == node.TypeLeaf {
+						leaves++
+					}
+
+					So(n.Size, ShouldBeGreaterThan, 0)
+
+					addrs[uintptr(n.Addr)] = true
+				}
+
+				So(leaves, ShouldEqual, 100)
+				So(nodes[0].Parent, ShouldEqual, 0)
+				So(nodes[0].Type, ShouldEqual, node.TypeNode16)
+
+				for _, n := range nodes[1:] {
+					So(addrs[uintptr(n.Parent)], ShouldBeTrue)
+				}
+			})
+
+			Convey("Then it can be analyzed", func() {
+				l := art.AnalyzeLayout(nodes)
+
+				So(l.Nodes, ShouldEqual, len(nodes))
+				So(l.Bytes, ShouldBeGreaterThan, 0)
+				So(l.CacheLines, ShouldBeGreaterThan, 0)
+				So(l.AvgDistance, ShouldBeGreaterThan, 0)
+			})
+		})
+
+		Convey("When dumping an empty tree", func() {
+			var buf bytes.Buffer
+
+			So((&art.Tree[int]{}).WriteHeapProfileHint(&buf), ShouldBeNil)
+
+			nodes, err := art.ReadLayout(&buf)
+
+			So(err, ShouldBeNil)
+			So(nodes, ShouldBeEmpty)
+		})
+	})
+}
+
+// TestReadLayout tests reading invalid memory layouts
+func TestReadLayout(t *testing.T) {
+	Convey("Given an invalid layout", t, func() {
+		_, err := art.ReadLayout(strings.NewReader("0x1000 0x0 node4 80\n0x1050 oops\n"))
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "line 2")
+
+		_, err = art.ReadLayout(strings.NewReader("0x1000 0x0 node5 80\n"))
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `unknown node type "node5"`)
+	})
+}
+
+// TestAnalyzeLayout tests computing the locality metrics
+func TestAnalyzeLayout(t *testing.T) {
+	Convey("Given a memory layout", t, func() {
+		layout := "0x1000 0x0 node4 64\n0x1040 0x1000 leaf 32\n0x1060 0x1000 leaf 32\n0x2000 0x1000 leaf 32\n"
+
+		nodes, err := art.ReadLayout(strings.NewReader(layout))
+
+		So(err, ShouldBeNil)
+		So(nodes, ShouldHaveLength, 4)
+
+		Convey("When analyzing it", func() {
+			l := art.AnalyzeLayout(nodes)
+
+			So(l, ShouldResemble, art.Locality{
+				Nodes:             4,
+				Bytes:             160,
+				Span:              0x1020,
+				CacheLines:        3,
+				NodesPerCacheLine: 4.0 / 3,
+				AvgDistance:       float64(0x40+0x60+0x1000) / 3,
+				SameCacheLine:     0,
+			})
+		})
+
+		Convey("When analyzing an empty layout", func() {
+			So(art.AnalyzeLayout(nil), ShouldResemble, art.Locality{})
+		})
+	})
+}
@@ -0,0 +1,195 @@
+package art
+
+import (
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// LRUTree is an Adaptive Radix Tree which also keeps its keys in access order.
+//
+// Every leaf holds the links of an intrusive doubly linked list from the least to
+// the most recently used key, so an LRU cache can be built over the tree without a
+// parallel container/list and the double bookkeeping it requires. Inserting a key
+// or touching it with [LRUTree.Touch] or [LRUTree.Get] makes it the most recently
+// used one, while [LRUTree.Search] and the visits don't change the order.
+//
+// Example:
+//
+//	var cache art.LRUTree[[]byte]
+//
+//	cache.Insert(a, key, value)
+//
+//	for cache.Len() > capacity {
+//	    key, _, _ := cache.Oldest()
+//	    cache.Delete(a, key)
+//	}
+type LRUTree[T any] struct {
+	tree       Tree[lruValue[T]]
+	head, tail xunsafe.Addr[node.Leaf[lruValue[T]]] // The least and the most recently used keys.
+}
+
+// lruValue is the value stored in a leaf of an [LRUTree].
+type lruValue[T any] struct {
+	Value      T
+	prev, next xunsafe.Addr[node.Leaf[lruValue[T]]]
+}
+
+// lruLeaf returns the leaf holding the given value.
+func lruLeaf[T any](p *lruValue[T]) *node.Leaf[lruValue[T]] {
+	var l node.Leaf[lruValue[T]]
+
+	return xunsafe.ByteAdd[node.Leaf[lruValue[T]]](p, -int(unsafe.Offsetof(l.Value)))
+}
+
+// Len returns the number of elements in the tree.
+func (t *LRUTree[T]) Len() int { return t.tree.Len() }
+
+// Search searches for the value of a key, without changing the access order.
+//
+// It returns the value if found, otherwise nil.
+func (t *LRUTree[T]) Search(key []byte) *T {
+	if p := t.tree.Search(key); p != nil {
+		return &p.Value
+	}
+
+	return nil
+}
+
+// Get searches for the value of a key, and makes it the most recently used key.
+//
+// It returns the value if found, otherwise nil.
+func (t *LRUTree[T]) Get(key []byte) *T {
+	p := t.tree.Search(key)
+	if p == nil {
+		return nil
+	}
+
+	t.moveToBack(lruLeaf(p))
+
+	return &p.Value
+}
+
+// Touch makes the key the most recently used one.
+//
+// It returns false if the key is not found.
+func (t *LRUTree[T]) Touch(key []byte) bool {
+	return t.Get(key) != nil
+}
+
+// Insert inserts or replaces the value of a key, and makes it the most recently used key.
+//
+// It returns the old value if the key already exists, or nil if the key is inserted.
+func (t *LRUTree[T]) Insert(a arena.Allocator, key []byte, value T) *T {
+	p, inserted := t.tree.Emplace(a, key)
+	l := lruLeaf(p)
+
+	if inserted {
+		p.Value = value
+		t.pushBack(l)
+
+		return nil
+	}
+
+	old := p.Value
+	p.Value = value
+	t.moveToBack(l)
+
+	return &old
+}
+
+// Delete deletes a key from the tree.
+//
+// It returns the old value if the key is found, or nil if the key is not found.
+func (t *LRUTree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
+	p := t.tree.Search(key)
+	if p == nil {
+		return nil
+	}
+
+	t.unlink(lruLeaf(p))
+
+	old := t.tree.Delete(a, key)
+
+	return &old.Value
+}
+
+// Oldest returns the least recently used key and its value.
+//
+// It returns false if the tree is empty.
+func (t *LRUTree[T]) Oldest() (key []byte, value *T, ok bool) {
+	if t.head == 0 {
+		return
+	}
+
+	l := t.head.AssertValid()
+
+	return l.Key.Raw(), &l.Value.Value, true
+}
+
+// Visit visits the tree in key order.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *LRUTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	return t.tree.Visit(func(key []byte, value *lruValue[T]) bool {
+		return cb(key, &value.Value)
+	})
+}
+
+// VisitLRU visits the tree from the least to the most recently used key.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false. The callback function may touch the visited key,
+// but must not insert or delete keys.
+func (t *LRUTree[T]) VisitLRU(cb func(key []byte, value *T) bool) bool {
+	for addr := t.head; addr != 0; {
+		l := addr.AssertValid()
+		addr = l.Value.next
+
+		if cb(l.Key.Raw(), &l.Value.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *LRUTree[T]) pushBack(l *node.Leaf[lruValue[T]]) {
+	addr := xunsafe.AddrOf(l)
+
+	l.Value.prev, l.Value.next = t.tail, 0
+
+	if t.tail != 0 {
+		t.tail.AssertValid().Value.next = addr
+	} else {
+		t.head = addr
+	}
+
+	t.tail = addr
+}
+
+func (t *LRUTree[T]) unlink(l *node.Leaf[lruValue[T]]) {
+	if l.Value.prev != 0 {
+		l.Value.prev.AssertValid().Value.next = l.Value.next
+	} else {
+		t.head = l.Value.next
+	}
+
+	if l.Value.next != 0 {
+		l.Value.next.AssertValid().Value.prev = l.Value.prev
+	} else {
+		t.tail = l.Value.prev
+	}
+
+	l.Value.prev, l.Value.next = 0, 0
+}
+
+func (t *LRUTree[T]) moveToBack(l *node.Leaf[lruValue[T]]) {
+	if xunsafe.AddrOf(l) != t.tail {
+		t.unlink(l)
+		t.pushBack(l)
+	}
+}
@@ -0,0 +1,164 @@
+package art_test
+
+import (
+	"math/rand"
+	"runtime"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func lruKeys[T any](t *art.LRUTree[T]) (keys []string) {
+	t.VisitLRU(func(key []byte, _ *T) bool {
+		keys = append(keys, string(key))
+
+		return false
+	})
+
+	return
+}
+
+func TestLRUTree(t *testing.T) {
+	Convey("Given an LRU tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.LRUTree[int]{}
+
+		_, _, ok := tree.Oldest()
+		So(ok, ShouldBeFalse)
+
+		So(tree.Insert(a, []byte("banana"), 2), ShouldBeNil)
+		So(tree.Insert(a, []byte("apple"), 1), ShouldBeNil)
+		So(tree.Insert(a, []byte("cherry"), 3), ShouldBeNil)
+
+		Convey("Then the keys should be visited in insertion order", func() {
+			So(tree.Len(), ShouldEqual, 3)
+			So(lruKeys(tree), ShouldResemble, []string{"banana", "apple", "cherry"})
+
+			key, value, ok := tree.Oldest()
+			So(ok, ShouldBeTrue)
+			So(string(key), ShouldEqual, "banana")
+			So(*value, ShouldEqual, 2)
+		})
+
+		Convey("Then the keys should still be visited in key order", func() {
+			var keys []string
+
+			tree.Visit(func(key []byte, _ *int) bool {
+				keys = append(keys, string(key))
+				return false
+			})
+
+			So(keys, ShouldResemble, []string{"apple", "banana", "cherry"})
+		})
+
+		Convey("When touching a key", func() {
+			So(tree.Touch([]byte("banana")), ShouldBeTrue)
+			So(tree.Touch([]byte("durian")), ShouldBeFalse)
+
+			Convey("Then it should become the most recently used one", func() {
+				So(lruKeys(tree), ShouldResemble, []string{"apple", "cherry", "banana"})
+			})
+		})
+
+		Convey("When getting or searching keys", func() {
+			So(*tree.Get([]byte("apple")), ShouldEqual, 1)
+			So(*tree.Search([]byte("banana")), ShouldEqual, 2)
+			So(tree.Get([]byte("durian")), ShouldBeNil)
+
+			Convey("Then only the got key should be moved", func() {
+				So(lruKeys(tree), ShouldResemble, []string{"banana", "cherry", "apple"})
+			})
+		})
+
+		Convey("When replacing a value", func() {
+			next := a.Next()
+
+			So(*tree.Insert(a, []byte("banana"), 20), ShouldEqual, 2)
+
+			So(*tree.Search([]byte("banana")), ShouldEqual, 20)
+			So(lruKeys(tree), ShouldResemble, []string{"apple", "cherry", "banana"})
+
+			Convey("Then nothing should be allocated", func() {
+				So(a.Next(), ShouldEqual, next)
+			})
+		})
+
+		Convey("When deleting keys", func() {
+			So(*tree.Delete(a, []byte("apple")), ShouldEqual, 1)
+			So(tree.Delete(a, []byte("apple")), ShouldBeNil)
+
+			So(lruKeys(tree), ShouldResemble, []string{"banana", "cherry"})
+
+			So(*tree.Delete(a, []byte("banana")), ShouldEqual, 2)
+			So(*tree.Delete(a, []byte("cherry")), ShouldEqual, 3)
+
+			So(tree.Len(), ShouldEqual, 0)
+			So(lruKeys(tree), ShouldBeEmpty)
+
+			tree.Insert(a, []byte("durian"), 4)
+			So(lruKeys(tree), ShouldResemble, []string{"durian"})
+		})
+
+		Convey("When stopping a visit early", func() {
+			var keys []string
+
+			So(tree.VisitLRU(func(key []byte, _ *int) bool {
+				keys = append(keys, string(key))
+				return true
+			}), ShouldBeTrue)
+
+			So(keys, ShouldResemble, []string{"banana"})
+		})
+	})
+
+	Convey("Given a bounded cache", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		cache := &art.LRUTree[int]{}
+		rng := rand.New(rand.NewSource(42))
+
+		var order []string // The reference order, from the least to the most recently used.
+
+		move := func(key string) {
+			for i, k := range order {
+				if k == key {
+					order = append(order[:i], order[i+1:]...)
+					break
+				}
+			}
+
+			order = append(order, key)
+		}
+
+		for i := 0; i < 2000; i++ {
+			key := strconv.Itoa(rng.Intn(100))
+
+			if rng.Intn(3) == 0 {
+				if cache.Touch([]byte(key)) {
+					move(key)
+				}
+
+				continue
+			}
+
+			cache.Insert(a, []byte(key), i)
+			move(key)
+
+			for cache.Len() > 32 {
+				oldest, _, _ := cache.Oldest()
+
+				So(string(oldest), ShouldEqual, order[0])
+
+				cache.Delete(a, oldest)
+				order = order[1:]
+			}
+		}
+
+		So(lruKeys(cache), ShouldResemble, order)
+	})
+}
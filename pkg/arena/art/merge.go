@@ -0,0 +1,97 @@
+//go:build go1.23
+
+package art
+
+import (
+	"bytes"
+	"iter"
+)
+
+// MergeIter returns an iterator over the keys of both trees a and b in key order,
+// lazily merging them without materializing a merged tree.
+//
+// The value of each key is chosen by pick, which is called with the values of the key
+// in a and b, nil if the key is missing from the tree. The key is skipped if pick
+// returns nil, e.g. for a tombstone. A nil pick prefers the value in b.
+//
+// This allows serving reads over an immutable base tree plus a mutable delta tree.
+// The trees must not be modified during the iteration.
+//
+// Example:
+//
+//	type Entry struct {
+//	    Value   string
+//	    Deleted bool
+//	}
+//
+//	for key, e := range art.MergeIter(base, delta, func(key []byte, old, new *Entry) *Entry {
+//	    if new == nil {
+//	        return old
+//	    }
+//
+//	    if new.Deleted {
+//	        return nil
+//	    }
+//
+//	    return new
+//	}) {
+//	    fmt.Printf("%s = %s\n", key, e.Value)
+//	}
+func MergeIter[T any](a, b *Tree[T], pick func(key []byte, av, bv *T) *T) iter.Seq2[[]byte, *T] {
+	if pick == nil {
+		pick = func(_ []byte, av, bv *T) *T {
+			if bv != nil {
+				return bv
+			}
+
+			return av
+		}
+	}
+
+	return func(yield func([]byte, *T) bool) {
+		nextA, stopA := iter.Pull2(a.All())
+		defer stopA()
+
+		nextB, stopB := iter.Pull2(b.All())
+		defer stopB()
+
+		ak, av, aok := nextA()
+		bk, bv, bok := nextB()
+
+		for aok || bok {
+			var (
+				key    []byte
+				lv, rv *T
+			)
+
+			switch c := cmpKeys(ak, aok, bk, bok); {
+			case c < 0:
+				key, lv = ak, av
+				ak, av, aok = nextA()
+			case c > 0:
+				key, rv = bk, bv
+				bk, bv, bok = nextB()
+			default:
+				key, lv, rv = ak, av, bv
+				ak, av, aok = nextA()
+				bk, bv, bok = nextB()
+			}
+
+			if v := pick(key, lv, rv); v != nil && !yield(key, v) {
+				return
+			}
+		}
+	}
+}
+
+// cmpKeys compares the next keys of two iterators, where an exhausted iterator sorts last.
+func cmpKeys(a []byte, aok bool, b []byte, bok bool) int {
+	switch {
+	case !aok:
+		return 1
+	case !bok:
+		return -1
+	default:
+		return bytes.Compare(a, b)
+	}
+}
@@ -0,0 +1,98 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestMergeIter(t *testing.T) {
+	Convey("Given a base tree and a delta tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		base, delta := &art.Tree[int]{}, &art.Tree[int]{}
+
+		for i, k := range []string{"apple", "banana", "cherry", "date"} {
+			base.Insert(a, []byte(k), i)
+		}
+
+		delta.Insert(a, []byte("banana"), 10)
+		delta.Insert(a, []byte("cherry"), -1) // Tombstone.
+		delta.Insert(a, []byte("elder"), 11)
+		delta.Insert(a, []byte(""), 12)
+
+		collect := func(pick func(key []byte, av, bv *int) *int) (keys []string, values []int) {
+			for k, v := range art.MergeIter(base, delta, pick) {
+				keys = append(keys, string(k))
+				values = append(values, *v)
+			}
+
+			return
+		}
+
+		Convey("When merging with the default pick", func() {
+			keys, values := collect(nil)
+
+			Convey("Then the delta should win", func() {
+				So(keys, ShouldResemble, []string{"", "apple", "banana", "cherry", "date", "elder"})
+				So(values, ShouldResemble, []int{12, 0, 10, -1, 3, 11})
+			})
+		})
+
+		Convey("When merging with tombstones", func() {
+			var picked [][2]bool
+
+			keys, values := collect(func(key []byte, av, bv *int) *int {
+				picked = append(picked, [2]bool{av != nil, bv != nil})
+
+				switch {
+				case bv == nil:
+					return av
+				case *bv < 0:
+					return nil
+				default:
+					return bv
+				}
+			})
+
+			Convey("Then the deleted keys should be skipped", func() {
+				So(keys, ShouldResemble, []string{"", "apple", "banana", "date", "elder"})
+				So(values, ShouldResemble, []int{12, 0, 10, 3, 11})
+				So(picked, ShouldResemble, [][2]bool{
+					{false, true}, {true, false}, {true, true}, {true, true}, {true, false}, {false, true},
+				})
+			})
+		})
+
+		Convey("When stopping early", func() {
+			var keys []string
+
+			for k := range art.MergeIter(base, delta, nil) {
+				keys = append(keys, string(k))
+
+				if len(keys) == 3 {
+					break
+				}
+			}
+
+			So(keys, ShouldResemble, []string{"", "apple", "banana"})
+		})
+
+		Convey("When merging with an empty tree", func() {
+			var keys []string
+
+			for k := range art.MergeIter(base, &art.Tree[int]{}, nil) {
+				keys = append(keys, string(k))
+			}
+
+			So(keys, ShouldResemble, []string{"apple", "banana", "cherry", "date"})
+		})
+
+	})
+}
@@ -0,0 +1,139 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// Migration is an incremental migration of the entries of a tree into another one,
+// transforming their keys and values, as returned by [Migrate].
+//
+// The entries are migrated in key order by batches, so that a large key-schema change
+// can be interleaved with the regular operations instead of a stop-the-world rebuild.
+// The progress is tracked by a cursor, the last migrated key, rather than a position,
+// so the source tree can be modified between the steps. The keys inserted or updated
+// behind the cursor must be written to the destination tree too, e.g. by dual writes,
+// since the migration won't visit them again.
+//
+// The source tree is not modified, it can be cleared once the migration is done.
+//
+// Example:
+//
+//	m := art.Migrate(old, new, a, func(key []byte, v User) ([]byte, User, bool) {
+//	    return append([]byte("user/"), key...), v, !v.Deleted
+//	}, 1000)
+//
+//	for !m.Step() {
+//	    saveCursor(m.Cursor())
+//	    serveRequests()
+//	}
+type Migration[T any] struct {
+	src, dst  *Tree[T]
+	a         arena.Allocator
+	transform func(oldKey []byte, v T) (newKey []byte, newV T, keep bool)
+	batch     int
+
+	cursor   []byte // The last migrated key, if started.
+	started  bool
+	done     bool
+	migrated int
+	skipped  int
+}
+
+// Migrate returns a migration of the entries of src into dst, allocated in a, which
+// migrates up to batch entries per [Migration.Step].
+//
+// Each entry is transformed into a new key and value, or skipped if transform returns
+// false. An entry overwrites any entry of dst with the same new key.
+//
+// It panics if batch is not positive, or src and dst are the same tree.
+func Migrate[T any](
+	src, dst *Tree[T],
+	a arena.Allocator,
+	transform func(oldKey []byte, v T) (newKey []byte, newV T, keep bool),
+	batch int,
+) *Migration[T] {
+	if batch <= 0 {
+		panic("art: migration batch must be positive")
+	}
+
+	if src == dst {
+		panic("art: migration into the source tree")
+	}
+
+	return &Migration[T]{src: src, dst: dst, a: a, transform: transform, batch: batch}
+}
+
+// Step migrates the next batch of entries.
+//
+// It returns true once all the entries have been migrated.
+func (m *Migration[T]) Step() (done bool) {
+	if m.done {
+		return true
+	}
+
+	i := 0
+	if m.started {
+		// The smallest key after the cursor is the cursor followed by a NUL byte,
+		// so its rank is the number of keys up to and including the cursor.
+		i = m.src.Rank(append(m.cursor, 0))
+	}
+
+	for n := 0; n < m.batch; n++ {
+		leaf := m.src.Select(i)
+		if leaf == nil {
+			break
+		}
+
+		key := leaf.Key.Raw()
+
+		if newKey, v, keep := m.transform(key, leaf.Value); keep {
+			m.dst.Insert(m.a, newKey, v)
+			m.migrated++
+		} else {
+			m.skipped++
+		}
+
+		m.cursor = append(m.cursor[:0], key...)
+		m.started = true
+		i++
+	}
+
+	m.done = i >= m.src.Len()
+
+	return m.done
+}
+
+// Run migrates all the remaining entries.
+func (m *Migration[T]) Run() {
+	for !m.Step() {
+	}
+}
+
+// Done returns true once all the entries have been migrated.
+func (m *Migration[T]) Done() bool { return m.done }
+
+// Cursor returns a copy of the last migrated key, or nil before the first entry.
+//
+// It can be saved to resume the migration with [Migration.Resume], e.g. after a restart.
+func (m *Migration[T]) Cursor() []byte {
+	if !m.started {
+		return nil
+	}
+
+	return append([]byte{}, m.cursor...)
+}
+
+// Resume continues the migration after the given cursor, as returned by [Migration.Cursor].
+//
+// A nil cursor restarts the migration from the first key.
+func (m *Migration[T]) Resume(cursor []byte) {
+	m.cursor = append(m.cursor[:0], cursor...)
+	m.started = cursor != nil
+	m.done = false
+}
+
+// Migrated returns the number of entries inserted into the destination tree.
+func (m *Migration[T]) Migrated() int { return m.migrated }
+
+// Skipped returns the number of entries skipped by the transform function.
+func (m *Migration[T]) Skipped() int { return m.skipped }
@@ -0,0 +1,107 @@
+package art_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestMigrate(t *testing.T) {
+	Convey("Given a tree with the old key schema", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		src, dst := &art.Tree[int]{}, &art.Tree[int]{}
+
+		for i, k := range []string{"", "alice", "bob", "carol", "dave", "eve", "mallory"} {
+			src.Insert(a, []byte(k), i)
+		}
+
+		transform := func(key []byte, v int) ([]byte, int, bool) {
+			return append([]byte("user/"), key...), v * 10, string(key) != "mallory"
+		}
+
+		m := art.Migrate(src, dst, a, transform, 3)
+
+		So(m.Done(), ShouldBeFalse)
+		So(m.Cursor(), ShouldBeNil)
+
+		Convey("When migrating step by step", func() {
+			So(m.Step(), ShouldBeFalse)
+			So(string(m.Cursor()), ShouldEqual, "bob")
+			So(dst.Len(), ShouldEqual, 3)
+
+			So(m.Step(), ShouldBeFalse)
+			So(string(m.Cursor()), ShouldEqual, "eve")
+
+			So(m.Step(), ShouldBeTrue)
+			So(m.Step(), ShouldBeTrue)
+
+			Convey("Then all the kept entries should be migrated", func() {
+				So(m.Done(), ShouldBeTrue)
+				So(m.Migrated(), ShouldEqual, 6)
+				So(m.Skipped(), ShouldEqual, 1)
+				So(dst.Len(), ShouldEqual, 6)
+				So(*dst.Search([]byte("user/")), ShouldEqual, 0)
+				So(*dst.Search([]byte("user/eve")), ShouldEqual, 50)
+				So(dst.Search([]byte("user/mallory")), ShouldBeNil)
+				So(src.Len(), ShouldEqual, 7)
+			})
+		})
+
+		Convey("When the source is modified between the steps", func() {
+			So(m.Step(), ShouldBeFalse)
+
+			src.Delete(a, []byte("alice"))
+			src.Insert(a, []byte("bobby"), 42)
+			src.Insert(a, []byte("aaron"), 99) // Behind the cursor.
+
+			m.Run()
+
+			Convey("Then the entries after the cursor should be migrated", func() {
+				So(*dst.Search([]byte("user/bobby")), ShouldEqual, 420)
+				So(dst.Search([]byte("user/aaron")), ShouldBeNil)
+				So(dst.Len(), ShouldEqual, 7)
+			})
+		})
+
+		Convey("When resuming from a saved cursor", func() {
+			m.Step()
+
+			cursor := m.Cursor()
+
+			resumed := art.Migrate(src, &art.Tree[int]{}, a, transform, 100)
+			resumed.Resume(cursor)
+			resumed.Run()
+
+			Convey("Then only the remaining entries should be migrated", func() {
+				So(resumed.Migrated(), ShouldEqual, 3)
+				So(resumed.Skipped(), ShouldEqual, 1)
+			})
+
+			Convey("Then a nil cursor should restart the migration", func() {
+				resumed.Resume(nil)
+				resumed.Run()
+
+				So(resumed.Migrated(), ShouldEqual, 9)
+			})
+		})
+
+		Convey("When the arguments are invalid", func() {
+			So(func() { art.Migrate(src, dst, a, transform, 0) }, ShouldPanic)
+			So(func() { art.Migrate(src, src, a, transform, 1) }, ShouldPanic)
+		})
+	})
+
+	Convey("Given an empty tree", t, func() {
+		m := art.Migrate(&art.Tree[string]{}, &art.Tree[string]{}, new(arena.Arena),
+			func(key []byte, v string) ([]byte, string, bool) { return key, strings.ToUpper(v), true }, 1)
+
+		So(m.Step(), ShouldBeTrue)
+		So(m.Migrated(), ShouldEqual, 0)
+	})
+}
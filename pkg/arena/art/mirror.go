@@ -0,0 +1,188 @@
+package art
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// ErrMirrorMismatch is returned by [Mirror.Verify] when the tree and the map disagree.
+var ErrMirrorMismatch = errors.New("art: tree and map mismatch")
+
+// Mirror is a testing utility which applies every operation both to a [Tree] and
+// to a map, so that [Mirror.Verify] can cross-check the tree against the map as
+// an oracle.
+//
+// This makes property-based tests of the tree, or of code built on top of it,
+// simple to write: apply random operations, then verify.
+//
+// Example:
+//
+//	m := art.NewMirror[int](new(arena.Arena))
+//
+//	for i := 0; i < 1000; i++ {
+//	    key := []byte(strconv.Itoa(rand.Intn(100)))
+//
+//	    if rand.Intn(2) == 0 {
+//	        m.Insert(key, i)
+//	    } else {
+//	        m.Delete(key)
+//	    }
+//	}
+//
+//	if err := m.Verify(); err != nil {
+//	    t.Fatal(err)
+//	}
+type Mirror[T any] struct {
+	Tree Tree[T]
+	Map  map[string]T
+
+	a     arena.AllocatorExt
+	equal func(x, y T) bool
+	err   error // The first mismatch found by an operation.
+}
+
+// NewMirror returns an empty mirror which allocates the tree from a.
+//
+// The values are compared with reflect.DeepEqual, see [Mirror.SetEqual].
+func NewMirror[T any](a arena.AllocatorExt) *Mirror[T] {
+	return &Mirror[T]{
+		Map:   make(map[string]T),
+		a:     a,
+		equal: func(x, y T) bool { return reflect.DeepEqual(x, y) },
+	}
+}
+
+// SetEqual sets the function used to compare the values of the tree and the map.
+func (m *Mirror[T]) SetEqual(equal func(x, y T) bool) { m.equal = equal }
+
+// Insert inserts or replaces the value of the key in both the tree and the map.
+func (m *Mirror[T]) Insert(key []byte, value T) {
+	old, found := m.Map[string(key)]
+
+	m.check("insert", key, m.Tree.Insert(m.a, key, value), old, found)
+
+	m.Map[string(key)] = value
+}
+
+// InsertNoReplace inserts the value of the key in both the tree and the map,
+// unless the key is already present.
+func (m *Mirror[T]) InsertNoReplace(key []byte, value T) {
+	old, found := m.Map[string(key)]
+
+	m.check("insert-no-replace", key, m.Tree.InsertNoReplace(m.a, key, value), old, found)
+
+	if !found {
+		m.Map[string(key)] = value
+	}
+}
+
+// Delete deletes the key from both the tree and the map.
+func (m *Mirror[T]) Delete(key []byte) {
+	old, found := m.Map[string(key)]
+
+	m.check("delete", key, m.Tree.Delete(m.a, key), old, found)
+
+	delete(m.Map, string(key))
+}
+
+// Search searches the key in both the tree and the map, and returns the value of the tree.
+func (m *Mirror[T]) Search(key []byte) *T {
+	old, found := m.Map[string(key)]
+
+	p := m.Tree.Search(key)
+
+	m.check("search", key, p, old, found)
+
+	return p
+}
+
+// check records a mismatch between the result of an operation on the tree and the
+// value previously stored in the map.
+func (m *Mirror[T]) check(op string, key []byte, got *T, want T, found bool) {
+	if m.err != nil {
+		return
+	}
+
+	switch {
+	case got == nil && found:
+		m.err = fmt.Errorf("%s %q: missing from the tree, expected %v, %w", op, key, want, ErrMirrorMismatch)
+	case got != nil && !found:
+		m.err = fmt.Errorf("%s %q: got %v from the tree, expected none, %w", op, key, *got, ErrMirrorMismatch)
+	case got != nil && !m.equal(*got, want):
+		m.err = fmt.Errorf("%s %q: got %v from the tree, expected %v, %w", op, key, *got, want, ErrMirrorMismatch)
+	}
+}
+
+// Verify cross-checks the tree against the map.
+//
+// It returns the first mismatch found by a previous operation, or checks that the
+// tree holds exactly the keys and values of the map, that they are visited in
+// ascending key order, and that the size, the minimum, the maximum and the ranks
+// of the tree are consistent with them.
+func (m *Mirror[T]) Verify() error {
+	if m.err != nil {
+		return m.err
+	}
+
+	if m.Tree.Len() != len(m.Map) {
+		return fmt.Errorf("tree has %d keys, expected %d, %w", m.Tree.Len(), len(m.Map), ErrMirrorMismatch)
+	}
+
+	var (
+		err       error
+		n         int
+		prev, max []byte
+	)
+
+	m.Tree.Visit(func(key []byte, value *T) bool {
+		switch want, found := m.Map[string(key)]; {
+		case n > 0 && bytes.Compare(prev, key) >= 0:
+			err = fmt.Errorf("key %q visited after %q, %w", key, prev, ErrMirrorMismatch)
+		case !found:
+			err = fmt.Errorf("key %q not in the map, %w", key, ErrMirrorMismatch)
+		case !m.equal(*value, want):
+			err = fmt.Errorf("key %q has value %v, expected %v, %w", key, *value, want, ErrMirrorMismatch)
+		case m.Tree.Rank(key) != n:
+			err = fmt.Errorf("key %q has rank %d, expected %d, %w", key, m.Tree.Rank(key), n, ErrMirrorMismatch)
+		}
+
+		if n == 0 {
+			if l := m.Tree.Minimum(); l == nil || !bytes.Equal(l.Key.Raw(), key) {
+				err = fmt.Errorf("minimum is not %q, %w", key, ErrMirrorMismatch)
+			}
+		}
+
+		prev, max = key, key
+		n++
+
+		return err != nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if n != len(m.Map) {
+		return fmt.Errorf("visited %d keys, expected %d, %w", n, len(m.Map), ErrMirrorMismatch)
+	}
+
+	if n > 0 {
+		if l := m.Tree.Maximum(); l == nil || !bytes.Equal(l.Key.Raw(), max) {
+			return fmt.Errorf("maximum is not %q, %w", max, ErrMirrorMismatch)
+		}
+	}
+
+	for key, want := range m.Map {
+		if p := m.Tree.Search([]byte(key)); p == nil {
+			return fmt.Errorf("search %q: missing from the tree, expected %v, %w", key, want, ErrMirrorMismatch)
+		} else if !m.equal(*p, want) {
+			return fmt.Errorf("search %q: got %v, expected %v, %w", key, *p, want, ErrMirrorMismatch)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package art_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestMirror(t *testing.T) {
+	Convey("Given a mirror", t, func() {
+		m := art.NewMirror[int](new(arena.Arena))
+
+		So(m.Verify(), ShouldBeNil)
+
+		Convey("When applying random operations", func() {
+			rng := rand.New(rand.NewSource(42))
+
+			for i := 0; i < 5000; i++ {
+				key := []byte(strconv.Itoa(rng.Intn(300)))
+
+				switch rng.Intn(4) {
+				case 0:
+					m.Delete(key)
+				case 1:
+					m.InsertNoReplace(key, i)
+				case 2:
+					m.Search(key)
+				default:
+					m.Insert(key, i)
+				}
+			}
+
+			Convey("Then the tree should match the map", func() {
+				So(m.Tree.Len(), ShouldBeGreaterThan, 0)
+				So(m.Verify(), ShouldBeNil)
+			})
+		})
+
+		Convey("When the map diverges from the tree", func() {
+			m.Insert([]byte("apple"), 1)
+			m.Insert([]byte("banana"), 2)
+
+			Convey("Then a different value should be reported", func() {
+				m.Map["apple"] = 3
+
+				So(m.Verify(), ShouldWrap, art.ErrMirrorMismatch)
+			})
+
+			Convey("Then a missing key should be reported", func() {
+				delete(m.Map, "banana")
+
+				So(m.Verify(), ShouldWrap, art.ErrMirrorMismatch)
+			})
+
+			Convey("Then an extra key should be reported", func() {
+				m.Map["cherry"] = 3
+
+				So(m.Verify(), ShouldWrap, art.ErrMirrorMismatch)
+			})
+
+			Convey("Then a mismatched operation should be reported", func() {
+				m.Tree.Delete(new(arena.Arena), []byte("apple"))
+				m.Insert([]byte("banana"), 4)
+
+				m.Delete([]byte("apple"))
+
+				So(m.Verify(), ShouldWrap, art.ErrMirrorMismatch)
+			})
+		})
+
+		Convey("When using a custom equality", func() {
+			m.SetEqual(func(x, y int) bool { return x%10 == y%10 })
+
+			m.Insert([]byte("apple"), 1)
+			m.Map["apple"] = 11
+
+			So(m.Verify(), ShouldBeNil)
+		})
+	})
+}
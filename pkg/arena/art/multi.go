@@ -0,0 +1,120 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// MultiTree is an Adaptive Radix Tree which allows multiple values per key.
+//
+// The values of a key are kept in insertion order, so duplicates are always
+// visited in the order they were inserted, which makes it suitable for use
+// cases such as inverted indexes mapping a term to many document IDs.
+//
+// The values are stored in an arena slice per leaf, appending a value to an
+// existing key only reallocates that slice when it runs out of capacity.
+type MultiTree[T any] struct {
+	tree Tree[slice.Slice[T]]
+	n    int
+}
+
+// Len returns the total number of values in the tree.
+func (t *MultiTree[T]) Len() int {
+	return t.n
+}
+
+// Keys returns the number of distinct keys in the tree.
+func (t *MultiTree[T]) Keys() int {
+	return t.tree.Len()
+}
+
+// Search returns the values associated with the key in insertion order.
+//
+// It returns an empty slice if the key is not found. The returned slice is only
+// valid until the next modification of the key.
+func (t *MultiTree[T]) Search(key []byte) slice.Slice[T] {
+	if p := t.tree.Search(key); p != nil {
+		return *p
+	}
+
+	return slice.Slice[T]{}
+}
+
+// Insert appends a value to the values associated with the key.
+func (t *MultiTree[T]) Insert(a arena.AllocatorExt, key []byte, value T) {
+	if p := t.tree.Search(key); p != nil {
+		*p = p.AppendOne(a, value)
+	} else {
+		t.tree.Insert(a, key, slice.Of(a, value))
+	}
+
+	t.n++
+}
+
+// Delete deletes the values associated with the key which match the predicate,
+// keeping the order of the remaining values.
+//
+// All values of the key are deleted if pred is nil, and the key itself is
+// deleted once it has no more values.
+//
+// It returns the number of deleted values.
+func (t *MultiTree[T]) Delete(a arena.AllocatorExt, key []byte, pred func(value *T) bool) (n int) {
+	p := t.tree.Search(key)
+	if p == nil {
+		return 0
+	}
+
+	values := p.Raw()
+	kept := values[:0]
+
+	for i := range values {
+		if pred == nil || pred(&values[i]) {
+			n++
+		} else {
+			kept = append(kept, values[i])
+		}
+	}
+
+	t.n -= n
+
+	if len(kept) == 0 {
+		if old := t.tree.Delete(a, key); old != nil {
+			old.Release(a)
+		}
+	} else {
+		*p = p.SetLen(len(kept))
+	}
+
+	return
+}
+
+// Visit visits every value in the tree, in key order and then insertion order.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *MultiTree[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	return t.tree.Visit(func(key []byte, values *slice.Slice[T]) bool {
+		return visitValues(key, *values, cb)
+	})
+}
+
+// VisitPrefix visits every value in the tree with a prefix, in key order and then
+// insertion order.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *MultiTree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
+	return t.tree.VisitPrefix(prefix, func(key []byte, values *slice.Slice[T]) bool {
+		return visitValues(key, *values, cb)
+	})
+}
+
+func visitValues[T any](key []byte, values slice.Slice[T], cb func(key []byte, value *T) bool) bool {
+	for i := 0; i < values.Len(); i++ {
+		if cb(key, values.Get(i)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+	"github.com/flier/goutil/pkg/xiter"
+)
+
+func deref[T any](p *T) T { return *p }
+
+// TestMultiTree tests the MultiTree type
+func TestMultiTree(t *testing.T) {
+	Convey("Given a MultiTree with duplicated keys", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.MultiTree[int]{}
+
+		for i, term := range []string{"go", "rust", "go", "zig", "go", "rust"} {
+			tree.Insert(a, []byte(term), i)
+		}
+
+		So(tree.Len(), ShouldEqual, 6)
+		So(tree.Keys(), ShouldEqual, 3)
+
+		Convey("When searching all values of a key", func() {
+			So(slices.Collect(xiter.Map(tree.SearchAll([]byte("go")), deref)), ShouldResemble, []int{0, 2, 4})
+			So(slices.Collect(xiter.Map(tree.SearchAll([]byte("rust")), deref)), ShouldResemble, []int{1, 5})
+			So(tree.Search([]byte("zig")).Raw(), ShouldResemble, []int{3})
+			So(slices.Collect(tree.SearchAll([]byte("c"))), ShouldBeEmpty)
+		})
+
+		Convey("When iterating over all values", func() {
+			var keys []string
+			var values []int
+
+			for key, value := range tree.All() {
+				keys = append(keys, string(key))
+				values = append(values, *value)
+			}
+
+			So(keys, ShouldResemble, []string{"go", "go", "go", "rust", "rust", "zig"})
+			So(values, ShouldResemble, []int{0, 2, 4, 1, 5, 3})
+		})
+
+		Convey("When iterating over a prefix", func() {
+			var values []int
+
+			for _, value := range tree.AllPrefix([]byte("ru")) {
+				values = append(values, *value)
+			}
+
+			So(values, ShouldResemble, []int{1, 5})
+		})
+
+		Convey("When deleting some values of a key", func() {
+			n := tree.Delete(a, []byte("go"), func(v *int) bool { return *v == 2 })
+
+			So(n, ShouldEqual, 1)
+			So(tree.Len(), ShouldEqual, 5)
+			So(tree.Keys(), ShouldEqual, 3)
+			So(tree.Search([]byte("go")).Raw(), ShouldResemble, []int{0, 4})
+
+			Convey("Then appending should keep the order", func() {
+				tree.Insert(a, []byte("go"), 6)
+
+				So(tree.Search([]byte("go")).Raw(), ShouldResemble, []int{0, 4, 6})
+			})
+		})
+
+		Convey("When deleting all values of a key", func() {
+			n := tree.Delete(a, []byte("rust"), nil)
+
+			So(n, ShouldEqual, 2)
+			So(tree.Len(), ShouldEqual, 4)
+			So(tree.Keys(), ShouldEqual, 2)
+			So(tree.Search([]byte("rust")).Len(), ShouldEqual, 0)
+		})
+
+		Convey("When deleting a missing key", func() {
+			So(tree.Delete(a, []byte("c"), nil), ShouldEqual, 0)
+			So(tree.Len(), ShouldEqual, 6)
+		})
+	})
+
+	Convey("Given a MultiTree with many values per key", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.MultiTree[int]{}
+
+		for i := 0; i < 1000; i++ {
+			tree.Insert(a, []byte{byte(i % 7)}, i)
+		}
+
+		Convey("Then the values of each key should be in insertion order", func() {
+			So(tree.Len(), ShouldEqual, 1000)
+			So(tree.Keys(), ShouldEqual, 7)
+
+			for k := 0; k < 7; k++ {
+				values := tree.Search([]byte{byte(k)}).Raw()
+
+				So(slices.IsSorted(values), ShouldBeTrue)
+
+				for _, v := range values {
+					So(v%7, ShouldEqual, k)
+				}
+			}
+		})
+	})
+}
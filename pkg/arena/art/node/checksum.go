@@ -0,0 +1,71 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// ErrCorrupted is the error panicked with when a node header doesn't match its checksum.
+//
+// It is only detected when built with the debug tag, see [Base].
+var ErrCorrupted = errors.New("node header checksum mismatch")
+
+// checksum holds the checksums of a node header, which only exist in debug mode.
+//
+// The first one covers the fields of the [Base], the second one the keys of the
+// concrete node, so that the methods of the Base can update their own part without
+// knowing the node type.
+type checksum = debug.Value[[2]uint32]
+
+// sealBase updates the checksum of the base fields, only in debug mode.
+func (n *Base[T]) sealBase() {
+	if debug.Enabled {
+		n.sum.Get()[0] = n.baseSum()
+	}
+}
+
+// seal updates the checksums of the node header, only in debug mode.
+func (n *Base[T]) seal(keys []byte) {
+	if debug.Enabled {
+		*n.sum.Get() = [2]uint32{n.baseSum(), fnv32(0, keys)}
+	}
+}
+
+// verify panics with [ErrCorrupted] if the node header doesn't match its checksums,
+// only in debug mode.
+func (n *Base[T]) verify(node Node[T], keys []byte) {
+	if debug.Enabled {
+		if sum := *n.sum.Get(); sum != [2]uint32{n.baseSum(), fnv32(0, keys)} {
+			panic(fmt.Errorf("%w: %v %p", ErrCorrupted, node.Type(), node))
+		}
+	}
+}
+
+// baseSum returns the checksum of the base fields, including the prefix bytes.
+func (n *Base[T]) baseSum() uint32 {
+	h := fnv32(0, bytesOf(&n.Partial))
+	h = fnv32(h, n.Partial.Raw())
+	h = fnv32(h, bytesOf(&n.NumChildren))
+	h = fnv32(h, bytesOf(&n.ZeroSizedChild))
+
+	return fnv32(h, bytesOf(&n.NumLeaves))
+}
+
+// fnv32 is a FNV-1a hash with a zero offset basis, so that a zeroed node, as
+// allocated by arena.New, has a zero checksum and is valid without being sealed.
+func fnv32(h uint32, b []byte) uint32 {
+	for _, c := range b {
+		h = (h ^ uint32(c)) * 16777619
+	}
+
+	return h
+}
+
+// bytesOf returns the memory of *p as bytes.
+func bytesOf[T any](p *T) []byte {
+	return unsafe.Slice(xunsafe.Cast[byte](p), unsafe.Sizeof(*p))
+}
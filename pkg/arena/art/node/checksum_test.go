@@ -0,0 +1,89 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	. "github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestChecksum(t *testing.T) {
+	Convey("Given a node built through its methods", t, func() {
+		a := &arena.Arena{}
+
+		n := arena.New(a, Node4[int]{})
+		n.SetPrefix(slice.FromString(a, "foo"))
+		n.AddChild('a', NewLeaf(a, []byte("fooa"), 1))
+		n.AddChild('b', NewLeaf(a, []byte("foob"), 2))
+		n.AddLeaves(2)
+
+		So(func() { n.FindChild('a') }, ShouldNotPanic)
+
+		if !debug.Enabled {
+			Convey("Then the checksum should take no space", func() {
+				So(unsafe.Sizeof(Base[int]{}), ShouldEqual, uintptr(40))
+			})
+
+			return
+		}
+
+		corrupted := func(f func()) (err error) {
+			defer func() {
+				err, _ = recover().(error)
+			}()
+
+			f()
+
+			return nil
+		}
+
+		Convey("When a key is overwritten", func() {
+			n.Keys[0] = 'z'
+
+			Convey("Then accessing the node should panic", func() {
+				So(errors.Is(corrupted(func() { n.FindChild('b') }), ErrCorrupted), ShouldBeTrue)
+				So(errors.Is(corrupted(func() { n.Minimum() }), ErrCorrupted), ShouldBeTrue)
+			})
+
+			Convey("And sealing the node should accept the change", func() {
+				n.Seal()
+
+				So(n.FindChild('z'), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the prefix is overwritten", func() {
+			n.Partial.Store(0, 'x')
+
+			Convey("Then accessing the node should panic", func() {
+				So(errors.Is(corrupted(func() { n.FindChild('a') }), ErrCorrupted), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the children count is overwritten", func() {
+			n.NumChildren = 3
+
+			Convey("Then accessing the node should panic", func() {
+				So(errors.Is(corrupted(func() { n.Maximum() }), ErrCorrupted), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the node grows", func() {
+			n.AddChild('c', NewLeaf(a, []byte("fooc"), 3))
+			n.AddChild('d', NewLeaf(a, []byte("food"), 4))
+
+			g := n.Grow(a)
+			g.AddChild('e', NewLeaf(a, []byte("fooe"), 5))
+
+			Convey("Then the new node should be sealed", func() {
+				So(g.FindChild('e'), ShouldNotBeNil)
+			})
+		})
+	})
+}
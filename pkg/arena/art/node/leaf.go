@@ -39,6 +39,12 @@ type Leaf[T any] struct {
 	// The type T can be any Go type, providing flexibility for different use cases.
 	// Common types include strings, integers, pointers, or custom structs.
 	Value T
+
+	// Flags stores 8 user flag bits for this entry, e.g. tombstone or dirty marks.
+	//
+	// They are kept when the value is replaced, and dropped with the leaf when
+	// the key is deleted.
+	Flags uint8
 }
 
 // Ensure Leaf implements the Node interface at compile time.
@@ -72,7 +78,7 @@ var _ Node[any] = (*Leaf[any])(nil)
 func NewLeaf[T any](a arena.Allocator, key []byte, value T) *Leaf[T] {
 	debug.Assert(a != nil, "arena must not be nil")
 
-	return arena.New(a, Leaf[T]{slice.FromBytes(a, key), value})
+	return arena.New(a, Leaf[T]{Key: slice.FromBytes(a, key), Value: value})
 }
 
 // Type returns the node type identifier for Leaf nodes.
@@ -120,6 +126,12 @@ func (l *Leaf[T]) Minimum() *Leaf[T] { return l }
 // of their own subtree.
 func (l *Leaf[T]) Maximum() *Leaf[T] { return l }
 
+// Leaves returns 1 since a leaf is the only leaf of its own subtree.
+func (l *Leaf[T]) Leaves() int { return 1 }
+
+// AddLeaves panics since leaf nodes cannot have children.
+func (l *Leaf[T]) AddLeaves(delta int) { panic("leaf cannot have children") }
+
 // FindChild panics since leaf nodes cannot have children.
 //
 // If this method is called, it indicates a programming error in the tree
@@ -24,6 +24,7 @@ package node
 import (
 	"github.com/flier/goutil/pkg/arena"
 	"github.com/flier/goutil/pkg/arena/slice"
+	"github.com/flier/goutil/pkg/xunsafe"
 )
 
 // Type represents the type identifier for different node implementations in the ART tree.
@@ -73,6 +74,35 @@ const (
 	TypeNode256
 )
 
+var typeNames = [...]string{
+	TypeUnknown: "unknown",
+	TypeLeaf:    "leaf",
+	TypeNode4:   "node4",
+	TypeNode16:  "node16",
+	TypeNode48:  "node48",
+	TypeNode256: "node256",
+}
+
+// String returns the name of the node type.
+func (t Type) String() string {
+	if t < 0 || int(t) >= len(typeNames) {
+		return typeNames[TypeUnknown]
+	}
+
+	return typeNames[t]
+}
+
+// ParseType returns the node type with the given name, or [TypeUnknown] if there is none.
+func ParseType(name string) Type {
+	for t, s := range typeNames {
+		if s == name {
+			return Type(t)
+		}
+	}
+
+	return TypeUnknown
+}
+
 // Node is the core interface for all node types in the Adaptive Radix Tree (ART).
 //
 // It provides a unified interface for operations like finding children, adding/removing
@@ -117,6 +147,17 @@ type Node[T any] interface {
 	// The prefix parameter should be a valid slice.Slice[byte] instance.
 	SetPrefix(prefix slice.Slice[byte])
 
+	// Leaves returns the number of leaves in the subtree rooted at this node.
+	//
+	// The count is maintained by the tree operations, so it is only meaningful for
+	// nodes built through them, and is used for ordered statistics queries.
+	Leaves() int
+
+	// AddLeaves adjusts the number of leaves in the subtree rooted at this node by delta.
+	//
+	// This is called along the path of an insertion or deletion to keep [Node.Leaves] accurate.
+	AddLeaves(delta int)
+
 	// Minimum returns the leftmost leaf node in the subtree rooted at this node.
 	//
 	// This is useful for ordered traversal operations and finding the smallest key.
@@ -193,8 +234,19 @@ type Base[T any] struct {
 	// and for various tree operations that need to know the node's state.
 	NumChildren int
 
+	// sum holds the checksums of the node header, verified on access in debug mode.
+	//
+	// It is zero sized otherwise, and must not be the last field to avoid padding.
+	sum checksum
+
 	// ZeroSizedChild is a special child that is used to represent a zero-sized child.
 	ZeroSizedChild Ref[T]
+
+	// NumLeaves tracks the number of leaves in the subtree rooted at this node.
+	//
+	// It is maintained by the tree operations rather than by the node itself,
+	// and is used to answer ordered statistics queries such as rank and select.
+	NumLeaves int
 }
 
 // Prefix returns the shared prefix bytes for this node.
@@ -208,4 +260,22 @@ func (n *Base[T]) Prefix() slice.Slice[byte] { return n.Partial }
 // This method satisfies the Node interface requirement for prefix modification.
 // The prefix parameter should be a valid slice.Slice[byte] instance.
 // This method is typically called during tree restructuring operations.
-func (n *Base[T]) SetPrefix(prefix slice.Slice[byte]) { n.Partial = prefix }
+func (n *Base[T]) SetPrefix(prefix slice.Slice[byte]) {
+	n.Partial = prefix
+	n.sealBase()
+}
+
+// Leaves returns the number of leaves in the subtree rooted at this node.
+func (n *Base[T]) Leaves() int { return n.NumLeaves }
+
+// AddLeaves adjusts the number of leaves in the subtree rooted at this node by delta.
+func (n *Base[T]) AddLeaves(delta int) {
+	n.NumLeaves += delta
+	n.sealBase()
+}
+
+// checkChild panics with [arena.ErrCrossArena] if the child is allocated in another arena
+// than the node, only in debug mode.
+func (n *Base[T]) checkChild(child Ref[T]) {
+	arena.AssertNoAlias(xunsafe.Addr[byte](xunsafe.AddrOf(n)), child.Addr())
+}
@@ -91,6 +91,13 @@ func (n *Node16[T]) Ref() Ref[T] { return NewRef[T](TypeNode16, n) }
 //
 // Performance: O(1) for the first level, then O(depth) for traversal
 func (n *Node16[T]) Minimum() *Leaf[T] {
+	n.verify()
+
+	if !n.ZeroSizedChild.Empty() {
+		// The key ending at this node is less than the keys of the children.
+		return n.ZeroSizedChild.AsLeaf()
+	}
+
 	if n.NumChildren == 0 {
 		return nil
 	}
@@ -109,8 +116,10 @@ func (n *Node16[T]) Minimum() *Leaf[T] {
 //
 // Performance: O(1) for the last level, then O(depth) for traversal
 func (n *Node16[T]) Maximum() *Leaf[T] {
+	n.verify()
+
 	if n.NumChildren == 0 {
-		return nil
+		return n.ZeroSizedChild.AsLeaf()
 	}
 	return n.Children[n.NumChildren-1].AsNode().Maximum()
 }
@@ -142,6 +151,8 @@ func (n *Node16[T]) Maximum() *Leaf[T] {
 //   - Early termination on match
 //   - Returns corresponding child reference
 func (n *Node16[T]) FindChild(b int) *Ref[T] {
+	n.verify()
+
 	if b < 0 {
 		if n.ZeroSizedChild.Empty() {
 			return nil
@@ -188,6 +199,12 @@ func (n *Node16[T]) FindChild(b int) *Ref[T] {
 //   - Memory operations: Array shifting for sorted order
 //   - SIMD acceleration: For finding insertion position
 func (n *Node16[T]) AddChild(b int, child AsRef[T]) {
+	if debug.Enabled {
+		n.checkChild(child.Ref())
+
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		n.ZeroSizedChild = child.Ref()
 
@@ -262,6 +279,8 @@ func (n *Node16[T]) Grow(a arena.Allocator) Node[T] {
 		newNode.Keys[n.Keys[i]] = byte(i + 1)
 	}
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -284,6 +303,10 @@ func (n *Node16[T]) Grow(a arena.Allocator) Node[T] {
 //   - Space complexity: O(1)
 //   - Memory operations: Array shifting to maintain order
 func (n *Node16[T]) RemoveChild(b int, child *Ref[T]) {
+	if debug.Enabled {
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		if &n.ZeroSizedChild == child {
 			n.ZeroSizedChild = 0
@@ -331,7 +354,16 @@ func (n *Node16[T]) RemoveChild(b int, child *Ref[T]) {
 //   - Space complexity: O(1) (fixed array sizes)
 //   - Memory allocation: One Node4 structure (if shrinking)
 func (n *Node16[T]) Shrink(a arena.AllocatorExt) Node[T] {
-	if n.NumChildren >= 3 {
+	return n.ShrinkBelow(a, DefaultShrinkPolicy.Node16)
+}
+
+// ShrinkBelow shrinks the node to a Node4 if it has less than min children,
+// like [Node16.Shrink] does with a fixed threshold.
+//
+// The node is never shrunk if it has more children than a Node4 can hold (4),
+// so a min of zero disables shrinking.
+func (n *Node16[T]) ShrinkBelow(a arena.AllocatorExt, min int) Node[T] {
+	if n.NumChildren >= min || n.NumChildren > 4 {
 		return n
 	}
 
@@ -345,6 +377,8 @@ func (n *Node16[T]) Shrink(a arena.AllocatorExt) Node[T] {
 	// Free the original Node16 since we're replacing it
 	arena.Free(a, n)
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -365,3 +399,10 @@ func (n *Node16[T]) Release(a arena.Allocator) {
 
 	arena.Free(a, n)
 }
+
+// Seal updates the checksum of the node header after its fields are modified directly.
+//
+// The checksum is only verified when built with the debug tag, Seal does nothing otherwise.
+func (n *Node16[T]) Seal() { n.Base.seal(n.Keys[:]) }
+
+func (n *Node16[T]) verify() { n.Base.verify(n, n.Keys[:]) }
@@ -1,6 +1,9 @@
 package node
 
 import (
+	"math/bits"
+
+	"github.com/flier/goutil/internal/debug"
 	"github.com/flier/goutil/pkg/arena"
 )
 
@@ -19,6 +22,7 @@ import (
 //
 // Memory Layout:
 //   - Children array: 256 pointers (fixed size, one for each byte value)
+//   - Occupied bitmap: 4 words, one bit for each non-empty child
 //   - Base struct: prefix + child count
 //   - Total overhead: highest among all node types
 //   - Memory usage: constant regardless of actual children count
@@ -51,6 +55,14 @@ type Node256[T any] struct {
 	// Non-zero values are valid child references.
 	// This direct mapping provides O(1) lookup performance.
 	Children [256]Ref[T]
+
+	// Occupied is a bitmap of the non-empty children.
+	//
+	// Bit i is set if and only if Children[i] is non-zero, which lets sparse nodes
+	// skip runs of empty children with bit scans instead of checking every slot.
+	// Children must only be modified through AddChild and RemoveChild to keep it
+	// in sync.
+	Occupied [4]uint64
 }
 
 // Ensure Node256 implements the Node interface at compile time.
@@ -79,7 +91,7 @@ func (n *Node256[T]) Ref() Ref[T] { return NewRef[T](TypeNode256, n) }
 
 // Minimum returns the leftmost leaf node in the subtree rooted at this node.
 //
-// The method scans the children array from index 0 to find the first non-empty
+// The method scans the occupancy bitmap from index 0 to find the first non-empty
 // child, then recursively calls Minimum() on that child. This approach works
 // because Node256 stores children in a direct byte-to-index mapping.
 //
@@ -88,20 +100,24 @@ func (n *Node256[T]) Ref() Ref[T] { return NewRef[T](TypeNode256, n) }
 //   - nil if this subtree is empty or contains no leaf nodes
 //
 // Performance:
-//   - Time complexity: O(1) in the best case (first child exists), O(256) in the worst case
+//   - Time complexity: O(1) - at most 4 bitmap words are scanned
 //   - Space complexity: O(1)
-//   - Memory access: Sequential scan of children array
+//   - Memory access: Occupancy bitmap, then a single child
 //
 // Algorithm:
-//   - Scan children array from index 0 to 255
-//   - Find first non-zero child reference
+//   - Find the lowest set bit of the occupancy bitmap
 //   - Recursively call Minimum() on that child
 //   - Return result or nil if no children found
 func (n *Node256[T]) Minimum() *Leaf[T] {
-	for i := 0; i < 256; i++ {
-		if n.Children[i] != 0 {
-			return n.Children[i].AsNode().Minimum()
-		}
+	n.verify()
+
+	if !n.ZeroSizedChild.Empty() {
+		// The key ending at this node is less than the keys of the children.
+		return n.ZeroSizedChild.AsLeaf()
+	}
+
+	if i := n.Next(0); i >= 0 {
+		return n.Children[i].AsNode().Minimum()
 	}
 
 	return nil
@@ -109,7 +125,7 @@ func (n *Node256[T]) Minimum() *Leaf[T] {
 
 // Maximum returns the rightmost leaf node in the subtree rooted at this node.
 //
-// The method scans the children array from index 255 down to 0 to find the last
+// The method scans the occupancy bitmap from index 255 down to 0 to find the last
 // non-empty child, then recursively calls Maximum() on that child. This approach
 // works because Node256 stores children in a direct byte-to-index mapping.
 //
@@ -118,23 +134,70 @@ func (n *Node256[T]) Minimum() *Leaf[T] {
 //   - nil if this subtree is empty or contains no leaf nodes
 //
 // Performance:
-//   - Time complexity: O(1) in the best case (last child exists), O(256) in the worst case
+//   - Time complexity: O(1) - at most 4 bitmap words are scanned
 //   - Space complexity: O(1)
-//   - Memory access: Reverse sequential scan of children array
+//   - Memory access: Occupancy bitmap, then a single child
 //
 // Algorithm:
-//   - Scan children array from index 255 down to 0
-//   - Find last non-zero child reference
+//   - Find the highest set bit of the occupancy bitmap
 //   - Recursively call Maximum() on that child
 //   - Return result or nil if no children found
 func (n *Node256[T]) Maximum() *Leaf[T] {
-	for i := 255; i >= 0; i-- {
-		if n.Children[i] != 0 {
-			return n.Children[i].AsNode().Maximum()
+	n.verify()
+
+	if i := n.Prev(255); i >= 0 {
+		return n.Children[i].AsNode().Maximum()
+	}
+
+	return n.ZeroSizedChild.AsLeaf()
+}
+
+// Next returns the index of the first non-empty child at or after i, or -1 if
+// there is none.
+//
+// Iterating the children in order only visits the non-empty ones:
+//
+//	for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+//	    child := n.Children[i]
+//	}
+func (n *Node256[T]) Next(i int) int {
+	if i < 0 {
+		i = 0
+	}
+
+	for w := i >> 6; w < len(n.Occupied); w++ {
+		word := n.Occupied[w]
+		if w == i>>6 {
+			word &= ^uint64(0) << (i & 63)
+		}
+
+		if word != 0 {
+			return w<<6 + bits.TrailingZeros64(word)
 		}
 	}
 
-	return nil
+	return -1
+}
+
+// Prev returns the index of the last non-empty child at or before i, or -1 if
+// there is none.
+func (n *Node256[T]) Prev(i int) int {
+	if i > 255 {
+		i = 255
+	}
+
+	for w := i >> 6; w >= 0; w-- {
+		word := n.Occupied[w]
+		if w == i>>6 {
+			word &= ^uint64(0) >> (63 - i&63)
+		}
+
+		if word != 0 {
+			return w<<6 + 63 - bits.LeadingZeros64(word)
+		}
+	}
+
+	return -1
 }
 
 // FindChild returns the child node for the given key byte.
@@ -164,6 +227,8 @@ func (n *Node256[T]) Maximum() *Leaf[T] {
 //   - Check if reference is non-zero
 //   - Return pointer to reference or nil
 func (n *Node256[T]) FindChild(b int) *Ref[T] {
+	n.verify()
+
 	if b < 0 {
 		if n.ZeroSizedChild.Empty() {
 			return nil
@@ -199,7 +264,7 @@ func (n *Node256[T]) FindChild(b int) *Ref[T] {
 //
 // Algorithm:
 //   - Check if position already has a child (Children[b] != 0)
-//   - If no existing child: increment NumChildren counter
+//   - If no existing child: increment NumChildren counter and set its occupancy bit
 //   - Assign child reference to Children[b]
 //   - No shifting or reordering required
 //
@@ -209,6 +274,12 @@ func (n *Node256[T]) FindChild(b int) *Ref[T] {
 //   - Memory operations: Single array assignment
 //   - No shifting or reordering overhead
 func (n *Node256[T]) AddChild(b int, child AsRef[T]) {
+	if debug.Enabled {
+		n.checkChild(child.Ref())
+
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		n.ZeroSizedChild = child.Ref()
 
@@ -219,6 +290,7 @@ func (n *Node256[T]) AddChild(b int, child AsRef[T]) {
 
 	if n.Children[k] == 0 {
 		n.NumChildren++
+		n.Occupied[k>>6] |= 1 << (k & 63)
 	}
 
 	n.Children[k] = child.Ref()
@@ -259,6 +331,7 @@ func (n *Node256[T]) Grow(arena.Allocator) Node[T] {
 //
 // Algorithm:
 //   - Set Children[b] = 0 (clear the child reference)
+//   - Clear the occupancy bit of b
 //   - Decrement NumChildren counter
 //   - No shifting or reordering required
 //
@@ -267,6 +340,10 @@ func (n *Node256[T]) Grow(arena.Allocator) Node[T] {
 //   - Space complexity: O(1)
 //   - Memory operations: Single array assignment
 func (n *Node256[T]) RemoveChild(b int, child *Ref[T]) {
+	if debug.Enabled {
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		if &n.ZeroSizedChild == child {
 			n.ZeroSizedChild = 0
@@ -278,6 +355,7 @@ func (n *Node256[T]) RemoveChild(b int, child *Ref[T]) {
 	k := byte(b)
 
 	n.Children[k] = 0
+	n.Occupied[k>>6] &^= 1 << (k & 63)
 	n.NumChildren--
 }
 
@@ -305,16 +383,25 @@ func (n *Node256[T]) RemoveChild(b int, child *Ref[T]) {
 //
 // Conversion Process:
 //   - Create new Node48 with same base information
-//   - Scan Children array for non-zero entries
+//   - Scan the occupancy bitmap for non-empty children
 //   - Copy valid children to sparse array positions
 //   - Map keys to sparse array using 1-based indexing
 //
 // Performance:
-//   - Time complexity: O(256) - scan all possible positions
+//   - Time complexity: O(k) - only the k non-empty children are visited
 //   - Space complexity: O(1) (fixed array sizes)
 //   - Memory allocation: One Node48 structure (if shrinking)
 func (n *Node256[T]) Shrink(a arena.AllocatorExt) Node[T] {
-	if n.NumChildren >= 37 {
+	return n.ShrinkBelow(a, DefaultShrinkPolicy.Node256)
+}
+
+// ShrinkBelow shrinks the node to a Node48 if it has less than min children,
+// like [Node256.Shrink] does with a fixed threshold.
+//
+// The node is never shrunk if it has more children than a Node48 can hold (48),
+// so a min of zero disables shrinking.
+func (n *Node256[T]) ShrinkBelow(a arena.AllocatorExt, min int) Node[T] {
+	if n.NumChildren >= min || n.NumChildren > 48 {
 		return n
 	}
 
@@ -323,17 +410,17 @@ func (n *Node256[T]) Shrink(a arena.AllocatorExt) Node[T] {
 
 	// Copy children from direct array to sparse array
 	var pos byte
-	for i := 0; i < 256; i++ {
-		if n.Children[i] != 0 {
-			newNode.Children[pos] = n.Children[i]
-			newNode.Keys[i] = pos + 1
-			pos++
-		}
+	for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+		newNode.Children[pos] = n.Children[i]
+		newNode.Keys[i] = pos + 1
+		pos++
 	}
 
 	// Free the original Node256 since we're replacing it
 	arena.Free(a, n)
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -354,3 +441,10 @@ func (n *Node256[T]) Release(a arena.Allocator) {
 
 	arena.Free(a, n)
 }
+
+// Seal updates the checksum of the node header after its fields are modified directly.
+//
+// The checksum is only verified when built with the debug tag, Seal does nothing otherwise.
+func (n *Node256[T]) Seal() { n.Base.seal(bytesOf(&n.Occupied)) }
+
+func (n *Node256[T]) verify() { n.Base.verify(n, bytesOf(&n.Occupied)) }
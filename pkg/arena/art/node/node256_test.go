@@ -617,3 +617,63 @@ func TestNode256_Shrink(t *testing.T) {
 		})
 	})
 }
+
+func TestNode256_Occupied(t *testing.T) {
+	Convey("Given a Node256 with sparse children", t, func() {
+		a := &arena.Arena{}
+		node := arena.New(a, Node256[any]{})
+
+		keys := []int{0, 3, 63, 64, 130, 255}
+		for _, k := range keys {
+			node.AddChild(k, NewLeaf[any](a, []byte{byte(k)}, k))
+		}
+
+		Convey("Then Next should only visit the non-empty children in order", func() {
+			var visited []int
+			for i := node.Next(0); i >= 0; i = node.Next(i + 1) {
+				visited = append(visited, i)
+			}
+
+			So(visited, ShouldResemble, keys)
+			So(node.Next(4), ShouldEqual, 63)
+			So(node.Next(256), ShouldEqual, -1)
+		})
+
+		Convey("Then Prev should only visit the non-empty children in reverse order", func() {
+			var visited []int
+			for i := node.Prev(255); i >= 0; i = node.Prev(i - 1) {
+				visited = append(visited, i)
+			}
+
+			So(visited, ShouldResemble, []int{255, 130, 64, 63, 3, 0})
+			So(node.Prev(62), ShouldEqual, 3)
+			So(node.Prev(-1), ShouldEqual, -1)
+		})
+
+		Convey("Then Minimum and Maximum should use the bitmap", func() {
+			So(node.Minimum().Value, ShouldEqual, 0)
+			So(node.Maximum().Value, ShouldEqual, 255)
+		})
+
+		Convey("When removing children", func() {
+			node.RemoveChild(0, node.FindChild(0))
+			node.RemoveChild(255, node.FindChild(255))
+
+			Convey("Then their bits should be cleared", func() {
+				So(node.Next(0), ShouldEqual, 3)
+				So(node.Prev(255), ShouldEqual, 130)
+				So(node.Minimum().Value, ShouldEqual, 3)
+				So(node.Maximum().Value, ShouldEqual, 130)
+			})
+		})
+
+		Convey("When shrinking to a Node48 and growing back", func() {
+			n48 := node.Shrink(a).(*Node48[any])
+			n256 := n48.Grow(a).(*Node256[any])
+
+			Convey("Then the bitmap should be rebuilt", func() {
+				So(n256.Occupied, ShouldResemble, node.Occupied)
+			})
+		})
+	})
+}
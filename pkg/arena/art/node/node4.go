@@ -85,6 +85,13 @@ func (n *Node4[T]) Ref() Ref[T] { return NewRef[T](TypeNode4, n) }
 //
 // Performance: O(1) for the first level, then O(depth) for traversal
 func (n *Node4[T]) Minimum() *Leaf[T] {
+	n.verify()
+
+	if !n.ZeroSizedChild.Empty() {
+		// The key ending at this node is less than the keys of the children.
+		return n.ZeroSizedChild.AsLeaf()
+	}
+
 	if n.NumChildren == 0 {
 		return nil
 	}
@@ -103,8 +110,10 @@ func (n *Node4[T]) Minimum() *Leaf[T] {
 //
 // Performance: O(1) for the last level, then O(depth) for traversal
 func (n *Node4[T]) Maximum() *Leaf[T] {
+	n.verify()
+
 	if n.NumChildren == 0 {
-		return nil
+		return n.ZeroSizedChild.AsLeaf()
 	}
 	return n.Children[n.NumChildren-1].AsNode().Maximum()
 }
@@ -132,6 +141,8 @@ func (n *Node4[T]) Maximum() *Leaf[T] {
 //   - Early termination on match
 //   - Returns corresponding child reference
 func (n *Node4[T]) FindChild(b int) *Ref[T] {
+	n.verify()
+
 	if b < 0 {
 		if n.ZeroSizedChild.Empty() {
 			return nil
@@ -176,6 +187,12 @@ func (n *Node4[T]) FindChild(b int) *Ref[T] {
 //   - Space complexity: O(1) (fixed array size)
 //   - Memory operations: Array shifting for sorted order
 func (n *Node4[T]) AddChild(b int, child AsRef[T]) {
+	if debug.Enabled {
+		n.checkChild(child.Ref())
+
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		n.ZeroSizedChild = child.Ref()
 
@@ -239,6 +256,8 @@ func (n *Node4[T]) Grow(a arena.Allocator) Node[T] {
 	copy(newNode.Keys[:], n.Keys[:n.NumChildren])
 	copy(newNode.Children[:], n.Children[:n.NumChildren])
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -261,6 +280,10 @@ func (n *Node4[T]) Grow(a arena.Allocator) Node[T] {
 //   - Space complexity: O(1)
 //   - Memory operations: Array shifting to maintain order
 func (n *Node4[T]) RemoveChild(b int, child *Ref[T]) {
+	if debug.Enabled {
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		if &n.ZeroSizedChild == child {
 			n.ZeroSizedChild = 0
@@ -297,6 +320,7 @@ func (n *Node4[T]) RemoveChild(b int, child *Ref[T]) {
 //
 // Shrinking Logic:
 //   - If multiple children: return self (no shrinking possible)
+//   - If a child and the zero sized child: return self
 //   - If single child is leaf: return the leaf directly
 //   - If single child is node: combine prefixes and return child
 //
@@ -305,17 +329,21 @@ func (n *Node4[T]) RemoveChild(b int, child *Ref[T]) {
 //   - Child nodes are preserved and returned
 //   - Prefix concatenation may occur for internal node children
 func (n *Node4[T]) Shrink(a arena.AllocatorExt) Node[T] {
-	if n.NumChildren > 1 {
+	if n.NumChildren > 1 || (n.NumChildren == 1 && !n.ZeroSizedChild.Empty()) {
 		return n
 	}
 
 	child := n.Children[0]
+	if n.NumChildren == 0 {
+		// Only the zero sized child is left, which is always a leaf.
+		child = n.ZeroSizedChild
+	}
 
 	if !child.IsLeaf() {
 		// If the child is a node, we need to concatenate the prefix and the child's prefix.
 		if c := child.AsNode(); c != nil {
-			// Append the key byte to the current prefix
-			n.Partial = n.Partial.AppendOne(a, n.Keys[0])
+			// Append the key byte and the child's prefix to the current prefix
+			n.Partial = n.Partial.AppendOne(a, n.Keys[0]).Append(a, c.Prefix().Raw()...)
 
 			// Release the child's old prefix and set the new combined prefix
 			c.Prefix().Release(a)
@@ -352,3 +380,10 @@ func (n *Node4[T]) Release(a arena.Allocator) {
 
 	arena.Free(a, n)
 }
+
+// Seal updates the checksum of the node header after its fields are modified directly.
+//
+// The checksum is only verified when built with the debug tag, Seal does nothing otherwise.
+func (n *Node4[T]) Seal() { n.Base.seal(n.Keys[:]) }
+
+func (n *Node4[T]) verify() { n.Base.verify(n, n.Keys[:]) }
@@ -99,6 +99,13 @@ func (n *Node48[T]) Ref() Ref[T] { return NewRef[T](TypeNode48, n) }
 //   - Space complexity: O(1)
 //   - SIMD acceleration: Available for finding first non-zero key
 func (n *Node48[T]) Minimum() *Leaf[T] {
+	n.verify()
+
+	if !n.ZeroSizedChild.Empty() {
+		// The key ending at this node is less than the keys of the children.
+		return n.ZeroSizedChild.AsLeaf()
+	}
+
 	if n.NumChildren == 0 {
 		return nil
 	}
@@ -127,8 +134,10 @@ func (n *Node48[T]) Minimum() *Leaf[T] {
 //   - Space complexity: O(1)
 //   - SIMD acceleration: Available for finding last non-zero key
 func (n *Node48[T]) Maximum() *Leaf[T] {
+	n.verify()
+
 	if n.NumChildren == 0 {
-		return nil
+		return n.ZeroSizedChild.AsLeaf()
 	}
 
 	// Find the last non-zero key in the Keys array using SIMD optimization
@@ -165,6 +174,8 @@ func (n *Node48[T]) Maximum() *Leaf[T] {
 //   - If valid, access Children[Keys[b]-1] (1-based indexing)
 //   - Return child reference or nil if not found
 func (n *Node48[T]) FindChild(b int) *Ref[T] {
+	n.verify()
+
 	if b < 0 {
 		if n.ZeroSizedChild.Empty() {
 			return nil
@@ -208,6 +219,12 @@ func (n *Node48[T]) FindChild(b int) *Ref[T] {
 //   - Space complexity: O(1)
 //   - Memory operations: Direct assignment to sparse arrays
 func (n *Node48[T]) AddChild(b int, child AsRef[T]) {
+	if debug.Enabled {
+		n.checkChild(child.Ref())
+
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		n.ZeroSizedChild = child.Ref()
 
@@ -278,9 +295,12 @@ func (n *Node48[T]) Grow(a arena.Allocator) Node[T] {
 	for i := 0; i < 256; i++ {
 		if n.Keys[i] != 0 {
 			newNode.Children[i] = n.Children[n.Keys[i]-1]
+			newNode.Occupied[i>>6] |= 1 << (i & 63)
 		}
 	}
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -304,6 +324,10 @@ func (n *Node48[T]) Grow(a arena.Allocator) Node[T] {
 //   - Space complexity: O(1)
 //   - Memory operations: Two array assignments
 func (n *Node48[T]) RemoveChild(b int, child *Ref[T]) {
+	if debug.Enabled {
+		defer n.Seal()
+	}
+
 	if b < 0 {
 		if &n.ZeroSizedChild == child {
 			n.ZeroSizedChild = 0
@@ -359,7 +383,16 @@ func (n *Node48[T]) RemoveChild(b int, child *Ref[T]) {
 //   - Space complexity: O(1) (fixed array sizes)
 //   - Memory allocation: One Node16 structure (if shrinking)
 func (n *Node48[T]) Shrink(a arena.AllocatorExt) Node[T] {
-	if n.NumChildren >= 12 {
+	return n.ShrinkBelow(a, DefaultShrinkPolicy.Node48)
+}
+
+// ShrinkBelow shrinks the node to a Node16 if it has less than min children,
+// like [Node48.Shrink] does with a fixed threshold.
+//
+// The node is never shrunk if it has more children than a Node16 can hold (16),
+// so a min of zero disables shrinking.
+func (n *Node48[T]) ShrinkBelow(a arena.AllocatorExt, min int) Node[T] {
+	if n.NumChildren >= min || n.NumChildren > 16 {
 		return n
 	}
 
@@ -379,6 +412,8 @@ func (n *Node48[T]) Shrink(a arena.AllocatorExt) Node[T] {
 	// Free the original Node48 since we're replacing it
 	arena.Free(a, n)
 
+	newNode.Seal()
+
 	return newNode
 }
 
@@ -399,3 +434,10 @@ func (n *Node48[T]) Release(a arena.Allocator) {
 
 	arena.Free(a, n)
 }
+
+// Seal updates the checksum of the node header after its fields are modified directly.
+//
+// The checksum is only verified when built with the debug tag, Seal does nothing otherwise.
+func (n *Node48[T]) Seal() { n.Base.seal(n.Keys[:]) }
+
+func (n *Node48[T]) verify() { n.Base.verify(n, n.Keys[:]) }
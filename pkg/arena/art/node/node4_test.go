@@ -428,6 +428,46 @@ func TestNode4_Shrink(t *testing.T) {
 			})
 		})
 
+		Convey("When shrinking with exactly 1 inner node child", func() {
+			inner := arena.New(a, Node4[any]{})
+			inner.Partial = slice.FromString(a, "xy")
+			inner.AddChild(int('a'), child1)
+			inner.AddChild(int('b'), child2)
+
+			n.AddChild(int('w'), inner)
+
+			result := n.Shrink(a)
+
+			Convey("Then should return the child with the combined prefix", func() {
+				So(result, ShouldEqual, inner)
+				So(string(inner.Partial.Raw()), ShouldEqual, hello+"wxy")
+			})
+		})
+
+		Convey("When shrinking with 1 child and a zero sized child", func() {
+			n.AddChild(int('a'), child1)
+			n.AddChild(-1, child2)
+
+			result := n.Shrink(a)
+
+			Convey("Then should return the same node", func() {
+				So(result, ShouldEqual, n)
+				So(n.ZeroSizedChild.AsLeaf(), ShouldEqual, child2)
+			})
+		})
+
+		Convey("When shrinking with only a zero sized child", func() {
+			n.AddChild(-1, child2)
+
+			So(n.NumChildren, ShouldEqual, 0)
+
+			result := n.Shrink(a)
+
+			Convey("Then should return the zero sized child", func() {
+				So(result, ShouldEqual, child2)
+			})
+		})
+
 		Convey("When shrinking with no children", func() {
 			So(n.NumChildren, ShouldEqual, 0)
 
@@ -108,6 +108,12 @@ func (r Ref[T]) Type() Type { return Type(uintptr(r) & nodeTypeMask) }
 // This is commonly used to check for uninitialized or invalid references.
 func (r Ref[T]) Empty() bool { return r == 0 }
 
+// Addr returns the address of the node this reference points to.
+//
+// It is intended for diagnostics such as memory layout dumps, the node must be
+// accessed through the type-safe accessors.
+func (r Ref[T]) Addr() xunsafe.Addr[byte] { return xunsafe.Addr[byte](uintptr(r) & nodePtrMask) }
+
 // IsLeaf returns true if this reference points to a leaf node.
 //
 // Leaf nodes are terminal nodes that store key-value pairs and cannot have children.
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// ShrinkPolicy controls when nodes are converted to a smaller node type after
+// removing a child.
+//
+// Each field is the number of children below which a node of that type shrinks.
+// Lowering a threshold, or setting it to zero to disable shrinking, leaves a gap
+// between the grow and shrink points, which avoids repeatedly converting nodes
+// back and forth in workloads hovering around a boundary, such as deleting and
+// re-inserting keys.
+//
+// A Node4 with a single child is always merged into its parent regardless of the
+// policy, since this is required by the path compression.
+type ShrinkPolicy struct {
+	// Node16 is the number of children below which a Node16 shrinks to a Node4.
+	Node16 int
+
+	// Node48 is the number of children below which a Node48 shrinks to a Node16.
+	Node48 int
+
+	// Node256 is the number of children below which a Node256 shrinks to a Node48.
+	Node256 int
+}
+
+var (
+	// DefaultShrinkPolicy is the policy used by the Shrink method of the nodes.
+	DefaultShrinkPolicy = ShrinkPolicy{Node16: 3, Node48: 12, Node256: 37}
+
+	// NeverShrink is a policy which disables shrinking.
+	NeverShrink = ShrinkPolicy{}
+)
+
+// Shrink converts n to a smaller node type according to the policy p.
+//
+// The default policy is used if p is nil.
+func Shrink[T any](a arena.AllocatorExt, n Node[T], p *ShrinkPolicy) Node[T] {
+	if p == nil {
+		return n.Shrink(a)
+	}
+
+	switch n := n.(type) {
+	case *Node16[T]:
+		return n.ShrinkBelow(a, p.Node16)
+	case *Node48[T]:
+		return n.ShrinkBelow(a, p.Node48)
+	case *Node256[T]:
+		return n.ShrinkBelow(a, p.Node256)
+	default:
+		return n.Shrink(a)
+	}
+}
@@ -0,0 +1,80 @@
+package node_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	. "github.com/flier/goutil/pkg/arena/art/node"
+)
+
+func TestShrink(t *testing.T) {
+	Convey("Given a Node48 with 10 children", t, func() {
+		a := &arena.Arena{}
+
+		n := arena.New(a, Node48[any]{})
+
+		for i := 0; i < 10; i++ {
+			n.AddChild(i, NewLeaf[any](a, []byte{byte(i)}, nil))
+		}
+
+		Convey("When shrinking with the default policy", func() {
+			result := Shrink[any](a, n, nil)
+
+			Convey("Then should return a Node16", func() {
+				So(result.Type(), ShouldEqual, TypeNode16)
+				So(result.(*Node16[any]).NumChildren, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When shrinking with a lower threshold", func() {
+			result := Shrink[any](a, n, &ShrinkPolicy{Node48: 8})
+
+			Convey("Then should return the same node", func() {
+				So(result, ShouldPointTo, n)
+			})
+		})
+
+		Convey("When shrinking is disabled", func() {
+			result := Shrink[any](a, n, &NeverShrink)
+
+			Convey("Then should return the same node", func() {
+				So(result, ShouldPointTo, n)
+			})
+		})
+	})
+
+	Convey("Given a Node16 with 1 child", t, func() {
+		a := &arena.Arena{}
+
+		n := arena.New(a, Node16[any]{})
+		n.AddChild(1, NewLeaf[any](a, []byte{1}, nil))
+
+		Convey("When shrinking below more children than a Node4 holds", func() {
+			result := n.ShrinkBelow(a, 16)
+
+			Convey("Then should return a Node4", func() {
+				So(result.Type(), ShouldEqual, TypeNode4)
+			})
+		})
+	})
+
+	Convey("Given a Node16 with 8 children", t, func() {
+		a := &arena.Arena{}
+
+		n := arena.New(a, Node16[any]{})
+
+		for i := 0; i < 8; i++ {
+			n.AddChild(i, NewLeaf[any](a, []byte{byte(i)}, nil))
+		}
+
+		Convey("When the threshold is above the capacity of a Node4", func() {
+			result := n.ShrinkBelow(a, 16)
+
+			Convey("Then should return the same node", func() {
+				So(result, ShouldPointTo, n)
+			})
+		})
+	})
+}
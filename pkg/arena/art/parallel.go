@@ -0,0 +1,202 @@
+//go:build go1.23
+
+package art
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// parallelBatchSize is the number of keys sent to a shard at once by [BuildParallel].
+const parallelBatchSize = 1024
+
+// parallelBatch is a batch of key-value pairs, with the keys packed in a single buffer.
+type parallelBatch[T any] struct {
+	keys   []byte
+	ends   []int
+	values []T
+}
+
+func (b *parallelBatch[T]) add(key []byte, value T) {
+	b.keys = append(b.keys, key...)
+	b.ends = append(b.ends, len(b.keys))
+	b.values = append(b.values, value)
+}
+
+// BuildParallel builds a tree from the key-value pairs of seq using shards goroutines.
+//
+// The pairs are partitioned by the leading byte of their keys, the subtree of every
+// leading byte is built concurrently by its shard, each allocating from its own arena,
+// and the subtrees are then grafted under the root, which is allocated from arenas[0].
+// The resulting tree is the same as inserting the pairs in order with [Tree.Insert],
+// so a later value replaces an earlier one with the same key.
+//
+// shards is clamped between one and len(arenas), and no arena may be used by anything
+// else until BuildParallel returns. The arenas must be reset together, see [arena.LinkArenas].
+// The keys are copied, so seq may reuse its buffers.
+//
+// It panics with [ErrKeyTooLong] if a key is longer than [MaxKeyLen].
+//
+// Example:
+//
+//	arenas := make([]*arena.Arena, runtime.GOMAXPROCS(0))
+//	for i := range arenas {
+//	    arenas[i] = new(arena.Arena)
+//	}
+//
+//	t := art.BuildParallel(arenas, len(arenas), maps.All(index))
+func BuildParallel[T any](arenas []*arena.Arena, shards int, seq iter.Seq2[[]byte, T]) *Tree[T] {
+	if len(arenas) == 0 {
+		panic("art: BuildParallel without any arena")
+	}
+
+	shards = min(max(shards, 1), len(arenas))
+
+	// The root and the later inserts may link nodes across the arenas of the shards.
+	arena.LinkArenas(arenas...)
+
+	t := new(Tree[T])
+
+	var (
+		subtrees [256]node.Ref[T]
+		counts   [256]int
+		empty    *T // The value of the empty key, which is not sent to any shard.
+		wg       sync.WaitGroup
+	)
+
+	inputs := make([]chan *parallelBatch[T], shards)
+
+	for i := range inputs {
+		inputs[i] = make(chan *parallelBatch[T], 4)
+
+		wg.Add(1)
+
+		go func(a *arena.Arena, input <-chan *parallelBatch[T]) {
+			defer wg.Done()
+
+			for batch := range input {
+				start := 0
+
+				for j, end := range batch.ends {
+					key := batch.keys[start:end]
+					start = end
+
+					leaf := node.NewLeaf(a, key, batch.values[j])
+					if tree.RecursiveInsert(a, &subtrees[key[0]], leaf, 1, true) == nil {
+						counts[key[0]]++
+					}
+				}
+			}
+		}(arenas[i], inputs[i])
+	}
+
+	batches := make([]*parallelBatch[T], shards)
+
+	func() {
+		defer func() {
+			for i, batch := range batches {
+				if batch != nil {
+					inputs[i] <- batch
+				}
+
+				close(inputs[i])
+			}
+
+			wg.Wait()
+		}()
+
+		for key, value := range seq {
+			if err := t.checkKey(key); err != nil {
+				panic(err)
+			}
+
+			if len(key) == 0 {
+				empty = &value
+
+				continue
+			}
+
+			i := int(key[0]) % shards
+
+			if batches[i] == nil {
+				batches[i] = &parallelBatch[T]{}
+			}
+
+			batches[i].add(key, value)
+
+			if len(batches[i].ends) == parallelBatchSize {
+				inputs[i] <- batches[i]
+				batches[i] = nil
+			}
+		}
+	}()
+
+	t.root, t.n = graft(arenas[0], &subtrees, &counts, empty)
+
+	return t
+}
+
+// graft builds the root of a tree from the subtrees of every leading byte, built at
+// depth one, and the value of the empty key if any.
+func graft[T any](a arena.Allocator, subtrees *[256]node.Ref[T], counts *[256]int, empty *T) (node.Ref[T], int) {
+	var first, children, n int
+
+	for b := len(subtrees) - 1; b >= 0; b-- {
+		if !subtrees[b].Empty() {
+			first = b
+			children++
+			n += counts[b]
+		}
+	}
+
+	switch {
+	case children == 0 && empty == nil:
+		return 0, 0
+
+	case children == 0:
+		return node.NewLeaf(a, []byte{}, *empty).Ref(), 1
+
+	case children == 1 && empty == nil:
+		sub := subtrees[first]
+		if sub.IsLeaf() {
+			return sub, n
+		}
+
+		// Move the leading byte into the prefix of the only subtree.
+		sn := sub.AsNode()
+		prefix := slice.Make[byte](a, 1+sn.Prefix().Len())
+		prefix.Store(0, byte(first))
+		copy(prefix.Raw()[1:], sn.Prefix().Raw())
+		sn.SetPrefix(prefix)
+
+		return sub, n
+	}
+
+	var root node.Node[T] = arena.New(a, node.Node4[T]{})
+
+	for b := range subtrees {
+		if subtrees[b].Empty() {
+			continue
+		}
+
+		if root.Full() {
+			root = root.Grow(a)
+		}
+
+		root.AddChild(b, subtrees[b])
+	}
+
+	if empty != nil {
+		root.AddChild(-1, node.NewLeaf(a, []byte{}, *empty))
+		n++
+	}
+
+	root.AddLeaves(n)
+
+	return root.Ref(), n
+}
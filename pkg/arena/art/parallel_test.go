@@ -0,0 +1,148 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"maps"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func newArenas(n int) []*arena.Arena {
+	arenas := make([]*arena.Arena, n)
+	for i := range arenas {
+		arenas[i] = new(arena.Arena)
+	}
+
+	return arenas
+}
+
+func pairs(keys ...string) func(yield func([]byte, int) bool) {
+	return func(yield func([]byte, int) bool) {
+		buf := make([]byte, 0, 64) // Reused for every key.
+
+		for i, key := range keys {
+			if !yield(append(buf[:0], key...), i) {
+				return
+			}
+		}
+	}
+}
+
+func TestBuildParallel(t *testing.T) {
+	Convey("Given random keys", t, func() {
+		rng := rand.New(rand.NewSource(42))
+
+		var keys []string
+
+		for i := 0; i < 5000; i++ {
+			keys = append(keys, strconv.Itoa(rng.Intn(3000))+string(rune('a'+rng.Intn(26))))
+		}
+
+		keys = append(keys, "", "x", "")
+
+		Convey("When building a tree in parallel", func() {
+			arenas := newArenas(8)
+			defer runtime.KeepAlive(arenas) // The trees are on the heap, keep their nodes alive.
+			tr := art.BuildParallel(arenas, 8, pairs(keys...))
+
+			Convey("Then it should match a tree built serially", func() {
+				a := new(arena.Arena)
+				defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+				want := &art.Tree[int]{}
+
+				for key, value := range pairs(keys...) {
+					want.Insert(a, key, value)
+				}
+
+				So(tr.Len(), ShouldEqual, want.Len())
+				So(maps.Collect(tr.AllStrings()), ShouldResemble, maps.Collect(want.AllStrings()))
+				So(slices.Collect(xiterKeys(tr)), ShouldResemble, slices.Collect(xiterKeys(want)))
+				So(string(tr.Minimum().Key.Raw()), ShouldEqual, string(want.Minimum().Key.Raw()))
+				So(string(tr.Maximum().Key.Raw()), ShouldEqual, "x")
+
+				for i := 0; i < tr.Len(); i += 97 {
+					key := tr.Select(i).Key.Raw()
+
+					So(tr.Rank(key), ShouldEqual, i)
+					So(string(key), ShouldEqual, string(want.Select(i).Key.Raw()))
+				}
+			})
+
+			Convey("Then it should still be modifiable", func() {
+				a := arenas[0]
+				n := tr.Len()
+
+				So(tr.Delete(a, []byte("x")), ShouldNotBeNil)
+				So(tr.Delete(a, []byte("")), ShouldNotBeNil)
+				tr.Insert(a, []byte("new"), -1)
+
+				So(tr.Len(), ShouldEqual, n-1)
+				So(*tr.Search([]byte("new")), ShouldEqual, -1)
+			})
+		})
+
+		Convey("When building with a single shard", func() {
+			arenas := newArenas(1)
+			defer runtime.KeepAlive(arenas) // The trees are on the heap, keep their nodes alive.
+
+			tr := art.BuildParallel(arenas, 0, pairs(keys...))
+
+			So(tr.Len(), ShouldEqual, len(slices.Compact(slices.Sorted(slices.Values(keys)))))
+		})
+	})
+
+	Convey("Given keys sharing a leading byte", t, func() {
+		arenas := newArenas(4)
+		defer runtime.KeepAlive(arenas) // The trees are on the heap, keep their nodes alive.
+
+		tr := art.BuildParallel(arenas, 4, pairs("apple", "apricot", "avocado"))
+
+		Convey("Then the tree should be built under a single prefix", func() {
+			So(tr.Len(), ShouldEqual, 3)
+			So(*tr.Search([]byte("apricot")), ShouldEqual, 1)
+			So(tr.Search([]byte("banana")), ShouldBeNil)
+			So(tr.Rank([]byte("b")), ShouldEqual, 3)
+			So(slices.Collect(xiterKeys(tr)), ShouldResemble, []string{"apple", "apricot", "avocado"})
+		})
+	})
+
+	Convey("Given a single key", t, func() {
+		arenas := newArenas(2)
+		defer runtime.KeepAlive(arenas) // The trees are on the heap, keep their nodes alive.
+
+		tr := art.BuildParallel(arenas, 2, pairs("apple"))
+
+		So(tr.Len(), ShouldEqual, 1)
+		So(*tr.Search([]byte("apple")), ShouldEqual, 0)
+	})
+
+	Convey("Given no keys", t, func() {
+		arenas := newArenas(2)
+		defer runtime.KeepAlive(arenas) // The trees are on the heap, keep their nodes alive.
+
+		tr := art.BuildParallel(arenas, 2, pairs())
+
+		So(tr.Len(), ShouldEqual, 0)
+		So(tr.Minimum(), ShouldBeNil)
+	})
+
+}
+
+func xiterKeys[T any](t *art.Tree[T]) func(yield func(string) bool) {
+	return func(yield func(string) bool) {
+		for key := range t.AllStrings() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// maxPooledSize is the largest block size kept by a [Pool], which fits a Node256.
+const maxPooledSize = 4096
+
+// Pool is a pool of recycled tree nodes that can be shared by multiple trees.
+//
+// Released nodes, leaves and prefixes are kept in exact size free lists and
+// reused by any tree allocating from the same pool, so short-lived trees, e.g.
+// one per request, reuse each other's memory instead of each of them growing
+// the underlying arena separately.
+//
+// Blocks which are not pooled are allocated from, and released back to, the
+// underlying allocator.
+//
+// Example:
+//
+//	pool := art.NewPool(new(arena.Arena))
+//
+//	for _, req := range requests {
+//	    t := &art.Tree[int]{}
+//	    t.Insert(pool, req.Key, req.Value)
+//	    // ...
+//	    t.Clear(pool)
+//	}
+type Pool struct {
+	a    arena.AllocatorExt
+	free [maxPooledSize/arena.Align + 1]xunsafe.Addr[byte]
+}
+
+var _ arena.AllocatorExt = (*Pool)(nil)
+
+// NewPool creates a new pool which allocates from the given allocator.
+func NewPool(a arena.AllocatorExt) *Pool {
+	return &Pool{a: a}
+}
+
+// Alloc allocates memory with the given size, reusing a released block of the same
+// size if there is one.
+func (p *Pool) Alloc(size int) *byte {
+	if class := poolClass(size); class > 0 {
+		if b := p.free[class].AssertValid(); b != nil {
+			p.free[class] = *xunsafe.Cast[xunsafe.Addr[byte]](b)
+
+			xunsafe.Clear(b, class*arena.Align)
+
+			p.Log("reuse", "%p, %d", b, size)
+
+			return b
+		}
+
+		size = class * arena.Align
+	}
+
+	return p.a.Alloc(size)
+}
+
+// Release returns a block to the pool for reuse.
+//
+// Blocks which are too small, too large or misaligned are released to the
+// underlying allocator instead.
+func (p *Pool) Release(b *byte, size int) {
+	addr := xunsafe.AddrOf(b)
+	class := size / arena.Align
+
+	if b == nil || class == 0 || class >= len(p.free) || addr.Padding(arena.Align) != 0 {
+		p.a.Release(b, size)
+		return
+	}
+
+	*xunsafe.Cast[xunsafe.Addr[byte]](b) = p.free[class]
+	p.free[class] = addr
+
+	p.Log("release", "%p, %d", b, size)
+}
+
+func (p *Pool) Next() xunsafe.Addr[byte] { return p.a.Next() }
+func (p *Pool) End() xunsafe.Addr[byte]  { return p.a.End() }
+func (p *Pool) Cap() int                 { return p.a.Cap() }
+func (p *Pool) Advance(n int)            { p.a.Advance(n) }
+
+func (p *Pool) Log(op, format string, args ...any) {
+	p.a.Log(op, format, args...)
+}
+
+// poolClass returns the free list index for an allocation of the given size,
+// or zero if such allocations are not pooled.
+func poolClass(size int) int {
+	class := (size + arena.Align - 1) / arena.Align
+	if class > maxPooledSize/arena.Align {
+		return 0
+	}
+
+	return class
+}
@@ -0,0 +1,139 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+// TestPool tests sharing a node pool across trees
+func TestPool(t *testing.T) {
+	Convey("Given a pool shared by trees", t, func() {
+		a := new(arena.Arena)
+		pool := art.NewPool(a)
+
+		build := func() *art.Tree[int] {
+			tree := &art.Tree[int]{}
+
+			for i := 0; i < 500; i++ {
+				tree.Insert(pool, []byte(fmt.Sprintf("request:%03d", i)), i)
+			}
+
+			return tree
+		}
+
+		Convey("When a tree is cleared", func() {
+			first := build()
+
+			So(first.Len(), ShouldEqual, 500)
+
+			first.Clear(pool)
+
+			So(first.Len(), ShouldEqual, 0)
+			So(first.Search([]byte("request:042")), ShouldBeNil)
+			So(first.Minimum(), ShouldBeNil)
+
+			Convey("Then another tree should reuse its nodes", func() {
+				next, capacity := a.Next(), a.Cap()
+
+				second := build()
+
+				// Only the fragments of split prefixes are not reclaimed.
+				So(a.Cap(), ShouldEqual, capacity)
+				So(a.Next().Sub(next), ShouldBeLessThan, 256)
+
+				So(second.Len(), ShouldEqual, 500)
+
+				for i := 0; i < 500; i++ {
+					So(*second.Search([]byte(fmt.Sprintf("request:%03d", i))), ShouldEqual, i)
+				}
+
+				runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+			})
+		})
+
+		Convey("When trees are interleaved", func() {
+			trees := []*art.Tree[int]{build(), build()}
+
+			for i := 0; i < 500; i += 2 {
+				So(*trees[0].Delete(pool, []byte(fmt.Sprintf("request:%03d", i))), ShouldEqual, i)
+			}
+
+			trees = append(trees, build())
+
+			Convey("Then every tree should keep its own values", func() {
+				So(trees[0].Len(), ShouldEqual, 250)
+				So(trees[1].Len(), ShouldEqual, 500)
+				So(trees[2].Len(), ShouldEqual, 500)
+
+				for i := 0; i < 500; i++ {
+					key := []byte(fmt.Sprintf("request:%03d", i))
+
+					if i%2 == 0 {
+						So(trees[0].Search(key), ShouldBeNil)
+					} else {
+						So(*trees[0].Search(key), ShouldEqual, i)
+					}
+
+					So(*trees[1].Search(key), ShouldEqual, i)
+					So(*trees[2].Search(key), ShouldEqual, i)
+				}
+
+				runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+			})
+		})
+	})
+}
+
+func TestTree_RecycledSync(t *testing.T) {
+	Convey("Given trees sharing a thread-safe recycling allocator", t, func() {
+		a := &arena.RecycledSync{}
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+
+		trees := make([]*art.Tree[int], 4)
+
+		var wg sync.WaitGroup
+
+		for i := range trees {
+			trees[i] = &art.Tree[int]{}
+
+			wg.Add(1)
+
+			go func(tree *art.Tree[int]) {
+				defer wg.Done()
+
+				for round := 0; round < 3; round++ {
+					for j := 0; j < 500; j++ {
+						tree.Insert(a, []byte(fmt.Sprintf("key:%03d", j)), j)
+					}
+
+					for j := 0; j < 500; j += 2 {
+						tree.Delete(a, []byte(fmt.Sprintf("key:%03d", j)))
+					}
+				}
+			}(trees[i])
+		}
+
+		wg.Wait()
+
+		Convey("Then every tree should hold its own values", func() {
+			for _, tree := range trees {
+				So(tree.Len(), ShouldEqual, 250)
+
+				for j := 0; j < 500; j++ {
+					if p := tree.Search([]byte(fmt.Sprintf("key:%03d", j))); j%2 == 0 {
+						So(p, ShouldBeNil)
+					} else {
+						So(*p, ShouldEqual, j)
+					}
+				}
+			}
+		})
+	})
+}
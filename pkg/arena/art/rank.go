@@ -0,0 +1,24 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// Rank returns the number of keys in the tree which are less than the given key.
+//
+// Every node keeps the number of leaves in its subtree, so Rank runs in O(depth)
+// instead of scanning the keys, which makes percentile-style queries cheap:
+//
+//	p50 := t.Select(t.Len() / 2)
+//	below := t.Rank(key) * 100 / t.Len()
+func (t *Tree[T]) Rank(key []byte) int {
+	return tree.Rank(t.root, key)
+}
+
+// Select returns the i-th smallest leaf in the tree, counting from zero.
+//
+// It returns nil if i is out of range.
+func (t *Tree[T]) Select(i int) *node.Leaf[T] {
+	return tree.Select(t.root, i)
+}
@@ -0,0 +1,27 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func ExampleTree_Rank() {
+	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+	t := new(art.Tree[int])
+
+	for i, s := range []string{"apple", "banana", "cherry", "date", "elderberry"} {
+		t.Insert(a, []byte(s), i)
+	}
+
+	fmt.Println(t.Rank([]byte("cherry")))
+	fmt.Println(t.Rank([]byte("coconut")))
+	fmt.Println(string(t.Select(t.Len() / 2).Key.Raw()))
+	// Output:
+	// 2
+	// 3
+	// cherry
+}
@@ -0,0 +1,50 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// ShrinkPolicy controls when nodes are converted to a smaller node type after a
+// key is deleted, see [node.ShrinkPolicy].
+type ShrinkPolicy = node.ShrinkPolicy
+
+var (
+	// DefaultShrinkPolicy is the shrink policy used by trees unless configured otherwise.
+	DefaultShrinkPolicy = node.DefaultShrinkPolicy
+
+	// NeverShrink is a shrink policy which keeps nodes at their largest size.
+	NeverShrink = node.NeverShrink
+)
+
+// SetShrinkPolicy sets the policy controlling when nodes shrink after a key is deleted.
+//
+// Lowering the thresholds below the defaults adds hysteresis between growing and
+// shrinking nodes, which avoids converting them back and forth in workloads that
+// repeatedly delete and re-insert keys around a boundary. Existing nodes are not
+// affected until their next deletion.
+//
+// Example:
+//
+//	t.SetShrinkPolicy(art.ShrinkPolicy{Node16: 2, Node48: 8, Node256: 24})
+//	t.SetShrinkPolicy(art.NeverShrink)
+func (t *Tree[T]) SetShrinkPolicy(p ShrinkPolicy) {
+	t.shrink, t.shrinkSet = p, true
+}
+
+// ShrinkPolicy returns the policy controlling when nodes shrink after a key is deleted.
+func (t *Tree[T]) ShrinkPolicy() ShrinkPolicy {
+	if !t.shrinkSet {
+		return node.DefaultShrinkPolicy
+	}
+
+	return t.shrink
+}
+
+// shrinkPolicy returns the policy of the tree, or nil for the default one.
+func (t *Tree[T]) shrinkPolicy() *ShrinkPolicy {
+	if !t.shrinkSet {
+		return nil
+	}
+
+	return &t.shrink
+}
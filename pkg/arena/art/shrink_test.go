@@ -0,0 +1,58 @@
+package art_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func TestTree_SetShrinkPolicy(t *testing.T) {
+	Convey("Given an ART tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		So(tree.ShrinkPolicy(), ShouldResemble, art.DefaultShrinkPolicy)
+
+		Convey("When shrinking is disabled", func() {
+			tree.SetShrinkPolicy(art.NeverShrink)
+
+			So(tree.ShrinkPolicy(), ShouldResemble, art.NeverShrink)
+
+			Convey("Then keys can be deleted and re-inserted", func() {
+				for round := 0; round < 3; round++ {
+					for i := 0; i < 64; i++ {
+						tree.Insert(a, []byte{byte(i)}, i)
+					}
+
+					So(tree.Len(), ShouldEqual, 64)
+
+					for i := 0; i < 60; i++ {
+						So(*tree.Delete(a, []byte{byte(i)}), ShouldEqual, i)
+					}
+
+					So(tree.Len(), ShouldEqual, 4)
+
+					for i := 60; i < 64; i++ {
+						So(*tree.Search([]byte{byte(i)}), ShouldEqual, i)
+					}
+				}
+			})
+		})
+
+		Convey("When the policy is set on a tree in arena memory", func() {
+			tree := arena.New(a, art.Tree[int]{})
+			tree.SetShrinkPolicy(art.ShrinkPolicy{Node16: 2, Node48: 8, Node256: 24})
+
+			runtime.GC()
+
+			Convey("Then the policy is stored in the tree itself", func() {
+				So(tree.ShrinkPolicy(), ShouldResemble, art.ShrinkPolicy{Node16: 2, Node48: 8, Node256: 24})
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package art
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// SubtreeRef is a handle to the subtree holding all the keys with a given prefix,
+// as returned by [Tree.SeekPrefix].
+//
+// The lookups through the handle start from the root of the subtree, so the prefix
+// is only matched once however many lookups are done under it.
+//
+// Inserting or deleting keys may restructure the subtree, the handle then seeks
+// the prefix again on its next use.
+//
+// Example:
+//
+//	tenant, ok := t.SeekPrefix([]byte("tenant/42/"))
+//	if !ok {
+//	    return
+//	}
+//
+//	user := tenant.Search([]byte("users/alice"))
+type SubtreeRef[T any] struct {
+	t      *Tree[T]
+	root   node.Ref[T]
+	depth  int
+	gen    uint64
+	prefix []byte
+}
+
+// SeekPrefix returns a handle to the subtree holding all the keys with the given prefix.
+//
+// It returns false if no key has the prefix.
+func (t *Tree[T]) SeekPrefix(prefix []byte) (s SubtreeRef[T], ok bool) {
+	s = SubtreeRef[T]{t: t, gen: t.gen, prefix: append([]byte(nil), prefix...)}
+	s.root, s.depth, ok = tree.SeekPrefix(t.root, s.prefix)
+
+	return s, ok
+}
+
+// Prefix returns the prefix of the subtree.
+func (s *SubtreeRef[T]) Prefix() []byte { return s.prefix }
+
+// Search searches for the key made of the prefix of the subtree followed by the given suffix.
+//
+// It returns the value if found, otherwise nil.
+func (s *SubtreeRef[T]) Search(suffix []byte) *T {
+	var buf [64]byte
+
+	key := append(append(buf[:0], s.prefix...), suffix...)
+
+	return tree.SearchFrom(s.resolve(), key, s.depth)
+}
+
+// Visit visits the keys of the subtree in order.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+//
+// The callback function must not insert or delete keys, which panics when built
+// with the debug tag.
+func (s *SubtreeRef[T]) Visit(cb func(key []byte, value *T) bool) bool {
+	return tree.RecursiveIter(s.resolve(), s.t.guard(cb))
+}
+
+// resolve returns the root of the subtree, seeking the prefix again if the tree
+// has been modified since the last time.
+func (s *SubtreeRef[T]) resolve() node.Ref[T] {
+	if s.t == nil {
+		return 0
+	}
+
+	if s.gen != s.t.gen {
+		s.root, s.depth, _ = tree.SeekPrefix(s.t.root, s.prefix)
+		s.gen = s.t.gen
+	}
+
+	return s.root
+}
@@ -0,0 +1,114 @@
+package art_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+)
+
+func ExampleTree_SeekPrefix() {
+	a := new(arena.Arena)
+	defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+	t := new(art.Tree[int])
+
+	for i, s := range []string{"tenant/1/users/alice", "tenant/1/users/bob", "tenant/2/users/carol"} {
+		t.Insert(a, []byte(s), i)
+	}
+
+	tenant, ok := t.SeekPrefix([]byte("tenant/1/"))
+	fmt.Println(ok)
+	fmt.Println(*tenant.Search([]byte("users/bob")))
+	fmt.Println(tenant.Search([]byte("users/carol")))
+
+	tenant.Visit(func(key []byte, value *int) bool {
+		fmt.Println(string(key), *value)
+
+		return false
+	})
+
+	// Output:
+	// true
+	// 1
+	// <nil>
+	// tenant/1/users/alice 0
+	// tenant/1/users/bob 1
+}
+
+func TestTree_SeekPrefix(t *testing.T) {
+	Convey("Given a tree", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := new(art.Tree[int])
+
+		keys := []string{"", "a", "ab", "abc", "abcd", "abcdefgh", "abcdefgz", "abd", "b", "ba"}
+		for i, k := range keys {
+			tree.Insert(a, []byte(k), i)
+		}
+
+		collect := func(s art.SubtreeRef[int]) (got []string) {
+			s.Visit(func(key []byte, value *int) bool {
+				got = append(got, string(key))
+
+				return false
+			})
+
+			return
+		}
+
+		Convey("Every prefix resolves to the keys having it", func() {
+			for _, prefix := range []string{"", "a", "ab", "abc", "abcd", "abcde", "abcdefg", "abcdefgh", "abd", "b", "ba"} {
+				s, ok := tree.SeekPrefix([]byte(prefix))
+				So(ok, ShouldBeTrue)
+
+				var want []string
+				tree.VisitPrefix([]byte(prefix), func(key []byte, value *int) bool {
+					want = append(want, string(key))
+
+					return false
+				})
+
+				So(collect(s), ShouldResemble, want)
+
+				for i, k := range keys {
+					if len(k) >= len(prefix) && k[:len(prefix)] == prefix {
+						p := s.Search([]byte(k[len(prefix):]))
+						So(p, ShouldNotBeNil)
+						So(*p, ShouldEqual, i)
+					}
+				}
+
+				So(s.Search([]byte("zzz")), ShouldBeNil)
+			}
+		})
+
+		Convey("A missing prefix is not found", func() {
+			for _, prefix := range []string{"abe", "abcdefgi", "c", "bab"} {
+				_, ok := tree.SeekPrefix([]byte(prefix))
+				So(ok, ShouldBeFalse)
+			}
+		})
+
+		Convey("The handle follows the modifications of the tree", func() {
+			s, ok := tree.SeekPrefix([]byte("abcdefg"))
+			So(ok, ShouldBeTrue)
+			So(collect(s), ShouldResemble, []string{"abcdefgh", "abcdefgz"})
+
+			tree.Insert(a, []byte("abcdefga"), 42)
+			tree.Delete(a, []byte("abcdefgz"))
+
+			So(collect(s), ShouldResemble, []string{"abcdefga", "abcdefgh"})
+			So(*s.Search([]byte("a")), ShouldEqual, 42)
+			So(s.Search([]byte("z")), ShouldBeNil)
+
+			tree.Clear(a)
+
+			So(collect(s), ShouldBeEmpty)
+			So(s.Search([]byte("h")), ShouldBeNil)
+		})
+	})
+}
@@ -1,17 +1,35 @@
 package art
 
 import (
+	"github.com/flier/goutil/internal/debug"
 	"github.com/flier/goutil/pkg/arena"
 	"github.com/flier/goutil/pkg/arena/art/node"
 	"github.com/flier/goutil/pkg/arena/art/tree"
+	"github.com/flier/goutil/pkg/arena/slice"
+	"github.com/flier/goutil/pkg/xunsafe"
 )
 
 // Tree represents an Adaptive Radix Tree.
 //
 // It is a generic type that can store any type of value.
+//
+// The keys are arbitrary byte strings, including the empty key and keys containing
+// NUL bytes: a key ending inside the tree is stored apart from the children of the
+// node where it ends, so "a" and "a\x00" are distinct keys.
 type Tree[T any] struct {
-	root node.Ref[T]
-	n    int
+	root   node.Ref[T]
+	n      int
+	gen    uint64 // Bumped whenever a key is added or removed.
+	frozen bool
+
+	maxKeyLen int               // Zero means MaxKeyLen.
+	shrink    node.ShrinkPolicy // Unused unless shrinkSet, see Tree.SetShrinkPolicy.
+	shrinkSet bool
+	journal   *journal[T] // Nil means no journal, see Tree.SetJournal.
+	stats     hotSpots    // Empty unless built with the artstats tag, see Tree.HotSpots.
+	filter    *filter     // Nil means no filter, see Tree.SetFilter.
+	cache     *cache[T]   // Nil means no cache, see Tree.SetCache.
+	hooks     []hook[T]   // Nil means no hooks, see Tree.OnChange.
 }
 
 // Len returns the number of elements in the tree.
@@ -23,6 +41,16 @@ func (t *Tree[T]) Len() int {
 //
 // It returns the value if found, otherwise nil.
 func (t *Tree[T]) Search(key []byte) *T {
+	t.hit(key, false)
+
+	if !t.MaybeContains(key) {
+		return nil
+	}
+
+	if t.cache != nil && !t.frozen {
+		return t.cache.search(t, key)
+	}
+
 	return tree.Search(t.root, key)
 }
 
@@ -51,41 +79,142 @@ func (t *Tree[T]) Maximum() *node.Leaf[T] {
 // Insert inserts a new value into the tree.
 //
 // It returns the old value if the key matches the existing key, or nil if the key is inserted.
+//
+// It panics with [ErrKeyTooLong] if the key is longer than [Tree.MaxKeyLen], use
+// [Tree.InsertE] to get an error instead.
 func (t *Tree[T]) Insert(a arena.Allocator, key []byte, value T) *T {
+	t.checkWritable()
+
+	if err := t.checkKey(key); err != nil {
+		panic(err)
+	}
+
+	t.hit(key, true)
+
 	p := tree.RecursiveInsert(a, &t.root, node.NewLeaf(a, key, value), 0, true)
 	if p == nil {
 		t.n++
+		t.gen++
+
+		t.filter.insert(key)
+
+		t.notify(OpInsert, key, nil, &value)
+	} else {
+		t.notify(OpReplace, key, p, &value)
 	}
 
+	t.record(journalInsert, key, &value)
+
 	return p
 }
 
 // InsertNoReplace inserts a new value into the tree without replacing the existing value.
 //
 // It returns the old value if the key matches the existing key, or nil if the key is inserted.
+//
+// It panics with [ErrKeyTooLong] if the key is longer than [Tree.MaxKeyLen], use
+// [Tree.InsertNoReplaceE] to get an error instead.
 func (t *Tree[T]) InsertNoReplace(a arena.Allocator, key []byte, value T) *T {
-	p := tree.RecursiveInsert(a, &t.root, node.NewLeaf(a, key, value), 0, false)
-	if p == nil {
-		t.n++
+	t.checkWritable()
+
+	if err := t.checkKey(key); err != nil {
+		panic(err)
 	}
 
-	return p
+	t.hit(key, true)
+
+	// The existing value is returned in place, copy it like the old value of Insert.
+	if p := tree.RecursiveInsert(a, &t.root, node.NewLeaf(a, key, value), 0, false); p != nil {
+		old := *p
+
+		return &old
+	}
+
+	t.n++
+	t.gen++
+
+	t.filter.insert(key)
+
+	t.notify(OpInsert, key, nil, &value)
+	t.record(journalInsert, key, &value)
+
+	return nil
+}
+
+// Emplace returns a pointer to the value of the key, inserting a zero value first if
+// the key is missing.
+//
+// It returns true if the key is inserted. The pointer stays valid until the key is
+// deleted, so large values can be initialized in place without being copied.
+//
+// The insertion is neither reported to the hooks nor recorded in the journal, since
+// the value isn't initialized yet, call [Tree.Commit] once it is.
+//
+// It panics with [ErrKeyTooLong] if the key is longer than [Tree.MaxKeyLen].
+func (t *Tree[T]) Emplace(a arena.Allocator, key []byte) (value *T, inserted bool) {
+	t.checkWritable()
+
+	if err := t.checkKey(key); err != nil {
+		panic(err)
+	}
+
+	t.hit(key, true)
+
+	// Search first, so replacing a key allocates nothing, even with an arena that
+	// can't release memory.
+	if p := tree.Search(t.root, key); p != nil {
+		return p, false
+	}
+
+	l := arena.New(a, node.Leaf[T]{Key: slice.FromBytes(a, key)})
+
+	if p := tree.RecursiveInsert(a, &t.root, l, 0, false); p != nil {
+		return p, false
+	}
+
+	t.n++
+	t.gen++
+
+	t.filter.insert(key)
+
+	return &l.Value, true
+}
+
+// Commit reports the insertion of a key by [Tree.Emplace] to the hooks, and records
+// it in the journal, with the value initialized in place since.
+//
+// It does nothing if the key is missing.
+func (t *Tree[T]) Commit(key []byte) {
+	if t.hooks == nil && t.journal == nil {
+		return
+	}
+
+	if p := tree.Search(t.root, key); p != nil {
+		t.notify(OpInsert, key, nil, p)
+		t.record(journalInsert, key, p)
+	}
 }
 
 // Delete deletes a value from the tree.
 //
 // It returns the old value if the key matches the existing key, or nil if the key is not found.
 func (t *Tree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
-	l := tree.RecursiveDelete(a, &t.root, key, 0)
+	t.checkWritable()
+
+	l := tree.RecursiveDeleteWith(a, &t.root, key, 0, t.shrinkPolicy())
 	if l == nil {
 		return nil
 	}
 
 	t.n--
+	t.gen++
+
+	t.notify(OpDelete, key, &l.Value, nil)
+	t.record(journalDelete, key, nil)
 
 	old := l.Value
 
-	arena.Free(a, l)
+	l.Release(a)
 
 	return &old
 }
@@ -94,14 +223,59 @@ func (t *Tree[T]) Delete(a arena.AllocatorExt, key []byte) *T {
 //
 // It returns true if the iteration is interrupted by the callback function,
 // otherwise it returns false.
+//
+// The callback function must not insert or delete keys, which panics when built
+// with the debug tag, see [Tree.VisitChecked].
 func (t *Tree[T]) Visit(cb func(key []byte, value *T) bool) bool {
-	return tree.RecursiveIter(t.root, cb)
+	return tree.RecursiveIter(t.root, t.guard(cb))
 }
 
 // VisitPrefix visits the tree with a prefix.
 //
 // It returns true if the iteration is interrupted by the callback function,
 // otherwise it returns false.
+//
+// The callback function must not insert or delete keys, which panics when built
+// with the debug tag, see [Tree.VisitPrefixChecked].
 func (t *Tree[T]) VisitPrefix(prefix []byte, cb func(key []byte, value *T) bool) bool {
-	return tree.IterPrefix(t.root, prefix, cb)
+	return tree.IterPrefix(t.root, prefix, t.guard(cb))
+}
+
+// checkWritable panics in debug mode if the tree has been frozen.
+func (t *Tree[T]) checkWritable() {
+	if debug.Enabled && t.frozen {
+		panic("art: write to a frozen tree")
+	}
+}
+
+// checkHeap panics in debug mode if the tree is in the memory of an arena, before it
+// stores pointers to the Go heap, which the GC doesn't scan there.
+func (t *Tree[T]) checkHeap() {
+	arena.AssertHeap(xunsafe.Addr[byte](xunsafe.AddrOf(t)))
+}
+
+// Clear removes all values from the tree, releasing every node back to the allocator.
+//
+// This is mostly useful with a recycling allocator such as a [Pool], so that the
+// nodes of a short-lived tree can be reused by other trees.
+func (t *Tree[T]) Clear(a arena.Allocator) {
+	t.checkWritable()
+
+	if t.hooks != nil {
+		tree.RecursiveIter(t.root, func(key []byte, value *T) bool {
+			t.notify(OpDelete, key, value, nil)
+
+			return false
+		})
+	}
+
+	tree.RecursiveRelease(a, t.root)
+
+	t.root = 0
+	t.n = 0
+	t.gen++
+
+	t.filter.clear()
+
+	t.record(journalClear, nil, nil)
 }
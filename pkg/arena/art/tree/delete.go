@@ -8,6 +8,14 @@ import (
 
 // RecursiveDelete finds and returns a leaf node that matches the given key.
 func RecursiveDelete[T any](a arena.AllocatorExt, ref *node.Ref[T], key []byte, depth int) *node.Leaf[T] {
+	return RecursiveDeleteWith(a, ref, key, depth, nil)
+}
+
+// RecursiveDeleteWith finds and returns a leaf node that matches the given key like
+// [RecursiveDelete], shrinking the parent node according to the policy p.
+func RecursiveDeleteWith[T any](
+	a arena.AllocatorExt, ref *node.Ref[T], key []byte, depth int, p *node.ShrinkPolicy,
+) *node.Leaf[T] {
 	if ref.Empty() {
 		return nil
 	}
@@ -55,7 +63,9 @@ func RecursiveDelete[T any](a arena.AllocatorExt, ref *node.Ref[T], key []byte,
 	// If the child is a leaf, check if it matches the key
 	if l := child.AsLeaf(); l != nil {
 		if l.Matches(key) {
-			RemoveChild(a, ref, b, child)
+			n.AddLeaves(-1)
+
+			RemoveChildWith(a, ref, b, child, p)
 
 			return l
 		}
@@ -64,17 +74,28 @@ func RecursiveDelete[T any](a arena.AllocatorExt, ref *node.Ref[T], key []byte,
 	}
 
 	// Recursively search in the child node
-	return RecursiveDelete(a, child, key, depth+1)
+	l := RecursiveDeleteWith(a, child, key, depth+1, p)
+	if l != nil {
+		n.AddLeaves(-1)
+	}
+
+	return l
 }
 
 // RemoveChild removes a child node from the current node.
 func RemoveChild[T any](a arena.AllocatorExt, ref *node.Ref[T], key int, child *node.Ref[T]) {
+	RemoveChildWith(a, ref, key, child, nil)
+}
+
+// RemoveChildWith removes a child node from the current node like [RemoveChild],
+// shrinking it according to the policy p.
+func RemoveChildWith[T any](a arena.AllocatorExt, ref *node.Ref[T], key int, child *node.Ref[T], p *node.ShrinkPolicy) {
 	debug.Assert(ref.IsNode(), "ref must be a node")
 
 	curr := ref.AsNode()
 	curr.RemoveChild(key, child)
 
-	if n := curr.Shrink(a); n != curr {
+	if n := node.Shrink(a, curr, p); n != curr {
 		ref.Replace(n)
 	}
 }
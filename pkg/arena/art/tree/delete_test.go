@@ -533,3 +533,56 @@ func TestRecursiveDelete_PathCompression(t *testing.T) {
 		})
 	})
 }
+
+func TestRecursiveDeleteWith(t *testing.T) {
+	Convey("Given a Node48 with 17 children", t, func() {
+		a := new(arena.Arena)
+		var root node.Ref[int]
+
+		for i := 0; i < 17; i++ {
+			RecursiveInsert(a, &root, node.NewLeaf(a, []byte{byte(i)}, i), 0, false)
+		}
+
+		So(root.IsNode48(), ShouldBeTrue)
+
+		Convey("When deleting below the default threshold", func() {
+			for i := 0; i < 6; i++ {
+				So(RecursiveDeleteWith(a, &root, []byte{byte(i)}, 0, nil), ShouldNotBeNil)
+			}
+
+			Convey("Then it should shrink to a Node16", func() {
+				So(root.IsNode16(), ShouldBeTrue)
+				So(*Search(root, []byte{16}), ShouldEqual, 16)
+			})
+		})
+
+		Convey("When deleting with a lower threshold", func() {
+			p := &node.ShrinkPolicy{Node16: 2, Node48: 8, Node256: 24}
+
+			for i := 0; i < 9; i++ {
+				So(RecursiveDeleteWith(a, &root, []byte{byte(i)}, 0, p), ShouldNotBeNil)
+			}
+
+			Convey("Then it should stay a Node48 until reaching the threshold", func() {
+				So(root.IsNode48(), ShouldBeTrue)
+
+				So(RecursiveDeleteWith(a, &root, []byte{9}, 0, p), ShouldNotBeNil)
+
+				So(root.IsNode16(), ShouldBeTrue)
+				So(*Search(root, []byte{16}), ShouldEqual, 16)
+			})
+		})
+
+		Convey("When deleting with shrinking disabled", func() {
+			for i := 0; i < 15; i++ {
+				So(RecursiveDeleteWith(a, &root, []byte{byte(i)}, 0, &node.NeverShrink), ShouldNotBeNil)
+			}
+
+			Convey("Then it should stay a Node48", func() {
+				So(root.IsNode48(), ShouldBeTrue)
+				So(*Search(root, []byte{15}), ShouldEqual, 15)
+				So(*Search(root, []byte{16}), ShouldEqual, 16)
+			})
+		})
+	})
+}
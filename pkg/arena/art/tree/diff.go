@@ -0,0 +1,125 @@
+package tree
+
+import (
+	"bytes"
+
+	"github.com/flier/goutil/pkg/arena/art/node"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// RecursiveDiff compares two trees and calls the callback function for every key
+// whose value differs between them, in key order.
+//
+//   - If the key only exists in the first tree, new is nil.
+//   - If the key only exists in the second tree, old is nil.
+//   - Otherwise, both values exist but are not equal according to eq.
+//
+// Subtrees referenced by both trees are skipped, and nodes with the same prefix are
+// compared child by child, so only the differing parts of the trees are traversed.
+//
+// It returns true if the comparison is interrupted by the callback function,
+// otherwise it returns false.
+func RecursiveDiff[T any](a, b node.Ref[T], eq func(T, T) bool, cb func(key []byte, old, new *T) bool) bool {
+	if a == b {
+		return false
+	}
+
+	if a.Empty() {
+		return RecursiveIter(b, func(key []byte, value *T) bool { return cb(key, nil, value) })
+	}
+
+	if b.Empty() {
+		return RecursiveIter(a, func(key []byte, value *T) bool { return cb(key, value, nil) })
+	}
+
+	if x, y := a.AsLeaf(), b.AsLeaf(); x != nil && y != nil && slice.EqualTo(x.Key, y.Key.Raw()) {
+		if eq(x.Value, y.Value) {
+			return false
+		}
+
+		return cb(x.Key.Raw(), &x.Value, &y.Value)
+	}
+
+	if a.IsNode() && b.IsNode() {
+		x, y := a.AsNode(), b.AsNode()
+
+		// Both nodes are reached by the same path, so with the same prefix their
+		// children share the same keys and can be compared one by one.
+		if slice.EqualTo(x.Prefix(), y.Prefix().Raw()) {
+			for c := -1; c < 256; c++ {
+				if RecursiveDiff(childAt(x, c), childAt(y, c), eq, cb) {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return mergeDiff(a, b, eq, cb)
+}
+
+func childAt[T any](n node.Node[T], b int) node.Ref[T] {
+	if child := n.FindChild(b); child != nil {
+		return *child
+	}
+
+	return 0
+}
+
+type entry[T any] struct {
+	key   []byte
+	value *T
+}
+
+func collect[T any](ref node.Ref[T]) (entries []entry[T]) {
+	RecursiveIter(ref, func(key []byte, value *T) bool {
+		entries = append(entries, entry[T]{key, value})
+		return false
+	})
+
+	return
+}
+
+// mergeDiff compares two subtrees with different shapes by merging their sorted leaves.
+func mergeDiff[T any](a, b node.Ref[T], eq func(T, T) bool, cb func(key []byte, old, new *T) bool) bool {
+	x, y := collect(a), collect(b)
+
+	for len(x) > 0 || len(y) > 0 {
+		var c int
+
+		switch {
+		case len(x) == 0:
+			c = 1
+		case len(y) == 0:
+			c = -1
+		default:
+			c = bytes.Compare(x[0].key, y[0].key)
+		}
+
+		switch {
+		case c < 0:
+			if cb(x[0].key, x[0].value, nil) {
+				return true
+			}
+
+			x = x[1:]
+
+		case c > 0:
+			if cb(y[0].key, nil, y[0].value) {
+				return true
+			}
+
+			y = y[1:]
+
+		default:
+			if !eq(*x[0].value, *y[0].value) && cb(x[0].key, x[0].value, y[0].value) {
+				return true
+			}
+
+			x, y = x[1:], y[1:]
+		}
+	}
+
+	return false
+}
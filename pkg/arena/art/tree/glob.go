@@ -0,0 +1,182 @@
+package tree
+
+import (
+	"math/bits"
+
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// IterGlob iterates over the keys matching a glob pattern using a callback function.
+//
+// The pattern supports '*' matching any sequence of bytes, '?' matching a single byte,
+// and '\' escaping the next byte. The subtrees whose shared prefix can't match the
+// pattern are skipped without visiting their leaves.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func IterGlob[T any](ref node.Ref[T], pattern []byte, cb func(key []byte, value *T) bool) bool {
+	g := compileGlob(pattern)
+
+	return iterGlob(ref, g, g.start(), 0, cb)
+}
+
+func iterGlob[T any](ref node.Ref[T], g glob, s globState, depth int, cb func(key []byte, value *T) bool) bool {
+	if ref.Empty() {
+		return false
+	}
+
+	if g.matchesAll(s) {
+		return RecursiveIter(ref, cb)
+	}
+
+	if l := ref.AsLeaf(); l != nil {
+		key := l.Key.Raw()
+
+		for _, b := range key[depth:] {
+			if s = g.step(s, b); s.empty() {
+				return false
+			}
+		}
+
+		if g.accepts(s) {
+			return cb(key, &l.Value)
+		}
+
+		return false
+	}
+
+	n := ref.AsNode()
+
+	partial := n.Prefix()
+	for i := 0; i < partial.Len(); i++ {
+		if s = g.step(s, partial.Load(i)); s.empty() {
+			return false
+		}
+	}
+
+	depth += partial.Len()
+
+	stopped := false
+
+	eachChild(n, func(key int, child node.Ref[T]) bool {
+		if key < 0 {
+			stopped = iterGlob(child, g, s, depth, cb)
+		} else if next := g.step(s, byte(key)); !next.empty() {
+			stopped = iterGlob(child, g, next, depth+1, cb)
+		}
+
+		return !stopped
+	})
+
+	return stopped
+}
+
+const (
+	globLiteral = iota
+	globAny
+	globStar
+)
+
+type globToken struct {
+	kind byte
+	b    byte
+}
+
+// glob is a compiled glob pattern, matched as a NFA whose states are the token indexes.
+type glob []globToken
+
+// globState is the set of the active states of a glob.
+type globState []uint64
+
+func compileGlob(pattern []byte) (g glob) {
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*':
+			if len(g) == 0 || g[len(g)-1].kind != globStar {
+				g = append(g, globToken{kind: globStar})
+			}
+		case c == '?':
+			g = append(g, globToken{kind: globAny})
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			g = append(g, globToken{kind: globLiteral, b: pattern[i]})
+		default:
+			g = append(g, globToken{kind: globLiteral, b: c})
+		}
+	}
+
+	return
+}
+
+func (g glob) start() globState {
+	s := make(globState, (len(g)+64)/64)
+	g.add(s, 0)
+
+	return s
+}
+
+// add adds the state i and the states reachable from it without consuming a byte.
+func (g glob) add(s globState, i int) {
+	for {
+		s[i/64] |= 1 << (i % 64)
+
+		if i == len(g) || g[i].kind != globStar {
+			return
+		}
+
+		i++
+	}
+}
+
+// step returns the states reached from s by consuming b.
+func (g glob) step(s globState, b byte) globState {
+	next := make(globState, len(s))
+
+	s.each(func(i int) {
+		if i == len(g) {
+			return
+		}
+
+		switch t := g[i]; t.kind {
+		case globStar:
+			g.add(next, i)
+		case globAny:
+			g.add(next, i+1)
+		default:
+			if t.b == b {
+				g.add(next, i+1)
+			}
+		}
+	})
+
+	return next
+}
+
+// accepts returns true if s contains the final state.
+func (g glob) accepts(s globState) bool {
+	return s[len(g)/64]&(1<<(len(g)%64)) != 0
+}
+
+// matchesAll returns true if s contains a state matching any suffix, which is the
+// case of a trailing '*'.
+func (g glob) matchesAll(s globState) bool {
+	return len(g) > 0 && g[len(g)-1].kind == globStar && s[(len(g)-1)/64]&(1<<((len(g)-1)%64)) != 0
+}
+
+func (s globState) empty() bool {
+	for _, w := range s {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s globState) each(f func(i int)) {
+	for i, w := range s {
+		for ; w != 0; w &= w - 1 {
+			f(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+}
@@ -26,7 +26,8 @@ func RecursiveInsert[T any](a arena.Allocator, ref *node.Ref[T], leaf *node.Leaf
 
 // InsertToLeaf inserts a leaf into a leaf node.
 //
-//   - If the leaf matches the key, we need to return the old value, or replace the value if replace is true.
+//   - If the leaf matches the key, we need to return a copy of the old value and replace the value if replace
+//     is true, or return the existing value itself otherwise.
 //   - If the leaf does not match the key, we need to split the leaf into a node4.
 //
 // Do not use this method directly, use [RecursiveInsert] instead.
@@ -35,14 +36,17 @@ func InsertToLeaf[T any](a arena.Allocator, ref *node.Ref[T], leaf *node.Leaf[T]
 
 	curr := ref.AsLeaf()
 
-	// If the leaf matches the key, we need to return the old value
-	if slice.Equal(curr.Key, leaf.Key) {
-		old := curr.Value
-
-		if replace {
-			curr.Value = leaf.Value
+	// If the leaf matches the key, we need to return the old value.
+	//
+	// The keys are compared by content, since an empty key may or may not have a backing array.
+	if curr.Matches(leaf.Key.Raw()) {
+		if !replace {
+			return &curr.Value
 		}
 
+		old := curr.Value
+		curr.Value = leaf.Value
+
 		return &old
 	}
 
@@ -59,6 +63,7 @@ func InsertToLeaf[T any](a arena.Allocator, ref *node.Ref[T], leaf *node.Leaf[T]
 	// Add the leafs to the new node4
 	newNode.AddChild(checkedLoad(leaf.Key, depth), leaf)
 	newNode.AddChild(checkedLoad(curr.Key, depth), ref)
+	newNode.AddLeaves(2)
 
 	ref.Replace(newNode)
 
@@ -102,6 +107,7 @@ func InsertToNode[T any](a arena.Allocator, ref *node.Ref[T], leaf *node.Leaf[T]
 
 			// Add the leaf to the new node
 			newNode.AddChild(checkedLoad(leaf.Key, depth+diff), leaf)
+			newNode.AddLeaves(n.Leaves() + 1)
 
 			ref.Replace(newNode)
 
@@ -113,7 +119,13 @@ func InsertToNode[T any](a arena.Allocator, ref *node.Ref[T], leaf *node.Leaf[T]
 
 	// If the child is found, we need to recurse
 	if child := n.FindChild(key); child != nil && !child.Empty() {
-		return RecursiveInsert(a, child, leaf, depth+1, replace)
+		if old := RecursiveInsert(a, child, leaf, depth+1, replace); old != nil {
+			return old
+		}
+
+		n.AddLeaves(1)
+
+		return nil
 	}
 
 	AddChild(a, ref, key, leaf)
@@ -125,6 +137,7 @@ func AddChild[T any](a arena.Allocator, ref *node.Ref[T], key int, leaf *node.Le
 	debug.Assert(ref.IsNode(), "current node must be a node")
 
 	curr := ref.AsNode()
+	curr.AddLeaves(1)
 
 	// If the child is not found, we need to insert a new leaf
 	if curr.Full() {
@@ -134,6 +147,8 @@ func AddChild[T any](a arena.Allocator, ref *node.Ref[T], key int, leaf *node.Le
 		ref.Replace(newNode)
 
 		if newNode != curr {
+			// The grown node took over the prefix, only release the old node itself.
+			curr.SetPrefix(slice.Slice[byte]{})
 			curr.Release(a)
 		}
 	} else {
@@ -452,7 +452,7 @@ func TestInsertToNode(t *testing.T) {
 		Convey("When inserting to a Node4 with prefix", func() {
 			// Create a Node4 with prefix "hel"
 			node4 := arena.New(a, node.Node4[int]{})
-			node4.Partial = slice.FromBytes(a, []byte("hel"))
+			node4.SetPrefix(slice.FromBytes(a, []byte("hel")))
 			ref := node4.Ref()
 
 			// Add an existing child
@@ -737,7 +737,7 @@ func TestInsertToNode(t *testing.T) {
 				for i := range longPrefix {
 					longPrefix[i] = byte(i % 256)
 				}
-				node4.Partial = slice.FromBytes(a, longPrefix)
+				node4.SetPrefix(slice.FromBytes(a, longPrefix))
 				ref := node4.Ref()
 
 				// Add an existing child
@@ -758,7 +758,7 @@ func TestInsertToNode(t *testing.T) {
 			Convey("And inserting to node with prefix that matches exactly", func() {
 				// Create a Node4 with prefix "hello"
 				node4 := arena.New(a, node.Node4[int]{})
-				node4.Partial = slice.FromBytes(a, []byte("hello"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello")))
 				ref := node4.Ref()
 
 				// Add an existing child
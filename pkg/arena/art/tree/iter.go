@@ -65,11 +65,9 @@ func RecursiveIter[T any](ref node.Ref[T], cb func(key []byte, value *T) bool) b
 			}
 		}
 
-		for i := 0; i < 256; i++ {
-			if !n.Children[i].Empty() {
-				if RecursiveIter(n.Children[i], cb) {
-					return true
-				}
+		for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+			if RecursiveIter(n.Children[i], cb) {
+				return true
 			}
 		}
 	}
@@ -0,0 +1,134 @@
+//go:build go1.21
+
+package tree
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// RecursiveIterDepth iterates over the tree using a callback function, reporting
+// the depth of each leaf.
+//
+// The depth is the number of leading key bytes consumed by the inner nodes on the
+// path to the leaf, that is, their compressed prefixes and branch bytes, starting
+// from the given depth. The remaining key bytes are stored only in the leaf.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func RecursiveIterDepth[T any](ref node.Ref[T], depth int, cb func(leaf *node.Leaf[T], depth int) bool) bool {
+	if ref.Empty() {
+		return false
+	}
+
+	if l := ref.AsLeaf(); l != nil {
+		return cb(l, min(depth, l.Key.Len()))
+	}
+
+	n := ref.AsNode()
+
+	depth += n.Prefix().Len()
+
+	switch n := n.(type) {
+	case *node.Node4[T]:
+		if RecursiveIterDepth(n.ZeroSizedChild, depth, cb) {
+			return true
+		}
+
+		for i := 0; i < n.NumChildren; i++ {
+			if RecursiveIterDepth(n.Children[i], depth+1, cb) {
+				return true
+			}
+		}
+
+	case *node.Node16[T]:
+		if RecursiveIterDepth(n.ZeroSizedChild, depth, cb) {
+			return true
+		}
+
+		for i := 0; i < n.NumChildren; i++ {
+			if RecursiveIterDepth(n.Children[i], depth+1, cb) {
+				return true
+			}
+		}
+
+	case *node.Node48[T]:
+		if RecursiveIterDepth(n.ZeroSizedChild, depth, cb) {
+			return true
+		}
+
+		for i := 0; i < 256; i++ {
+			if idx := n.Keys[i]; idx != 0 {
+				if RecursiveIterDepth(n.Children[idx-1], depth+1, cb) {
+					return true
+				}
+			}
+		}
+
+	case *node.Node256[T]:
+		if RecursiveIterDepth(n.ZeroSizedChild, depth, cb) {
+			return true
+		}
+
+		for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+			if RecursiveIterDepth(n.Children[i], depth+1, cb) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IterPrefixDepth iterates over the tree with a prefix using a callback function,
+// reporting the depth of each leaf as [RecursiveIterDepth] does.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func IterPrefixDepth[T any](ref node.Ref[T], prefix []byte, cb func(leaf *node.Leaf[T], depth int) bool) bool {
+	var depth int
+
+	for !ref.Empty() {
+		if l := ref.AsLeaf(); l != nil {
+			if l.MatchesPrefix(prefix) {
+				return cb(l, min(depth, l.Key.Len()))
+			}
+
+			return false
+		}
+
+		n := ref.AsNode()
+
+		// If the depth matches the prefix, we need to handle this node
+		if depth == len(prefix) {
+			if l := n.Minimum(); l != nil && l.MatchesPrefix(prefix) {
+				return RecursiveIterDepth(ref, depth, cb)
+			}
+
+			return false
+		}
+
+		// The prefix may end in the middle of the prefix of the current node
+		if p := n.Prefix(); p.Len() > 0 {
+			m := min(p.Len(), len(prefix)-depth)
+
+			if CheckPrefix(p, prefix, depth) != m {
+				return false
+			} else if depth+m == len(prefix) {
+				return RecursiveIterDepth(ref, depth, cb)
+			}
+
+			depth += p.Len()
+		}
+
+		child := n.FindChild(int(prefix[depth]))
+
+		if child == nil {
+			break
+		}
+
+		ref = *child
+		depth++
+	}
+
+	return false
+}
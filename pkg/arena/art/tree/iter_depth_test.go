@@ -0,0 +1,110 @@
+//go:build go1.21
+
+package tree_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	. "github.com/flier/goutil/pkg/arena/art/node"
+	. "github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// TestRecursiveIterDepth tests the RecursiveIterDepth function
+func TestRecursiveIterDepth(t *testing.T) {
+	Convey("Given RecursiveIterDepth function", t, func() {
+		a := new(arena.Arena)
+
+		Convey("When iterating over an empty reference", func() {
+			var emptyRef Ref[int]
+
+			result := RecursiveIterDepth(emptyRef, 0, func(l *Leaf[int], depth int) bool {
+				panic("unexpected")
+			})
+
+			So(result, ShouldBeFalse)
+		})
+
+		Convey("When iterating over a leaf node", func() {
+			leaf := NewLeaf(a, []byte("hello"), 123)
+			depths := make(map[string]int)
+
+			result := RecursiveIterDepth(leaf.Ref(), 3, func(l *Leaf[int], depth int) bool {
+				depths[string(l.Key.Raw())] = depth
+				return false
+			})
+
+			So(result, ShouldBeFalse)
+			So(depths, ShouldResemble, map[string]int{"hello": 3})
+		})
+
+		Convey("When iterating over a tree", func() {
+			var root Ref[int]
+
+			for i, key := range []string{"hello", "help", "he", "world"} {
+				RecursiveInsert(a, &root, NewLeaf(a, []byte(key), i), 0, true)
+			}
+
+			depths := make(map[string]int)
+
+			result := RecursiveIterDepth(root, 0, func(l *Leaf[int], depth int) bool {
+				depths[string(l.Key.Raw())] = depth
+				return false
+			})
+
+			So(result, ShouldBeFalse)
+			So(depths, ShouldResemble, map[string]int{
+				"he":    2,
+				"hello": 4,
+				"help":  4,
+				"world": 1,
+			})
+
+			Convey("Then IterPrefixDepth should only visit matched leaves", func() {
+				clear(depths)
+
+				IterPrefixDepth(root, []byte("hel"), func(l *Leaf[int], depth int) bool {
+					depths[string(l.Key.Raw())] = depth
+					return false
+				})
+
+				So(depths, ShouldResemble, map[string]int{
+					"hello": 4,
+					"help":  4,
+				})
+			})
+
+			Convey("Then IterPrefixDepth should stop at a mismatch inside a node prefix", func() {
+				var root Ref[int]
+
+				for i, key := range []string{"abxy1", "abxy2"} {
+					RecursiveInsert(a, &root, NewLeaf(a, []byte(key), i), 0, true)
+				}
+
+				var keys []string
+
+				visit := func(l *Leaf[int], depth int) bool {
+					keys = append(keys, string(l.Key.Raw()))
+					return false
+				}
+
+				So(IterPrefixDepth(root, []byte("abc"), visit), ShouldBeFalse)
+				So(IterPrefixDepth(root, []byte("abxz1"), visit), ShouldBeFalse)
+				So(keys, ShouldBeEmpty)
+
+				So(IterPrefixDepth(root, []byte("ab"), visit), ShouldBeFalse)
+				So(keys, ShouldResemble, []string{"abxy1", "abxy2"})
+			})
+
+			Convey("Then early termination should be reported", func() {
+				result := RecursiveIterDepth(root, 0, func(l *Leaf[int], depth int) bool {
+					return true
+				})
+
+				So(result, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -179,14 +179,13 @@ func TestRecursiveIter(t *testing.T) {
 
 		Convey("When iterating over a Node256", func() {
 			node256 := arena.New(a, Node256[int]{})
-			node256.NumChildren = 2
 
 			leaf1 := NewLeaf(a, []byte("hello"), 123)
 			leaf2 := NewLeaf(a, world, 456)
 
 			// Set children at specific indices
-			node256.Children['h'] = leaf1.Ref()
-			node256.Children['w'] = leaf2.Ref()
+			node256.AddChild('h', leaf1)
+			node256.AddChild('w', leaf2)
 
 			ref := node256.Ref()
 			visited := make(map[string]int)
@@ -230,6 +229,8 @@ func TestRecursiveIter(t *testing.T) {
 			root.Children[0] = leftNode.Ref()
 			root.Children[1] = rightNode.Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -413,7 +414,7 @@ func TestIterPrefix(t *testing.T) {
 			// Create a tree with prefix compression
 			root := arena.New(a, Node4[int]{})
 			root.NumChildren = 2
-			root.Partial = slice.FromBytes(a, []byte("hel"))
+			root.SetPrefix(slice.FromBytes(a, []byte("hel")))
 
 			// Add children with different suffixes
 			leaf1 := NewLeaf(a, []byte("hello"), 123)
@@ -424,6 +425,8 @@ func TestIterPrefix(t *testing.T) {
 			root.Children[0] = leaf1.Ref()
 			root.Children[1] = leaf2.Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -460,12 +463,12 @@ func TestIterPrefix(t *testing.T) {
 			// Create a more complex tree structure
 			root := arena.New(a, Node4[int]{})
 			root.NumChildren = 1
-			root.Partial = slice.FromBytes(a, []byte("app"))
+			root.SetPrefix(slice.FromBytes(a, []byte("app")))
 
 			// Create intermediate node
 			intermediate := arena.New(a, Node16[int]{})
 			intermediate.NumChildren = 2
-			intermediate.Partial = slice.FromBytes(a, []byte("lication"))
+			intermediate.SetPrefix(slice.FromBytes(a, []byte("lication")))
 
 			leaf1 := NewLeaf(a, []byte("application"), 1).Ref()
 			leaf2 := NewLeaf(a, []byte("applications"), 2).Ref()
@@ -475,9 +478,13 @@ func TestIterPrefix(t *testing.T) {
 			intermediate.Children[0] = leaf1
 			intermediate.Children[1] = leaf2
 
+			intermediate.Seal()
+
 			root.Keys[0] = 'l'
 			root.Children[0] = intermediate.Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -505,6 +512,8 @@ func TestIterPrefix(t *testing.T) {
 			root.Keys[0] = 'a'
 			root.Children[0] = leaf.Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -527,6 +536,8 @@ func TestIterPrefix(t *testing.T) {
 			root.Keys[0] = 'a'
 			root.Children[0] = NewLeaf(a, []byte("apple"), 123).Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -626,11 +637,10 @@ func TestRecursiveIter_EdgeCases(t *testing.T) {
 
 		Convey("When iterating over a Node256 with sparse children", func() {
 			node256 := arena.New(a, Node256[int]{})
-			node256.NumChildren = 1
 
 			// Only set one child at index 100
 			leaf := NewLeaf(a, []byte("hello"), 123)
-			node256.Children[100] = leaf.Ref()
+			node256.AddChild(100, leaf)
 
 			ref := node256.Ref()
 			visited := make(map[string]int)
@@ -806,6 +816,8 @@ func TestIterPrefix_ComplexScenarios(t *testing.T) {
 			root.Keys[0] = 'a'
 			root.Children[0] = leaf.Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 
 			Convey("And searching with prefix 'ab'", func() {
@@ -859,6 +871,8 @@ func TestIterPrefix_ComplexScenarios(t *testing.T) {
 			root.Children[2] = NewLeaf(a, []byte("cherry"), 3).Ref()
 			root.Children[3] = NewLeaf(a, []byte("date"), 4).Ref()
 
+			root.Seal()
+
 			ref := root.Ref()
 
 			Convey("And searching with prefix 'a'", func() {
@@ -906,6 +920,8 @@ func TestIterPrefix_Performance(t *testing.T) {
 				root.Children[i] = leaf.Ref()
 			}
 
+			root.Seal()
+
 			ref := root.Ref()
 			visited := make(map[string]int)
 
@@ -953,6 +969,8 @@ func BenchmarkRecursiveIter(b *testing.B) {
 		root.Children[i] = leaf.Ref()
 	}
 
+	root.Seal()
+
 	ref := root.Ref()
 
 	b.Run("full_iteration", func(b *testing.B) {
@@ -987,7 +1005,7 @@ func BenchmarkIterPrefix(b *testing.B) {
 			// Create a tree with prefix compression for benchmarking
 			root := arena.New(a, Node4[int]{})
 			root.NumChildren = 4
-			root.Partial = slice.FromBytes(a, []byte("prefix"))
+			root.SetPrefix(slice.FromBytes(a, []byte("prefix")))
 
 			for j := 0; j < 4; j++ {
 				key := append([]byte("prefix"), byte('a'+j))
@@ -996,6 +1014,8 @@ func BenchmarkIterPrefix(b *testing.B) {
 				root.Children[j] = leaf.Ref()
 			}
 
+			root.Seal()
+
 			ref := root.Ref()
 
 			visited := make(map[string]int)
@@ -1014,7 +1034,7 @@ func BenchmarkIterPrefix(b *testing.B) {
 			// Create a tree with prefix compression for benchmarking
 			root := arena.New(a, Node4[int]{})
 			root.NumChildren = 4
-			root.Partial = slice.FromBytes(a, []byte("prefix"))
+			root.SetPrefix(slice.FromBytes(a, []byte("prefix")))
 
 			for j := 0; j < 4; j++ {
 				key := append([]byte("prefix"), byte('a'+j))
@@ -1023,6 +1043,8 @@ func BenchmarkIterPrefix(b *testing.B) {
 				root.Children[j] = leaf.Ref()
 			}
 
+			root.Seal()
+
 			ref := root.Ref()
 
 			visited := make(map[string]int)
@@ -1041,7 +1063,7 @@ func BenchmarkIterPrefix(b *testing.B) {
 			// Create a tree with prefix compression for benchmarking
 			root := arena.New(a, Node4[int]{})
 			root.NumChildren = 4
-			root.Partial = slice.FromBytes(a, []byte("prefix"))
+			root.SetPrefix(slice.FromBytes(a, []byte("prefix")))
 
 			for j := 0; j < 4; j++ {
 				key := append([]byte("prefix"), byte('a'+j))
@@ -1050,6 +1072,8 @@ func BenchmarkIterPrefix(b *testing.B) {
 				root.Children[j] = leaf.Ref()
 			}
 
+			root.Seal()
+
 			ref := root.Ref()
 
 			visited := make(map[string]int)
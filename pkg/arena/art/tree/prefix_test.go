@@ -186,7 +186,7 @@ func TestPrefixMismatch(t *testing.T) {
 			})
 
 			Convey("And node has empty prefix", func() {
-				node4.Partial = slice.FromString(a, "")
+				node4.SetPrefix(slice.FromString(a, ""))
 
 				result := PrefixMismatch[any](node4, []byte("hello"), 0)
 
@@ -196,7 +196,7 @@ func TestPrefixMismatch(t *testing.T) {
 			})
 
 			Convey("And node has prefix", func() {
-				node4.Partial = slice.FromString(a, "hello")
+				node4.SetPrefix(slice.FromString(a, "hello"))
 
 				Convey("And key matches prefix exactly", func() {
 					result := PrefixMismatch[any](node4, []byte("hello"), 0)
@@ -257,7 +257,7 @@ func TestPrefixMismatch(t *testing.T) {
 		})
 
 		Convey("When checking with depth > 0", func() {
-			node4.Partial = slice.FromString(a, "world")
+			node4.SetPrefix(slice.FromString(a, "world"))
 
 			Convey("And checking from middle of key", func() {
 				result := PrefixMismatch[any](node4, []byte("hello world"), 6)
@@ -286,7 +286,7 @@ func TestPrefixMismatch(t *testing.T) {
 
 		Convey("When checking with special characters", func() {
 			Convey("And prefix contains newlines", func() {
-				node4.Partial = slice.FromBytes(a, []byte("hello\nworld"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello\nworld")))
 				result := PrefixMismatch[any](node4, []byte("hello\nworld"), 0)
 
 				Convey("Then should match exactly", func() {
@@ -295,7 +295,7 @@ func TestPrefixMismatch(t *testing.T) {
 			})
 
 			Convey("And prefix contains tabs", func() {
-				node4.Partial = slice.FromBytes(a, []byte("hello\tworld"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello\tworld")))
 				result := PrefixMismatch[any](node4, []byte("hello\tworld"), 0)
 
 				Convey("Then should match exactly", func() {
@@ -304,7 +304,7 @@ func TestPrefixMismatch(t *testing.T) {
 			})
 
 			Convey("And prefix contains null bytes", func() {
-				node4.Partial = slice.FromBytes(a, []byte("hello\000world"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello\000world")))
 				result := PrefixMismatch[any](node4, []byte("hello\000world"), 0)
 
 				Convey("Then should match exactly", func() {
@@ -315,7 +315,7 @@ func TestPrefixMismatch(t *testing.T) {
 
 		Convey("When checking with unicode characters", func() {
 			Convey("And prefix contains unicode", func() {
-				node4.Partial = slice.FromBytes(a, []byte("hello世界"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello世界")))
 				result := PrefixMismatch[any](node4, []byte("hello世界"), 0)
 
 				Convey("Then should match exactly", func() {
@@ -324,7 +324,7 @@ func TestPrefixMismatch(t *testing.T) {
 			})
 
 			Convey("And key has different unicode", func() {
-				node4.Partial = slice.FromBytes(a, []byte("hello世界"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello世界")))
 				result := PrefixMismatch[any](node4, []byte("hello地球"), 0)
 
 				Convey("Then should return mismatch position", func() {
@@ -347,7 +347,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 				for i := range longPrefix {
 					longPrefix[i] = byte(i % 256)
 				}
-				node4.Partial = slice.FromBytes(a, longPrefix)
+				node4.SetPrefix(slice.FromBytes(a, longPrefix))
 
 				key := make([]byte, prefixLen)
 				copy(key, longPrefix)
@@ -366,7 +366,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 				for i := range longPrefix {
 					longPrefix[i] = byte(i % 256)
 				}
-				node4.Partial = slice.FromBytes(a, longPrefix)
+				node4.SetPrefix(slice.FromBytes(a, longPrefix))
 
 				keyLen := 500
 				key := slice.FromBytes(a, make([]byte, keyLen))
@@ -383,7 +383,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 		Convey("When working with boundary values", func() {
 			Convey("And prefix contains zero bytes", func() {
 				node4 := arena.New(a, Node4[any]{})
-				node4.Partial = slice.FromBytes(a, []byte{0, 0, 0})
+				node4.SetPrefix(slice.FromBytes(a, []byte{0, 0, 0}))
 
 				result := PrefixMismatch[any](node4, []byte{0, 0, 0}, 0)
 
@@ -394,7 +394,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 
 			Convey("And prefix contains maximum byte values", func() {
 				node4 := arena.New(a, Node4[any]{})
-				node4.Partial = slice.FromBytes(a, []byte{255, 255, 255})
+				node4.SetPrefix(slice.FromBytes(a, []byte{255, 255, 255}))
 
 				result := PrefixMismatch[any](node4, []byte{255, 255, 255}, 0)
 
@@ -405,7 +405,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 
 			Convey("And prefix contains mixed boundary values", func() {
 				node4 := arena.New(a, Node4[any]{})
-				node4.Partial = slice.FromBytes(a, []byte{0, 128, 255})
+				node4.SetPrefix(slice.FromBytes(a, []byte{0, 128, 255}))
 
 				result := PrefixMismatch[any](node4, []byte{0, 128, 255}, 0)
 
@@ -426,7 +426,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 						prefix[i] = 255
 					}
 				}
-				node4.Partial = slice.FromBytes(a, prefix)
+				node4.SetPrefix(slice.FromBytes(a, prefix))
 
 				key := make([]byte, 100)
 				copy(key, prefix)
@@ -444,7 +444,7 @@ func TestPrefixMismatch_EdgeCases(t *testing.T) {
 				for i := range prefix {
 					prefix[i] = byte(i)
 				}
-				node4.Partial = slice.FromBytes(a, prefix)
+				node4.SetPrefix(slice.FromBytes(a, prefix))
 
 				result := PrefixMismatch[any](node4, prefix, 0)
 
@@ -0,0 +1,161 @@
+package tree
+
+import (
+	"bytes"
+
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// Rank returns the number of keys in the tree which are less than the given key.
+//
+// It walks down the path of the key once, summing the leaf counts of the children
+// ordered before it, so it runs in O(depth) rather than scanning the keys.
+func Rank[T any](ref node.Ref[T], key []byte) (rank int) {
+	var depth int
+
+	for !ref.Empty() {
+		if l := ref.AsLeaf(); l != nil {
+			if bytes.Compare(l.Key.Raw(), key) < 0 {
+				rank++
+			}
+
+			return
+		}
+
+		n := ref.AsNode()
+
+		// If the key diverges from the prefix, the whole subtree is either before or after it.
+		if partial := n.Prefix(); partial.Len() > 0 {
+			if i := CheckPrefix(partial, key, depth); i < partial.Len() {
+				if depth+i < len(key) && key[depth+i] > partial.Load(i) {
+					rank += n.Leaves()
+				}
+
+				return
+			}
+
+			depth += partial.Len()
+		}
+
+		b := -1
+
+		if depth < len(key) {
+			b = int(key[depth])
+		}
+
+		var next node.Ref[T]
+
+		eachChild(n, func(k int, child node.Ref[T]) bool {
+			if k >= b {
+				if k == b {
+					next = child
+				}
+
+				return false
+			}
+
+			rank += leaves(child)
+
+			return true
+		})
+
+		ref = next
+		depth++
+	}
+
+	return
+}
+
+// Select returns the i-th smallest leaf in the tree, counting from zero.
+//
+// It returns nil if i is out of range.
+func Select[T any](ref node.Ref[T], i int) *node.Leaf[T] {
+	if i < 0 || i >= leaves(ref) {
+		return nil
+	}
+
+	for !ref.Empty() {
+		if l := ref.AsLeaf(); l != nil {
+			return l
+		}
+
+		var next node.Ref[T]
+
+		eachChild(ref.AsNode(), func(_ int, child node.Ref[T]) bool {
+			if n := leaves(child); i >= n {
+				i -= n
+
+				return true
+			}
+
+			next = child
+
+			return false
+		})
+
+		ref = next
+	}
+
+	return nil
+}
+
+// leaves returns the number of leaves in the subtree rooted at ref.
+func leaves[T any](ref node.Ref[T]) int {
+	if ref.Empty() {
+		return 0
+	}
+
+	return ref.AsNode().Leaves()
+}
+
+// eachChild calls f for each non-empty child of n in key order, starting with the
+// zero sized child as key -1, until f returns false.
+func eachChild[T any](n node.Node[T], f func(key int, child node.Ref[T]) bool) {
+	switch n := n.(type) {
+	case *node.Node4[T]:
+		if !n.ZeroSizedChild.Empty() && !f(-1, n.ZeroSizedChild) {
+			return
+		}
+
+		for i := 0; i < n.NumChildren; i++ {
+			if !f(int(n.Keys[i]), n.Children[i]) {
+				return
+			}
+		}
+
+	case *node.Node16[T]:
+		if !n.ZeroSizedChild.Empty() && !f(-1, n.ZeroSizedChild) {
+			return
+		}
+
+		for i := 0; i < n.NumChildren; i++ {
+			if !f(int(n.Keys[i]), n.Children[i]) {
+				return
+			}
+		}
+
+	case *node.Node48[T]:
+		if !n.ZeroSizedChild.Empty() && !f(-1, n.ZeroSizedChild) {
+			return
+		}
+
+		for i := 0; i < 256; i++ {
+			if idx := n.Keys[i]; idx != 0 {
+				if !f(i, n.Children[idx-1]) {
+					return
+				}
+			}
+		}
+
+	case *node.Node256[T]:
+		if !n.ZeroSizedChild.Empty() && !f(-1, n.ZeroSizedChild) {
+			return
+		}
+
+		for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+			if !f(i, n.Children[i]) {
+				return
+			}
+		}
+	}
+}
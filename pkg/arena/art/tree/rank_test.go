@@ -0,0 +1,96 @@
+package tree_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+	. "github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+func TestRankSelect(t *testing.T) {
+	Convey("Given an empty tree", t, func() {
+		var root node.Ref[int]
+
+		So(Rank(root, []byte("foo")), ShouldEqual, 0)
+		So(Select(root, 0), ShouldBeNil)
+	})
+
+	Convey("Given a tree with a single leaf", t, func() {
+		a := new(arena.Arena)
+		var root node.Ref[int]
+
+		RecursiveInsert(a, &root, node.NewLeaf(a, []byte("foo"), 1), 0, false)
+
+		So(Rank(root, []byte("bar")), ShouldEqual, 0)
+		So(Rank(root, []byte("foo")), ShouldEqual, 0)
+		So(Rank(root, []byte("zoo")), ShouldEqual, 1)
+		So(Select(root, 0).Value, ShouldEqual, 1)
+		So(Select(root, 1), ShouldBeNil)
+		So(Select(root, -1), ShouldBeNil)
+	})
+
+	Convey("Given a tree with random keys", t, func() {
+		a := new(arena.Arena)
+		r := rand.New(rand.NewSource(42))
+
+		var root node.Ref[int]
+		var keys [][]byte
+
+		for i := 0; i < 2000; i++ {
+			key := make([]byte, 1+r.Intn(4))
+			for j := range key {
+				key[j] = byte(r.Intn(8) * 37) // Few distinct bytes, so that keys share prefixes.
+			}
+
+			if RecursiveInsert(a, &root, node.NewLeaf(a, key, i), 0, false) == nil {
+				keys = append(keys, key)
+			}
+		}
+
+		slices.SortFunc(keys, bytes.Compare)
+
+		check := func() {
+			So(root.AsNode().Leaves(), ShouldEqual, len(keys))
+
+			for i, key := range keys {
+				So(Rank(root, key), ShouldEqual, i)
+				So(Select(root, i).Key.Raw(), ShouldResemble, key)
+			}
+
+			So(Select(root, len(keys)), ShouldBeNil)
+			So(Rank(root, []byte{0xff, 0xff, 0xff, 0xff, 0xff}), ShouldEqual, len(keys))
+
+			for i := 0; i < 200; i++ {
+				key := make([]byte, r.Intn(6))
+				for j := range key {
+					key[j] = byte(r.Intn(256))
+				}
+
+				n, _ := slices.BinarySearchFunc(keys, key, bytes.Compare)
+
+				So(Rank(root, key), ShouldEqual, n)
+			}
+		}
+
+		Convey("Then the ranks should match the sorted keys", check)
+
+		Convey("When deleting half of the keys", func() {
+			r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+			for _, key := range keys[:len(keys)/2] {
+				So(RecursiveDelete(a, &root, key, 0), ShouldNotBeNil)
+			}
+
+			keys = keys[len(keys)/2:]
+			slices.SortFunc(keys, bytes.Compare)
+
+			Convey("Then the ranks should match the remaining keys", check)
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package tree
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// RecursiveRelease releases the node and all of its descendants back to the allocator.
+func RecursiveRelease[T any](a arena.Allocator, ref node.Ref[T]) {
+	if ref.Empty() {
+		return
+	}
+
+	switch n := ref.AsNode().(type) {
+	case *node.Leaf[T]:
+		n.Release(a)
+		return
+
+	case *node.Node4[T]:
+		RecursiveRelease(a, n.ZeroSizedChild)
+
+		for i := 0; i < n.NumChildren; i++ {
+			RecursiveRelease(a, n.Children[i])
+		}
+
+	case *node.Node16[T]:
+		RecursiveRelease(a, n.ZeroSizedChild)
+
+		for i := 0; i < n.NumChildren; i++ {
+			RecursiveRelease(a, n.Children[i])
+		}
+
+	case *node.Node48[T]:
+		RecursiveRelease(a, n.ZeroSizedChild)
+
+		for i := 0; i < 256; i++ {
+			if idx := n.Keys[i]; idx != 0 {
+				RecursiveRelease(a, n.Children[idx-1])
+			}
+		}
+
+	case *node.Node256[T]:
+		RecursiveRelease(a, n.ZeroSizedChild)
+
+		for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+			RecursiveRelease(a, n.Children[i])
+		}
+	}
+
+	ref.AsNode().Release(a)
+}
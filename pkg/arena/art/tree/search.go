@@ -8,8 +8,14 @@ import (
 //
 // It returns the value pointer if the key is found, otherwise it returns nil.
 func Search[T any](ref node.Ref[T], key []byte) *T {
-	var depth int
+	return SearchFrom(ref, key, 0)
+}
 
+// SearchFrom searches for a key in the subtree whose root is at the given depth,
+// such as the one returned by [SeekPrefix].
+//
+// It returns the value pointer if the key is found, otherwise it returns nil.
+func SearchFrom[T any](ref node.Ref[T], key []byte, depth int) *T {
 	for !ref.Empty() {
 		// If the current node is a leaf, we need to check if the key matches
 		if l := ref.AsLeaf(); l != nil {
@@ -49,3 +55,46 @@ func Search[T any](ref node.Ref[T], key []byte) *T {
 
 	return nil
 }
+
+// SeekPrefix finds the root of the smallest subtree holding all the keys with the given prefix.
+//
+// It returns the root of the subtree with the depth of its prefix, or false if no key has the prefix.
+func SeekPrefix[T any](ref node.Ref[T], prefix []byte) (root node.Ref[T], depth int, ok bool) {
+	for !ref.Empty() {
+		if l := ref.AsLeaf(); l != nil {
+			if l.MatchesPrefix(prefix) {
+				return ref, depth, true
+			}
+
+			break
+		}
+
+		curr := ref.AsNode()
+
+		// The prefix may end in the middle of the prefix of the current node
+		if partial := curr.Prefix(); partial.Len() > 0 {
+			prefixMatch := CheckPrefix(partial, prefix, depth)
+			if depth+prefixMatch == len(prefix) {
+				return ref, depth, true
+			}
+
+			if prefixMatch != partial.Len() {
+				break
+			}
+
+			depth += partial.Len()
+		} else if depth == len(prefix) {
+			return ref, depth, true
+		}
+
+		child := curr.FindChild(int(prefix[depth]))
+		if child == nil {
+			break
+		}
+
+		ref = *child
+		depth++
+	}
+
+	return 0, 0, false
+}
@@ -106,7 +106,7 @@ func TestSearch(t *testing.T) {
 		Convey("When searching in a tree with Node4 and prefix", func() {
 			// Create a Node4 with common prefix
 			node4 := arena.New(a, node.Node4[int]{})
-			node4.Partial = slice.FromBytes(a, []byte("hel"))
+			node4.SetPrefix(slice.FromBytes(a, []byte("hel")))
 
 			leaf1 := node.NewLeaf(a, hello, 123)
 			leaf2 := node.NewLeaf(a, help, 456)
@@ -358,7 +358,7 @@ func TestSearch(t *testing.T) {
 		Convey("When searching with prefix mismatch scenarios", func() {
 			Convey("And searching with key that has shorter prefix", func() {
 				node4 := arena.New(a, node.Node4[int]{})
-				node4.Partial = slice.FromBytes(a, []byte("hello"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello")))
 
 				leaf := node.NewLeaf(a, hello, 555)
 
@@ -375,7 +375,7 @@ func TestSearch(t *testing.T) {
 
 			Convey("And searching with key that has different prefix", func() {
 				node4 := arena.New(a, node.Node4[int]{})
-				node4.Partial = slice.FromBytes(a, []byte("hello"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello")))
 
 				leaf := node.NewLeaf(a, hello, 444)
 
@@ -392,7 +392,7 @@ func TestSearch(t *testing.T) {
 
 			Convey("And searching with key that has partial prefix match", func() {
 				node4 := arena.New(a, node.Node4[int]{})
-				node4.Partial = slice.FromBytes(a, []byte("hello"))
+				node4.SetPrefix(slice.FromBytes(a, []byte("hello")))
 
 				leaf := node.NewLeaf(a, hello, 333)
 
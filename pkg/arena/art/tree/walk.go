@@ -0,0 +1,52 @@
+package tree
+
+import (
+	"github.com/flier/goutil/pkg/arena/art/node"
+)
+
+// Walk visits every node of the tree in pre-order, together with its parent.
+//
+// The parent of the root node is an empty reference.
+func Walk[T any](ref node.Ref[T], cb func(ref, parent node.Ref[T])) {
+	walk(ref, 0, cb)
+}
+
+func walk[T any](ref, parent node.Ref[T], cb func(ref, parent node.Ref[T])) {
+	if ref.Empty() {
+		return
+	}
+
+	cb(ref, parent)
+
+	switch n := ref.AsNode().(type) {
+	case *node.Node4[T]:
+		walk(n.ZeroSizedChild, ref, cb)
+
+		for i := 0; i < n.NumChildren; i++ {
+			walk(n.Children[i], ref, cb)
+		}
+
+	case *node.Node16[T]:
+		walk(n.ZeroSizedChild, ref, cb)
+
+		for i := 0; i < n.NumChildren; i++ {
+			walk(n.Children[i], ref, cb)
+		}
+
+	case *node.Node48[T]:
+		walk(n.ZeroSizedChild, ref, cb)
+
+		for i := 0; i < 256; i++ {
+			if idx := n.Keys[i]; idx != 0 {
+				walk(n.Children[idx-1], ref, cb)
+			}
+		}
+
+	case *node.Node256[T]:
+		walk(n.ZeroSizedChild, ref, cb)
+
+		for i := n.Next(0); i >= 0; i = n.Next(i + 1) {
+			walk(n.Children[i], ref, cb)
+		}
+	}
+}
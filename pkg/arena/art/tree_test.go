@@ -2,12 +2,14 @@ package art_test
 
 import (
 	"fmt"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/flier/goutil/internal/debug"
 	"github.com/flier/goutil/pkg/arena"
 	"github.com/flier/goutil/pkg/arena/art"
 )
@@ -22,6 +24,7 @@ var (
 func TestTree_BasicOperations(t *testing.T) {
 	Convey("Given a new ART tree", t, func() {
 		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 		tree := &art.Tree[int]{}
 
 		Convey("When the tree is empty", func() {
@@ -597,6 +600,7 @@ func TestTree_DifferentTypes(t *testing.T) {
 		Convey("When using string values", func() {
 			tree := &art.Tree[string]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			tree.Insert(a, []byte("key1"), "value1")
 			tree.Insert(a, []byte("key2"), "value2")
@@ -613,6 +617,7 @@ func TestTree_DifferentTypes(t *testing.T) {
 
 			tree := &art.Tree[TestStruct]{}
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 			tree.Insert(a, []byte("struct1"), TestStruct{ID: 1, Name: "test1"})
 			tree.Insert(a, []byte("struct2"), TestStruct{ID: 2, Name: "test2"})
@@ -646,6 +651,7 @@ func TestTree_LenOperations(t *testing.T) {
 	Convey("Given an ART tree", t, func() {
 		tree := &art.Tree[int]{}
 		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 
 		Convey("When the tree is newly created", func() {
 			Convey("Then Len should return 0", func() {
@@ -920,6 +926,7 @@ func BenchmarkTree_VisitPrefix(b *testing.B) {
 func TestTree_DebugAssertions(t *testing.T) {
 	Convey("Given a Tree with debug assertions", t, func() {
 		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 		tree := &art.Tree[int]{}
 
 		Convey("When calling Search with invalid inputs", func() {
@@ -1001,6 +1008,7 @@ func TestTree_AdvancedScenarios(t *testing.T) {
 	Convey("Given advanced tree scenarios", t, func() {
 		Convey("When working with very large trees", func() {
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 			tree := &art.Tree[int]{}
 
 			// Insert many keys to test tree growth and node type transitions
@@ -1038,6 +1046,7 @@ func TestTree_AdvancedScenarios(t *testing.T) {
 
 		Convey("When working with keys that have very long common prefixes", func() {
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 			tree := &art.Tree[int]{}
 
 			// Create keys with very long common prefixes
@@ -1072,6 +1081,7 @@ func TestTree_AdvancedScenarios(t *testing.T) {
 
 		Convey("When working with rapid insert/delete cycles", func() {
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 			tree := &art.Tree[int]{}
 
 			// Perform rapid insert/delete cycles
@@ -1100,6 +1110,7 @@ func TestTree_AdvancedScenarios(t *testing.T) {
 
 		Convey("When working with mixed key types and special characters", func() {
 			a := new(arena.Arena)
+			defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
 			tree := &art.Tree[int]{}
 
 			// Insert keys with various special characters
@@ -1150,7 +1161,7 @@ func TestTree_PerformanceEdgeCases(t *testing.T) {
 	Convey("Given performance edge cases", t, func() {
 		Convey("When inserting keys in sorted order", func() {
 			a := new(arena.Arena)
-			tree := &art.Tree[int]{}
+			tree := arena.New(a, art.Tree[int]{}) // Keeps the arena alive.
 
 			// Insert keys in sorted order to test worst-case scenarios
 			for i := 0; i < 1000; i++ {
@@ -1171,7 +1182,7 @@ func TestTree_PerformanceEdgeCases(t *testing.T) {
 
 		Convey("When inserting keys in reverse sorted order", func() {
 			a := new(arena.Arena)
-			tree := &art.Tree[int]{}
+			tree := arena.New(a, art.Tree[int]{}) // Keeps the arena alive.
 
 			// Insert keys in reverse sorted order
 			for i := 999; i >= 0; i-- {
@@ -1192,7 +1203,7 @@ func TestTree_PerformanceEdgeCases(t *testing.T) {
 
 		Convey("When inserting keys with alternating patterns", func() {
 			a := new(arena.Arena)
-			tree := &art.Tree[int]{}
+			tree := arena.New(a, art.Tree[int]{}) // Keeps the arena alive.
 
 			// Insert keys with alternating patterns to test node growth
 			for i := 0; i < 1000; i++ {
@@ -1222,3 +1233,178 @@ func TestTree_PerformanceEdgeCases(t *testing.T) {
 		})
 	})
 }
+
+func TestTree_Emplace(t *testing.T) {
+	Convey("Given an ART tree", t, func() {
+		type bigValue struct {
+			n    int
+			data [256]byte
+		}
+
+		tree := &art.Tree[bigValue]{}
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+
+		Convey("When emplacing a missing key", func() {
+			p, inserted := tree.Emplace(a, kHello)
+
+			So(inserted, ShouldBeTrue)
+			So(p, ShouldNotBeNil)
+			So(p.n, ShouldEqual, 0)
+			So(tree.Len(), ShouldEqual, 1)
+
+			p.n = 42
+			p.data[0] = 'x'
+
+			Convey("Then the value should be initialized in place", func() {
+				v := tree.Search(kHello)
+
+				So(v, ShouldPointTo, p)
+				So(v.n, ShouldEqual, 42)
+				So(v.data[0], ShouldEqual, 'x')
+			})
+
+			Convey("Then emplacing the key again should return the same pointer", func() {
+				q, inserted := tree.Emplace(a, kHello)
+
+				So(inserted, ShouldBeFalse)
+				So(q, ShouldPointTo, p)
+				So(tree.Len(), ShouldEqual, 1)
+			})
+
+			Convey("Then emplacing the key again should not allocate", func() {
+				next := a.Next()
+
+				for i := 0; i < 10; i++ {
+					tree.Emplace(a, kHello)
+				}
+
+				So(a.Next(), ShouldEqual, next)
+			})
+
+			Convey("Then the pointer should stay valid while other keys are inserted", func() {
+				for i := 0; i < 100; i++ {
+					q, inserted := tree.Emplace(a, []byte(fmt.Sprintf("hello%d", i)))
+					So(inserted, ShouldBeTrue)
+
+					q.n = i
+				}
+
+				So(tree.Len(), ShouldEqual, 101)
+				So(tree.Search(kHello), ShouldPointTo, p)
+				So(p.n, ShouldEqual, 42)
+				So(tree.Search([]byte("hello42")).n, ShouldEqual, 42)
+			})
+		})
+
+		Convey("When emplacing a key longer than the limit", func() {
+			tree.SetMaxKeyLen(3)
+
+			So(func() { tree.Emplace(a, kHello) }, ShouldPanicWith, art.ErrKeyTooLong)
+			So(tree.Len(), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestTree_BinaryKeys(t *testing.T) {
+	Convey("Given a tree with binary keys containing NUL bytes and the empty key", t, func() {
+		a := new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		tree := &art.Tree[int]{}
+
+		keys := []string{"", "\x00", "\x00\x00", "\x00\x00\x00", "\x00a", "\x01", "a", "a\x00", "a\x00b", "ab"}
+
+		for i, key := range keys {
+			So(tree.Insert(a, []byte(key), i), ShouldBeNil)
+		}
+
+		So(tree.Len(), ShouldEqual, len(keys))
+
+		Convey("Then every key should be distinct", func() {
+			for i, key := range keys {
+				So(tree.Search([]byte(key)), ShouldNotBeNil)
+				So(*tree.Search([]byte(key)), ShouldEqual, i)
+			}
+
+			So(tree.Search([]byte("\x00\x00\x00\x00")), ShouldBeNil)
+			So(tree.Search([]byte("a\x00\x00")), ShouldBeNil)
+		})
+
+		Convey("Then inserting them again should replace the values", func() {
+			for i, key := range keys {
+				So(*tree.Insert(a, []byte(key), i+100), ShouldEqual, i)
+			}
+
+			So(tree.Len(), ShouldEqual, len(keys))
+		})
+
+		Convey("Then they should be visited in order", func() {
+			var got []string
+
+			tree.Visit(func(key []byte, _ *int) bool {
+				got = append(got, string(key))
+
+				return false
+			})
+
+			So(got, ShouldResemble, keys)
+			So(string(tree.Minimum().Key.Raw()), ShouldEqual, "")
+			So(string(tree.Maximum().Key.Raw()), ShouldEqual, "ab")
+		})
+
+		Convey("Then the prefix iteration should respect the NUL bytes", func() {
+			prefixKeys := func(prefix string) (got []string) {
+				tree.VisitPrefix([]byte(prefix), func(key []byte, _ *int) bool {
+					got = append(got, string(key))
+
+					return false
+				})
+
+				return
+			}
+
+			So(prefixKeys(""), ShouldResemble, keys)
+			So(prefixKeys("\x00"), ShouldResemble, []string{"\x00", "\x00\x00", "\x00\x00\x00", "\x00a"})
+			So(prefixKeys("\x00\x00"), ShouldResemble, []string{"\x00\x00", "\x00\x00\x00"})
+			So(prefixKeys("a\x00"), ShouldResemble, []string{"a\x00", "a\x00b"})
+			So(prefixKeys("\x02"), ShouldBeEmpty)
+		})
+
+		Convey("When deleting the keys one by one", func() {
+			for i, key := range keys {
+				So(*tree.Delete(a, []byte(key)), ShouldEqual, i)
+				So(tree.Search([]byte(key)), ShouldBeNil)
+
+				for _, rest := range keys[i+1:] {
+					So(tree.Search([]byte(rest)), ShouldNotBeNil)
+				}
+			}
+
+			So(tree.Len(), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestTree_CrossArena(t *testing.T) {
+	Convey("Given a tree allocated in an arena", t, func() {
+		a, b := new(arena.Arena), new(arena.Arena)
+		defer runtime.KeepAlive(a) // The trees are on the heap, keep their nodes alive.
+		defer runtime.KeepAlive(b)
+		tree := &art.Tree[int]{}
+
+		tree.Insert(a, []byte("foo"), 1)
+		tree.Insert(a, []byte("bar"), 2)
+
+		Convey("When inserting a key allocated in another arena", func() {
+			insert := func() { tree.Insert(b, []byte("baz"), 3) }
+
+			Convey("Then it should be detected in debug builds", func() {
+				if debug.Enabled {
+					So(insert, ShouldPanic)
+				} else {
+					So(insert, ShouldNotPanic)
+				}
+			})
+		})
+	})
+}
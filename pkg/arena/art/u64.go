@@ -0,0 +1,143 @@
+package art
+
+import (
+	"encoding/binary"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art/tree"
+)
+
+// U64Tree is an Adaptive Radix Tree specialized for uint64 keys.
+//
+// The keys are encoded big-endian into fixed 8 bytes keys on the stack, so that
+// the byte order of the tree matches the numeric order of the keys, and lookups
+// don't allocate. Since no key is a prefix of another one, every key ends at a
+// leaf and the tree never needs zero sized children.
+type U64Tree[T any] struct {
+	tree Tree[T]
+}
+
+// encodeU64 returns the big-endian encoding of the key.
+func encodeU64(k uint64) (b [8]byte) {
+	binary.BigEndian.PutUint64(b[:], k)
+
+	return
+}
+
+// decodeU64 returns the key of the given big-endian encoding.
+func decodeU64(b []byte) uint64 { return binary.BigEndian.Uint64(b) }
+
+// Len returns the number of elements in the tree.
+func (t *U64Tree[T]) Len() int { return t.tree.Len() }
+
+// Search searches for the value of a key.
+//
+// It returns the value if found, otherwise nil.
+func (t *U64Tree[T]) Search(key uint64) *T {
+	b := encodeU64(key)
+
+	return t.tree.Search(b[:])
+}
+
+// Insert inserts a new value into the tree.
+//
+// It returns the old value if the key already exists, or nil if the key is inserted.
+func (t *U64Tree[T]) Insert(a arena.Allocator, key uint64, value T) *T {
+	b := encodeU64(key)
+
+	return t.tree.Insert(a, b[:], value)
+}
+
+// InsertNoReplace inserts a new value into the tree without replacing the existing value.
+//
+// It returns the old value if the key already exists, or nil if the key is inserted.
+func (t *U64Tree[T]) InsertNoReplace(a arena.Allocator, key uint64, value T) *T {
+	b := encodeU64(key)
+
+	return t.tree.InsertNoReplace(a, b[:], value)
+}
+
+// Delete deletes a value from the tree.
+//
+// It returns the old value if the key is found, or nil if the key is not found.
+func (t *U64Tree[T]) Delete(a arena.AllocatorExt, key uint64) *T {
+	b := encodeU64(key)
+
+	return t.tree.Delete(a, b[:])
+}
+
+// Minimum returns the smallest key in the tree.
+//
+// It returns false if the tree is empty.
+func (t *U64Tree[T]) Minimum() (key uint64, value *T, ok bool) {
+	if l := t.tree.Minimum(); l != nil {
+		return decodeU64(l.Key.Raw()), &l.Value, true
+	}
+
+	return
+}
+
+// Maximum returns the largest key in the tree.
+//
+// It returns false if the tree is empty.
+func (t *U64Tree[T]) Maximum() (key uint64, value *T, ok bool) {
+	if l := t.tree.Maximum(); l != nil {
+		return decodeU64(l.Key.Raw()), &l.Value, true
+	}
+
+	return
+}
+
+// Visit visits the tree in ascending key order.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *U64Tree[T]) Visit(cb func(key uint64, value *T) bool) bool {
+	return t.tree.Visit(func(key []byte, value *T) bool {
+		return cb(decodeU64(key), value)
+	})
+}
+
+// VisitRange visits the keys between lo and hi (both inclusive) in ascending order.
+//
+// Only the subtree sharing the leading bytes of lo and hi is traversed, and the
+// traversal stops at the first key after hi.
+//
+// It returns true if the iteration is interrupted by the callback function,
+// otherwise it returns false.
+func (t *U64Tree[T]) VisitRange(lo, hi uint64, cb func(key uint64, value *T) bool) bool {
+	if lo > hi {
+		return false
+	}
+
+	l, h := encodeU64(lo), encodeU64(hi)
+
+	n := 0
+	for n < len(l) && l[n] == h[n] {
+		n++
+	}
+
+	root, _, ok := tree.SeekPrefix(t.tree.root, l[:n])
+	if !ok {
+		return false
+	}
+
+	var stopped bool
+
+	tree.RecursiveIter(root, t.tree.guard(func(key []byte, value *T) bool {
+		k := decodeU64(key)
+
+		switch {
+		case k < lo:
+			return false
+		case k > hi:
+			return true
+		}
+
+		stopped = cb(k, value)
+
+		return stopped
+	}))
+
+	return stopped
+}
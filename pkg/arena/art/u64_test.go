@@ -0,0 +1,133 @@
+//go:build go1.23
+
+package art_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/art"
+	"github.com/flier/goutil/pkg/xiter"
+)
+
+func TestU64Tree(t *testing.T) {
+	Convey("Given an empty U64Tree", t, func() {
+		a := new(arena.Arena)
+		tree := arena.New(a, art.U64Tree[int]{}) // Keeps the arena alive.
+
+		So(tree.Len(), ShouldEqual, 0)
+		So(tree.Search(0), ShouldBeNil)
+
+		_, _, ok := tree.Minimum()
+		So(ok, ShouldBeFalse)
+
+		Convey("When inserting keys", func() {
+			keys := []uint64{0, 1, 255, 256, 1 << 32, 1<<64 - 1, 42, 1<<32 + 1}
+
+			for i, k := range keys {
+				So(tree.Insert(a, k, i), ShouldBeNil)
+			}
+
+			So(tree.Len(), ShouldEqual, len(keys))
+
+			Convey("Then they should be searchable", func() {
+				for i, k := range keys {
+					So(*tree.Search(k), ShouldEqual, i)
+				}
+
+				So(tree.Search(2), ShouldBeNil)
+				So(*tree.Insert(a, 42, 100), ShouldEqual, 6)
+				So(*tree.InsertNoReplace(a, 42, 200), ShouldEqual, 100)
+			})
+
+			Convey("Then they should be visited in numeric order", func() {
+				sorted := slices.Sorted(slices.Values(keys))
+
+				So(slices.Collect(xiter.Keys(tree.All())), ShouldResemble, sorted)
+
+				k, v, ok := tree.Minimum()
+				So(ok, ShouldBeTrue)
+				So(k, ShouldEqual, 0)
+				So(*v, ShouldEqual, 0)
+
+				k, _, ok = tree.Maximum()
+				So(ok, ShouldBeTrue)
+				So(k, ShouldEqual, uint64(1<<64-1))
+			})
+
+			Convey("Then ranges should be inclusive", func() {
+				So(slices.Collect(xiter.Keys(tree.Range(1, 256))), ShouldResemble, []uint64{1, 42, 255, 256})
+				So(slices.Collect(xiter.Keys(tree.Range(2, 41))), ShouldBeEmpty)
+				So(slices.Collect(xiter.Keys(tree.Range(1<<32, 1<<32))), ShouldResemble, []uint64{1 << 32})
+				So(slices.Collect(xiter.Keys(tree.Range(256, 1))), ShouldBeEmpty)
+			})
+
+			Convey("Then ranges outside of the stored keys should be empty", func() {
+				So(slices.Collect(xiter.Keys(tree.Range(1<<48, 1<<48+5))), ShouldBeEmpty)
+				So(slices.Collect(xiter.Keys(tree.Range(1<<32+2, 1<<40))), ShouldBeEmpty)
+				So(slices.Collect(xiter.Keys(tree.Range(1<<63, 1<<64-2))), ShouldBeEmpty)
+				So(slices.Collect(xiter.Keys(tree.Range(2, 3))), ShouldBeEmpty)
+			})
+
+			Convey("Then deleting should remove them", func() {
+				So(*tree.Delete(a, 255), ShouldEqual, 2)
+				So(tree.Delete(a, 255), ShouldBeNil)
+				So(tree.Search(255), ShouldBeNil)
+				So(tree.Len(), ShouldEqual, len(keys)-1)
+			})
+		})
+	})
+
+	Convey("Given a U64Tree with two small keys", t, func() {
+		a := new(arena.Arena)
+		tree := arena.New(a, art.U64Tree[int]{}) // Keeps the arena alive.
+
+		tree.Insert(a, 1, 1)
+		tree.Insert(a, 2, 2)
+
+		Convey("Then a range only sharing a part of their common prefix should be empty", func() {
+			So(tree.VisitRange(1<<48, 1<<48+5, func(uint64, *int) bool { panic("unexpected") }), ShouldBeFalse)
+			So(slices.Collect(xiter.Keys(tree.Range(0, 1<<48))), ShouldResemble, []uint64{1, 2})
+		})
+	})
+
+	Convey("Given a U64Tree with random keys", t, func() {
+		a := new(arena.Arena)
+		tree := arena.New(a, art.U64Tree[uint64]{}) // Keeps the arena alive.
+		r := rand.New(rand.NewSource(7))
+
+		var keys []uint64
+
+		for i := 0; i < 1000; i++ {
+			k := r.Uint64() >> (r.Intn(8) * 8)
+			if tree.Insert(a, k, k) == nil {
+				keys = append(keys, k)
+			}
+		}
+
+		slices.Sort(keys)
+
+		Convey("Then random ranges should match a scan of the sorted keys", func() {
+			for i := 0; i < 100; i++ {
+				lo, hi := keys[r.Intn(len(keys))], keys[r.Intn(len(keys))]>>r.Intn(4)
+				if lo > hi {
+					lo, hi = hi, lo
+				}
+
+				var expected []uint64
+
+				for _, k := range keys {
+					if lo <= k && k <= hi {
+						expected = append(expected, k)
+					}
+				}
+
+				So(slices.Collect(xiter.Keys(tree.Range(lo, hi))), ShouldResemble, expected)
+			}
+		})
+	})
+}
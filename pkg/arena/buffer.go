@@ -0,0 +1,82 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// ErrOutOfMemory is returned, or raised as a panic, when an arena created by
+// [FromBuffer] has exhausted its backing buffer, or an arena would grow beyond
+// its hard limit, see [Arena.SetLimit].
+var ErrOutOfMemory = errors.New("arena: out of memory")
+
+// FromBuffer returns an arena which bump-allocates within the caller-provided buffer,
+// such as a stack-allocated array, a mmap'd region or a shared-memory segment.
+//
+// The arena never grows beyond buf, which gives a deterministic memory cap with no
+// background growth. Once buf is exhausted, [Arena.Alloc] panics with [ErrOutOfMemory],
+// use [Arena.TryAlloc] to handle the exhaustion gracefully instead.
+//
+// The start of buf is rounded up to [Align], and its usable length rounded down
+// accordingly. The arena keeps buf alive, but pointers into buf do not keep the
+// arena alive.
+//
+// Example:
+//
+//	var buf [4096]byte
+//
+//	a := arena.FromBuffer(buf[:])
+//	if p, err := a.TryAlloc(64); err == nil {
+//	    // Use p...
+//	}
+func FromBuffer(buf []byte) *Arena {
+	if buf == nil {
+		buf = []byte{} // A nil buffer would make the arena growable.
+	}
+
+	a := &Arena{buf: buf}
+	a.resetBuffer()
+
+	return a
+}
+
+// TryAlloc allocates memory with the given size like [Arena.Alloc], but returns
+// [ErrOutOfMemory] instead of panicking when an arena created by [FromBuffer] has
+// exhausted its backing buffer, or the arena would grow beyond its hard limit.
+func (a *Arena) TryAlloc(size int) (*byte, error) {
+	if size := alignUp(size); a.next.Add(size) > a.end {
+		if err := a.grow(size); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.Alloc(size), nil
+}
+
+// Remaining returns the number of bytes which can still be allocated without calling
+// [Arena.Grow].
+func (a *Arena) Remaining() int {
+	return a.end.Sub(a.next)
+}
+
+func (a *Arena) resetBuffer() {
+	a.next, a.end, a.cap = 0, 0, 0
+
+	if len(a.buf) > 0 {
+		start := xunsafe.AddrOf(unsafe.SliceData(a.buf))
+		next := start.RoundUpTo(Align)
+		n := max(0, len(a.buf)-next.Sub(start)) &^ (Align - 1)
+
+		clear(a.buf)
+
+		a.next = next
+		a.end = next.Add(n)
+		a.cap = n
+	}
+
+	a.keep = nil
+}
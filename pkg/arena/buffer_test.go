@@ -0,0 +1,75 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+func TestFromBuffer(t *testing.T) {
+	Convey("Given an arena backed by a buffer", t, func() {
+		var buf [256]byte
+
+		a := arena.FromBuffer(buf[:])
+
+		So(a.Cap(), ShouldBeLessThanOrEqualTo, len(buf))
+		So(a.Remaining(), ShouldEqual, a.Cap())
+
+		Convey("When allocating within the buffer", func() {
+			p := a.Alloc(16)
+
+			So(uintptr(unsafe.Pointer(p)), ShouldBeGreaterThanOrEqualTo, uintptr(unsafe.Pointer(&buf[0])))
+			So(uintptr(unsafe.Pointer(p)), ShouldBeLessThan, uintptr(unsafe.Pointer(&buf[len(buf)-1])))
+			So(uintptr(unsafe.Pointer(p))%uintptr(arena.Align), ShouldEqual, uintptr(0))
+			So(a.Remaining(), ShouldEqual, a.Cap()-16)
+
+			v := arena.New(a, int64(42))
+			So(*v, ShouldEqual, 42)
+		})
+
+		Convey("When the buffer is exhausted", func() {
+			for a.Remaining() > 0 {
+				_, err := a.TryAlloc(arena.Align)
+				So(err, ShouldBeNil)
+			}
+
+			p, err := a.TryAlloc(1)
+			So(p, ShouldBeNil)
+			So(err, ShouldEqual, arena.ErrOutOfMemory)
+
+			So(func() { a.Alloc(1) }, ShouldPanicWith, arena.ErrOutOfMemory)
+			So(func() { a.Reserve(1) }, ShouldPanicWith, arena.ErrOutOfMemory)
+
+			Convey("Then Reset should make the buffer available again", func() {
+				a.Reset()
+
+				So(a.Remaining(), ShouldEqual, a.Cap())
+
+				p, err := a.TryAlloc(64)
+				So(p, ShouldNotBeNil)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When the allocation is larger than the buffer", func() {
+			_, err := a.TryAlloc(len(buf) + 1)
+
+			So(err, ShouldEqual, arena.ErrOutOfMemory)
+		})
+	})
+
+	Convey("Given an arena backed by an empty buffer", t, func() {
+		a := arena.FromBuffer(nil)
+
+		Convey("Then every allocation should fail", func() {
+			_, err := a.TryAlloc(1)
+
+			So(err, ShouldEqual, arena.ErrOutOfMemory)
+		})
+	})
+}
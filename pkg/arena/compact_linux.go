@@ -0,0 +1,29 @@
+//go:build go1.22 && linux
+
+package arena
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+	"github.com/flier/goutil/pkg/xunsafe/layout"
+)
+
+// releasePages returns the whole pages of the n bytes at p to the OS, which must
+// not be used anymore.
+//
+// The pages are dropped with MADV_DONTNEED, so they read as zeros if touched again,
+// e.g. once the GC has collected their block and the runtime reused its memory.
+func releasePages(p *byte, n int) {
+	page := syscall.Getpagesize()
+
+	start := xunsafe.AddrOf(p).RoundUpTo(page)
+	end := xunsafe.Addr[byte](layout.RoundDown(int(xunsafe.AddrOf(p).Add(n)), page))
+
+	if start >= end {
+		return
+	}
+
+	_ = syscall.Madvise(unsafe.Slice(start.AssertValid(), end.Sub(start)), syscall.MADV_DONTNEED)
+}
@@ -0,0 +1,7 @@
+//go:build go1.22 && !linux
+
+package arena
+
+// releasePages does nothing, the pages are returned to the OS once the GC has
+// collected their block and the runtime scavenged them.
+func releasePages(p *byte, n int) {}
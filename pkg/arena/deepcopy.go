@@ -0,0 +1,188 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+// ErrUncopyable is raised by [DeepCopy] for a value which can't live in an arena.
+var ErrUncopyable = errors.New("arena: value can't be copied into an arena")
+
+// DeepCopy copies a value into the arena, together with the strings, slices and
+// pointed-to values it references, recursively.
+//
+// The GC doesn't scan arena memory, so a value stored in an arena must not reference
+// memory outside it. DeepCopy takes care of that for values that were built on the
+// heap, such as decoded records stored in the leaves of a tree.
+//
+// Pointers to the same value are copied once, so shared and cyclic structures are
+// preserved, but a pointer into the middle of another value gets its own copy.
+//
+// Maps, channels, functions and non-nil interfaces can't live in an arena, DeepCopy
+// panics with [ErrUncopyable] when it meets one. Struct fields tagged with `arena:"-"`
+// stop the recursion and are copied as-is, the memory they reference must then be
+// kept alive by other means, e.g. with [Arena.KeepAlive].
+//
+// Example:
+//
+//	type Record struct {
+//	    Name  string
+//	    Tags  []string
+//	    Owner *User
+//	    Cache map[string]int `arena:"-"` // Shared with the heap.
+//	}
+//
+//	t.Insert(a, key, *arena.DeepCopy(a, rec))
+func DeepCopy[T any](a Allocator, v T) *T {
+	// The shallow copy in the arena doesn't keep the heap memory referenced by v alive
+	// while it is being walked, v does.
+	defer runtime.KeepAlive(v)
+
+	p := New(a, v)
+
+	c := copier{a: a}
+	c.value(reflect.ValueOf(p).Elem())
+
+	return p
+}
+
+type copier struct {
+	a    Allocator
+	seen map[unsafe.Pointer]unsafe.Pointer
+}
+
+// value replaces the references of the addressable value v with arena copies.
+func (c *copier) value(v reflect.Value) {
+	if !v.CanSet() {
+		// Values read through unexported fields can't be set with reflection.
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if n := v.Len(); n > 0 {
+			b := unsafe.Slice(c.a.Alloc(n), n)
+			copy(b, v.String())
+
+			v.SetString(unsafe.String(&b[0], n))
+		}
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+
+		n := v.Len()
+		if n == 0 {
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0)) // Points to the zero-sized base, not to the heap.
+			return
+		}
+
+		t := v.Type().Elem()
+
+		hdr := struct {
+			data     unsafe.Pointer
+			len, cap int
+		}{c.alloc(t, n), n, n}
+
+		s := reflect.NewAt(v.Type(), unsafe.Pointer(&hdr)).Elem()
+		reflect.Copy(s, v)
+
+		if needsCopy(t) {
+			for i := 0; i < n; i++ {
+				c.value(s.Index(i))
+			}
+		}
+
+		v.Set(s)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+
+		t := v.Type().Elem()
+		if t.Size() == 0 {
+			return
+		}
+
+		old := v.UnsafePointer()
+
+		if p, ok := c.seen[old]; ok {
+			v.Set(reflect.NewAt(t, p))
+			return
+		}
+
+		if c.seen == nil {
+			c.seen = make(map[unsafe.Pointer]unsafe.Pointer)
+		}
+
+		p := reflect.NewAt(t, c.alloc(t, 1))
+		p.Elem().Set(v.Elem())
+
+		c.seen[old] = p.UnsafePointer()
+
+		c.value(p.Elem())
+
+		v.Set(p)
+
+	case reflect.Array:
+		if needsCopy(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				c.value(v.Index(i))
+			}
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+
+		for i := 0; i < v.NumField(); i++ {
+			if f := t.Field(i); f.Tag.Get("arena") != "-" && needsCopy(f.Type) {
+				c.value(v.Field(i))
+			}
+		}
+
+	case reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		if !v.IsNil() {
+			panic(fmt.Errorf("%w: %v", ErrUncopyable, v.Type()))
+		}
+	}
+}
+
+// alloc allocates the memory for n values of type t.
+func (c *copier) alloc(t reflect.Type, n int) unsafe.Pointer {
+	if t.Align() > Align {
+		panic("over-aligned object")
+	}
+
+	return unsafe.Pointer(c.a.Alloc(int(t.Size()) * n))
+}
+
+// needsCopy returns true if the values of type t may reference memory.
+func needsCopy(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Array:
+		return t.Len() > 0 && needsCopy(t.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if needsCopy(t.Field(i).Type) {
+				return true
+			}
+		}
+
+		return false
+
+	case reflect.String, reflect.Slice, reflect.Pointer,
+		reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return true
+
+	default:
+		return false
+	}
+}
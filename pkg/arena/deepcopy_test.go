@@ -0,0 +1,152 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+type user struct {
+	Name string
+}
+
+type record struct {
+	Name   string
+	Tags   []string
+	Scores [2]int
+	Owner  *user
+	Editor *user
+	Cache  map[string]int `arena:"-"`
+	empty  []byte
+	secret string
+	next   *record
+}
+
+func TestDeepCopy(t *testing.T) {
+	Convey("Given an arena on a buffer", t, func() {
+		buf := make([]byte, 4096)
+		a := arena.FromBuffer(buf)
+
+		inArena := func(p unsafe.Pointer) bool {
+			start := uintptr(unsafe.Pointer(&buf[0]))
+
+			return uintptr(p) >= start && uintptr(p) < start+uintptr(len(buf))
+		}
+
+		Convey("When copying a record", func() {
+			owner := &user{Name: "alice"}
+			rec := record{
+				Name:   "hello",
+				Tags:   []string{"foo", "bar"},
+				Scores: [2]int{1, 2},
+				Owner:  owner,
+				Editor: owner,
+				Cache:  map[string]int{"a": 1},
+				empty:  []byte{},
+				secret: "world",
+			}
+			rec.next = &rec
+
+			p := arena.DeepCopy(a, rec)
+
+			Convey("Then the values should be equal", func() {
+				So(p.Name, ShouldEqual, rec.Name)
+				So(p.Tags, ShouldResemble, rec.Tags)
+				So(p.Scores, ShouldEqual, rec.Scores)
+				So(p.Owner.Name, ShouldEqual, "alice")
+				So(p.secret, ShouldEqual, "world")
+				So(p.empty, ShouldNotBeNil)
+				So(p.empty, ShouldBeEmpty)
+			})
+
+			Convey("Then the references should point into the arena", func() {
+				So(inArena(unsafe.Pointer(p)), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(unsafe.StringData(p.Name))), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(unsafe.SliceData(p.Tags))), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(unsafe.StringData(p.Tags[0]))), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(p.Owner)), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(unsafe.StringData(p.Owner.Name))), ShouldBeTrue)
+				So(inArena(unsafe.Pointer(unsafe.StringData(p.secret))), ShouldBeTrue)
+			})
+
+			Convey("Then shared and cyclic pointers should be preserved", func() {
+				So(p.Editor, ShouldPointTo, p.Owner)
+				So(p.next, ShouldNotPointTo, &rec)
+				So(p.next.next, ShouldPointTo, p.next)
+			})
+
+			Convey("Then the tagged fields should be copied as-is", func() {
+				So(p.Cache, ShouldEqual, rec.Cache)
+			})
+
+			Convey("Then the original should be unchanged", func() {
+				So(rec.Owner, ShouldPointTo, owner)
+				So(inArena(unsafe.Pointer(unsafe.StringData(rec.Name))), ShouldBeFalse)
+			})
+		})
+
+		Convey("When copying a value which can't live in an arena", func() {
+			type bad struct {
+				M map[string]int
+			}
+
+			err := func() (err error) {
+				defer func() { err, _ = recover().(error) }()
+
+				arena.DeepCopy(a, bad{M: map[string]int{}})
+
+				return nil
+			}()
+
+			So(err, ShouldWrap, arena.ErrUncopyable)
+			So(func() { arena.DeepCopy(a, bad{}) }, ShouldNotPanic)
+			So(func() { arena.DeepCopy[any](a, 1) }, ShouldPanic)
+		})
+	})
+}
+
+func TestDeepCopy_GC(t *testing.T) {
+	// Reads of collected sources are only detected reliably with GODEBUG=clobberfree=1,
+	// which overwrites the freed memory.
+	Convey("Given heap values only referenced by the argument of DeepCopy", t, func() {
+		defer debug.SetGCPercent(debug.SetGCPercent(1))
+
+		a := new(arena.Arena)
+
+		name := func(i int) string { return strings.Repeat("user", i%16) + strconv.Itoa(i) }
+
+		users := func() []*user {
+			users := make([]*user, 4096)
+			for i := range users {
+				users[i] = &user{Name: name(i)}
+			}
+
+			return users
+		}
+
+		p := arena.DeepCopy(a, users())
+
+		runtime.GC()
+
+		Convey("Then the copies should survive the collection of the sources", func() {
+			bad := 0
+
+			for i, u := range *p {
+				if u.Name != name(i) {
+					bad++
+				}
+			}
+
+			So(bad, ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,180 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DoubleBuffer manages a structure which is rebuilt from scratch and swapped, such as
+// a frozen art.Tree, with two arenas used in turn.
+//
+// Readers pin the current structure with [DoubleBuffer.Acquire] without locking, while
+// [DoubleBuffer.Rebuild] builds the next one into the inactive arena, publishes it,
+// and resets the arena of the previous one once the grace period has elapsed and
+// its last reader has released it.
+//
+// A structure returned by [DoubleBuffer.Load] isn't pinned, it must not be used for
+// longer than the grace period after the next Rebuild, since its memory may be
+// reused afterwards.
+//
+// Example:
+//
+//	db := arena.NewDoubleBuffer[art.FrozenTree[int]](time.Second)
+//
+//	db.Rebuild(func(a *arena.Arena) *art.FrozenTree[int] {
+//	    t := arena.New(a, art.Tree[int]{}) // Not new(art.Tree[int]), the GC doesn't scan the arena.
+//	    ...
+//	    return arena.New(a, t.Freeze())
+//	})
+//
+//	t, release := db.Acquire()
+//	defer release()
+//
+//	v := t.Search(key)
+type DoubleBuffer[T any] struct {
+	mu      sync.Mutex
+	bufs    [2]buffer[T]
+	active  int
+	current atomic.Pointer[buffer[T]]
+	grace   time.Duration
+	reset   [2]chan struct{} // Closed once the arena has been reset, nil if it is ready.
+}
+
+// buffer is an arena with the structure built into it, and the readers pinning it.
+type buffer[T any] struct {
+	arena   Arena
+	value   atomic.Pointer[T]
+	readers atomic.Int64
+	retired atomic.Bool // True once the structure has been replaced.
+	mu      sync.Mutex
+	drained sync.Cond // Signaled when the last reader of a retired buffer releases it.
+}
+
+// NewDoubleBuffer returns an empty double buffer, which resets the arena of a
+// replaced structure after the given grace period.
+func NewDoubleBuffer[T any](grace time.Duration) *DoubleBuffer[T] {
+	return &DoubleBuffer[T]{grace: grace}
+}
+
+// Load returns the current structure, or nil if none has been built yet.
+//
+// The structure isn't pinned, use [DoubleBuffer.Acquire] to read it for longer
+// than the grace period.
+func (d *DoubleBuffer[T]) Load() *T {
+	if b := d.current.Load(); b != nil {
+		return b.value.Load()
+	}
+
+	return nil
+}
+
+// Acquire pins and returns the current structure, or nil if none has been built yet.
+//
+// The memory of the structure isn't reused until the returned function is called,
+// which must be called exactly once.
+func (d *DoubleBuffer[T]) Acquire() (*T, func()) {
+	for {
+		b := d.current.Load()
+		if b == nil {
+			return nil, func() {}
+		}
+
+		b.readers.Add(1)
+
+		// Rebuild swaps the current buffer before waiting for its readers,
+		// so a reader counted while the buffer is still current is waited for.
+		if d.current.Load() == b {
+			return b.value.Load(), b.release
+		}
+
+		b.release()
+	}
+}
+
+// release unpins the buffer, and wakes up the reset of a retired buffer on its last reader.
+func (b *buffer[T]) release() {
+	if b.readers.Add(-1) == 0 && b.retired.Load() {
+		b.mu.Lock()
+		b.drained.Broadcast()
+		b.mu.Unlock()
+	}
+}
+
+// wait waits for the last reader of the retired buffer to release it.
+func (b *buffer[T]) wait() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.drained.L == nil {
+		b.drained.L = &b.mu
+	}
+
+	for b.readers.Load() != 0 {
+		b.drained.Wait()
+	}
+}
+
+// Rebuild builds a new structure into the inactive arena and makes it the current one.
+//
+// It waits for the inactive arena to be reset if the grace period of the structure
+// it held hasn't elapsed yet, or if readers still pin it. If build panics, the
+// inactive arena is reset and the current structure is kept.
+//
+// It returns the new structure.
+func (d *DoubleBuffer[T]) Rebuild(build func(a *Arena) *T) *T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	next := 1 - d.active
+
+	if ch := d.reset[next]; ch != nil {
+		<-ch
+
+		d.reset[next] = nil
+	}
+
+	b := &d.bufs[next]
+
+	done := false
+	defer func() {
+		if !done {
+			b.arena.Reset()
+		}
+	}()
+
+	p := build(&b.arena)
+	done = true
+
+	b.value.Store(p)
+	b.retired.Store(false)
+
+	old := d.current.Swap(b)
+	prev := d.active
+	d.active = next
+
+	if old != nil {
+		d.retire(prev)
+	}
+
+	return p
+}
+
+// retire resets the arena i once the grace period has elapsed and its readers have released it.
+func (d *DoubleBuffer[T]) retire(i int) {
+	b := &d.bufs[i]
+
+	b.retired.Store(true)
+
+	ch := make(chan struct{})
+	d.reset[i] = ch
+
+	time.AfterFunc(max(d.grace, 0), func() {
+		b.wait()
+		b.value.Store(nil)
+		b.arena.Reset()
+		close(ch)
+	})
+}
@@ -0,0 +1,143 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+func TestDoubleBuffer(t *testing.T) {
+	Convey("Given a double buffer", t, func() {
+		db := arena.NewDoubleBuffer[[4]int64](10 * time.Millisecond)
+
+		So(db.Load(), ShouldBeNil)
+
+		build := func(v int64) func(a *arena.Arena) *[4]int64 {
+			return func(a *arena.Arena) *[4]int64 {
+				return arena.New(a, [4]int64{v, v, v, v})
+			}
+		}
+
+		Convey("When rebuilding", func() {
+			p := db.Rebuild(build(1))
+
+			So(db.Load(), ShouldEqual, p)
+			So(*p, ShouldEqual, [4]int64{1, 1, 1, 1})
+
+			Convey("Then the previous structure lives until the grace period has elapsed", func() {
+				q := db.Rebuild(build(2))
+
+				So(db.Load(), ShouldEqual, q)
+				So(*p, ShouldEqual, [4]int64{1, 1, 1, 1})
+
+				time.Sleep(50 * time.Millisecond)
+
+				So(*p, ShouldNotEqual, [4]int64{1, 1, 1, 1})
+				So(*q, ShouldEqual, [4]int64{2, 2, 2, 2})
+			})
+
+			Convey("Then the next rebuild waits for the arena to be reset", func() {
+				db.Rebuild(build(2))
+
+				start := time.Now()
+				r := db.Rebuild(build(3))
+
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 5*time.Millisecond)
+				So(*r, ShouldEqual, [4]int64{3, 3, 3, 3})
+			})
+
+			Convey("Then a panicking build keeps the current structure", func() {
+				So(func() {
+					db.Rebuild(func(a *arena.Arena) *[4]int64 {
+						arena.New(a, [4]int64{})
+						panic("boom")
+					})
+				}, ShouldPanicWith, "boom")
+
+				So(db.Load(), ShouldEqual, p)
+			})
+		})
+
+		Convey("When a reader pins the current structure", func() {
+			db := arena.NewDoubleBuffer[[4]int64](0)
+
+			db.Rebuild(build(1))
+
+			p, release := db.Acquire()
+
+			Convey("Then its arena is not reset until it is released", func() {
+				db.Rebuild(build(2))
+
+				done := make(chan *[4]int64)
+
+				go func() { done <- db.Rebuild(build(3)) }()
+
+				blocked := true
+
+				select {
+				case <-done:
+					blocked = false
+				case <-time.After(20 * time.Millisecond):
+				}
+
+				So(blocked, ShouldBeTrue)
+
+				So(*p, ShouldEqual, [4]int64{1, 1, 1, 1})
+
+				release()
+
+				So(*<-done, ShouldEqual, [4]int64{3, 3, 3, 3})
+			})
+		})
+
+		Convey("When reading concurrently with the rebuilds", func() {
+			var wg sync.WaitGroup
+			var torn atomic.Int64
+
+			stop := make(chan struct{})
+
+			db := arena.NewDoubleBuffer[[4]int64](0)
+			db.Rebuild(build(0))
+
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					for {
+						select {
+						case <-stop:
+							return
+						default:
+						}
+
+						p, release := db.Acquire()
+						if v := p[0]; p[1] != v || p[2] != v || p[3] != v {
+							torn.Add(1)
+						}
+
+						release()
+					}
+				}()
+			}
+
+			for i := int64(1); i <= 20; i++ {
+				db.Rebuild(build(i))
+			}
+
+			close(stop)
+			wg.Wait()
+
+			So(torn.Load(), ShouldEqual, 0)
+			So(db.Load()[0], ShouldEqual, 20)
+		})
+	})
+}
@@ -0,0 +1,113 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// Failing is an allocator which injects an allocation failure after a given number
+// of allocations, so that tests can deterministically exercise the out-of-memory
+// paths of the code using it.
+//
+// Once the limit is reached, [Failing.Alloc] panics with [ErrOutOfMemory] like an
+// exhausted [FromBuffer] arena, and keeps failing until [Failing.FailAfter] is
+// called again.
+//
+// Failing reports no free space from Next and End, so that callers which grow
+// their allocations in place, such as slice.Slice.Grow, always go through Alloc
+// and every allocation is counted.
+//
+// Example:
+//
+//	for n := 0; ; n++ {
+//	    a := arena.FailAfter(new(arena.Arena), n)
+//
+//	    err := arena.CatchOutOfMemory(func() { t.Insert(a, key, value) })
+//	    if err == nil {
+//	        break // Succeeded with n allocations.
+//	    }
+//
+//	    // Check that the failure left things consistent.
+//	}
+type Failing struct {
+	a      AllocatorExt
+	limit  int
+	allocs int
+}
+
+var _ AllocatorExt = (*Failing)(nil)
+
+// FailAfter returns an allocator which allocates from a, but fails every allocation
+// after the first n ones.
+func FailAfter(a AllocatorExt, n int) *Failing {
+	return &Failing{a: a, limit: n}
+}
+
+// FailAfter allows n more allocations before failing again.
+func (f *Failing) FailAfter(n int) {
+	f.limit = f.allocs + n
+}
+
+// Allocs returns the number of successful allocations so far.
+func (f *Failing) Allocs() int { return f.allocs }
+
+// Alloc allocates memory from the underlying allocator, or panics with
+// [ErrOutOfMemory] once the limit of allocations is reached.
+//
+// Do not use this method directly, use [New] instead.
+func (f *Failing) Alloc(size int) *byte {
+	p, err := f.TryAlloc(size)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// TryAlloc allocates memory like [Failing.Alloc], but returns [ErrOutOfMemory]
+// instead of panicking once the limit of allocations is reached.
+func (f *Failing) TryAlloc(size int) (*byte, error) {
+	if f.allocs >= f.limit {
+		f.Log("fail", "%d, after %d allocs", size, f.allocs)
+
+		return nil, ErrOutOfMemory
+	}
+
+	f.allocs++
+
+	return f.a.Alloc(size), nil
+}
+
+// Release releases memory back to the underlying allocator.
+//
+// Do not use this method directly, use [Free] instead.
+func (f *Failing) Release(p *byte, size int) { f.a.Release(p, size) }
+
+func (f *Failing) Next() xunsafe.Addr[byte] { return 0 }
+func (f *Failing) End() xunsafe.Addr[byte]  { return 0 }
+func (f *Failing) Cap() int                 { return f.a.Cap() }
+func (f *Failing) Advance(n int)            {}
+
+func (f *Failing) Log(op, format string, args ...any) {
+	f.a.Log(op, format, args...)
+}
+
+// CatchOutOfMemory calls f and returns [ErrOutOfMemory] if it panics with it.
+//
+// Any other panic is propagated.
+func CatchOutOfMemory(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrOutOfMemory { //nolint:errorlint
+				panic(r)
+			}
+
+			err = ErrOutOfMemory
+		}
+	}()
+
+	f()
+
+	return nil
+}
@@ -0,0 +1,57 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+func TestFailAfter(t *testing.T) {
+	Convey("Given an allocator failing after 2 allocations", t, func() {
+		a := arena.FailAfter(new(arena.Arena), 2)
+
+		So(a.Alloc(8), ShouldNotBeNil)
+		So(arena.New(a, 42), ShouldNotBeNil)
+		So(a.Allocs(), ShouldEqual, 2)
+
+		Convey("Then the next allocation should fail", func() {
+			So(func() { a.Alloc(8) }, ShouldPanicWith, arena.ErrOutOfMemory)
+
+			p, err := a.TryAlloc(8)
+			So(p, ShouldBeNil)
+			So(err, ShouldEqual, arena.ErrOutOfMemory)
+
+			So(a.Allocs(), ShouldEqual, 2)
+		})
+
+		Convey("Then FailAfter should allow more allocations", func() {
+			a.FailAfter(1)
+
+			So(a.Alloc(8), ShouldNotBeNil)
+			So(func() { a.Alloc(8) }, ShouldPanicWith, arena.ErrOutOfMemory)
+		})
+	})
+}
+
+func TestCatchOutOfMemory(t *testing.T) {
+	Convey("Given a function which runs out of memory", t, func() {
+		a := arena.FailAfter(new(arena.Arena), 0)
+
+		So(arena.CatchOutOfMemory(func() { a.Alloc(8) }), ShouldEqual, arena.ErrOutOfMemory)
+	})
+
+	Convey("Given a function which succeeds", t, func() {
+		So(arena.CatchOutOfMemory(func() {}), ShouldBeNil)
+	})
+
+	Convey("Given a function which panics with another error", t, func() {
+		err := errors.New("boom")
+
+		So(func() { _ = arena.CatchOutOfMemory(func() { panic(err) }) }, ShouldPanicWith, err)
+	})
+}
@@ -0,0 +1,183 @@
+//go:build go1.20
+
+// Package heap provides a binary heap stored in an arena.
+//
+// Unlike [container/heap], the elements are not boxed into interfaces nor stored
+// on the GC heap, so it is suitable for large priority queues, such as the expiry
+// queue of a cache index living in the same arena.
+package heap
+
+import (
+	"fmt"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// Heap is a binary min-heap ordered by a less function, whose elements are stored
+// in a [slice.Slice].
+//
+// Example:
+//
+//	h := heap.New(func(a, b Entry) bool { return a.Expiry < b.Expiry })
+//
+//	h.Push(a, Entry{Key: 1, Expiry: 30})
+//	h.Push(a, Entry{Key: 2, Expiry: 10})
+//
+//	e, _ := h.Pop() // Entry{Key: 2, Expiry: 10}
+type Heap[T any] struct {
+	data  slice.Slice[T]
+	less  func(a, b T) bool
+	moved func(v *T, i int) // Nil if the indexes are not tracked.
+}
+
+// New returns an empty heap ordered by less, whose first element is the least one.
+func New[T any](less func(a, b T) bool) Heap[T] {
+	return Heap[T]{less: less}
+}
+
+// NewIndexed returns an empty heap like [New], which calls moved with the new index
+// of an element every time it is pushed or moved, so that the element can record
+// its index for [Heap.Fix] or [Heap.Remove].
+func NewIndexed[T any](less func(a, b T) bool, moved func(v *T, i int)) Heap[T] {
+	return Heap[T]{less: less, moved: moved}
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int { return h.data.Len() }
+
+// Empty returns true if the heap has no elements.
+func (h *Heap[T]) Empty() bool { return h.data.Empty() }
+
+// Raw returns the elements in heap order, which must not be modified without
+// calling [Heap.Fix].
+func (h *Heap[T]) Raw() []T { return h.data.Raw() }
+
+// Push pushes an element onto the heap, reallocating it on the given arena if necessary.
+func (h *Heap[T]) Push(a arena.AllocatorExt, v T) {
+	h.data = h.data.AppendOne(a, v)
+
+	i := h.data.Len() - 1
+	h.notify(i)
+	h.up(i)
+}
+
+// Peek returns the least element without removing it, or false if the heap is empty.
+func (h *Heap[T]) Peek() (v T, ok bool) {
+	if h.data.Empty() {
+		return
+	}
+
+	return h.data.Load(0), true
+}
+
+// Pop removes and returns the least element, or false if the heap is empty.
+func (h *Heap[T]) Pop() (v T, ok bool) {
+	if h.data.Empty() {
+		return
+	}
+
+	return h.Remove(0), true
+}
+
+// Remove removes and returns the element at index i.
+func (h *Heap[T]) Remove(i int) T {
+	h.check(i)
+
+	n := h.data.Len() - 1
+	if i != n {
+		h.swap(i, n)
+
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+
+	v := h.data.Load(n)
+	h.data = h.data.SetLen(n)
+
+	return v
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed.
+func (h *Heap[T]) Fix(i int) {
+	h.check(i)
+
+	if !h.down(i, h.data.Len()) {
+		h.up(i)
+	}
+}
+
+// Reset removes all elements, keeping the capacity.
+func (h *Heap[T]) Reset() {
+	h.data = h.data.SetLen(0)
+}
+
+// Release releases the memory of the heap back to the arena.
+func (h *Heap[T]) Release(a arena.Allocator) {
+	if h.data.Cap() > 0 {
+		h.data.Release(a)
+	}
+
+	h.data = slice.Slice[T]{}
+}
+
+func (h *Heap[T]) check(i int) {
+	if i < 0 || i >= h.data.Len() {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with length %d", i, h.data.Len()))
+	}
+}
+
+func (h *Heap[T]) lessAt(i, j int) bool {
+	return h.less(h.data.Load(i), h.data.Load(j))
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.data.Swap(i, j)
+	h.notify(i)
+	h.notify(j)
+}
+
+func (h *Heap[T]) notify(i int) {
+	if h.moved != nil {
+		h.moved(h.data.Get(i), i)
+	}
+}
+
+func (h *Heap[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 // parent
+		if !h.lessAt(j, i) {
+			break
+		}
+
+		h.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the element at i0 down within the first n elements, and returns true
+// if it has moved.
+func (h *Heap[T]) down(i0, n int) bool {
+	i := i0
+
+	for {
+		j := 2*i + 1 // left child
+		if j >= n {
+			break
+		}
+
+		if r := j + 1; r < n && h.lessAt(r, j) {
+			j = r
+		}
+
+		if !h.lessAt(j, i) {
+			break
+		}
+
+		h.swap(i, j)
+		i = j
+	}
+
+	return i > i0
+}
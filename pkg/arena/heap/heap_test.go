@@ -0,0 +1,132 @@
+//go:build go1.20
+
+package heap_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/heap"
+)
+
+type entry struct {
+	key, expiry int
+	index       int
+}
+
+func TestHeap(t *testing.T) {
+	Convey("Given an empty heap", t, func() {
+		a := &arena.Arena{}
+		h := heap.New(func(a, b int) bool { return a < b })
+
+		So(h.Empty(), ShouldBeTrue)
+
+		_, ok := h.Peek()
+		So(ok, ShouldBeFalse)
+
+		_, ok = h.Pop()
+		So(ok, ShouldBeFalse)
+
+		Convey("When pushing random elements", func() {
+			rng := rand.New(rand.NewSource(1))
+
+			var want []int
+
+			for i := 0; i < 500; i++ {
+				v := rng.Intn(100)
+				h.Push(a, v)
+				want = append(want, v)
+			}
+
+			sort.Ints(want)
+
+			Convey("Then they should be popped in order", func() {
+				So(h.Len(), ShouldEqual, 500)
+
+				v, ok := h.Peek()
+				So(ok, ShouldBeTrue)
+				So(v, ShouldEqual, want[0])
+
+				var got []int
+
+				for !h.Empty() {
+					v, _ := h.Pop()
+					got = append(got, v)
+				}
+
+				So(got, ShouldResemble, want)
+			})
+
+			Convey("Then resetting it should empty it", func() {
+				h.Reset()
+
+				So(h.Len(), ShouldEqual, 0)
+
+				h.Push(a, 3)
+				So(h.Raw(), ShouldResemble, []int{3})
+			})
+		})
+	})
+
+	Convey("Given an indexed heap of entries", t, func() {
+		a := &arena.Arena{}
+
+		var entries []*entry
+
+		h := heap.NewIndexed(
+			func(a, b entry) bool { return a.expiry < b.expiry },
+			func(e *entry, i int) { entries[e.key].index = i },
+		)
+
+		for i, expiry := range []int{50, 10, 40, 20, 30} {
+			entries = append(entries, &entry{key: i, expiry: expiry})
+			h.Push(a, *entries[i])
+		}
+
+		Convey("Then the indexes should be tracked", func() {
+			for i, e := range h.Raw() {
+				So(entries[e.key].index, ShouldEqual, i)
+			}
+		})
+
+		Convey("When an expiry is changed and fixed", func() {
+			i := entries[0].index
+			h.Raw()[i].expiry = 5
+			h.Fix(i)
+
+			e, _ := h.Peek()
+			So(e.key, ShouldEqual, 0)
+
+			Convey("Then the indexes should still be tracked", func() {
+				for i, e := range h.Raw() {
+					So(entries[e.key].index, ShouldEqual, i)
+				}
+			})
+		})
+
+		Convey("When an entry is removed", func() {
+			e := h.Remove(entries[1].index)
+
+			So(e.key, ShouldEqual, 1)
+			So(h.Len(), ShouldEqual, 4)
+
+			var keys []int
+
+			for !h.Empty() {
+				e, _ := h.Pop()
+				keys = append(keys, e.key)
+			}
+
+			So(keys, ShouldResemble, []int{3, 4, 2, 0})
+		})
+
+		Convey("Then out of range indexes should panic", func() {
+			So(func() { h.Fix(5) }, ShouldPanic)
+			So(func() { h.Remove(-1) }, ShouldPanic)
+		})
+	})
+}
@@ -0,0 +1,153 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+	"github.com/flier/goutil/pkg/xunsafe/layout"
+)
+
+var (
+	// ErrNotContiguous is returned by [Arena.Save] for an arena which is not backed
+	// by a buffer, whose memory is spread over several blocks.
+	ErrNotContiguous = errors.New("arena: memory is not contiguous")
+
+	// ErrMisaligned is returned by [FromImage] for an image which is not aligned to [Align].
+	ErrMisaligned = errors.New("arena: image is misaligned")
+)
+
+// Offset is a pointer-free reference to a value in an arena backed by a buffer,
+// relative to the start of the buffer.
+//
+// Unlike a pointer, an offset stays valid when the memory of the arena is saved
+// with [Arena.Save] and loaded at another address with [FromImage], e.g. a file
+// mapped by several processes. Only the values linked with offsets can be
+// relocated this way: the structures linked with pointers, such as a slice.Slice
+// or an art.Tree, still reference the memory they were built in.
+//
+// The zero Offset references nothing.
+//
+// Example:
+//
+//	type Node struct {
+//	    Value int
+//	    Next  arena.Offset[Node]
+//	}
+//
+//	a := arena.FromBuffer(buf)
+//	tail := arena.New(a, Node{Value: 2})
+//	head := arena.New(a, Node{Value: 1, Next: arena.OffsetOf(a, tail)})
+//	root := arena.OffsetOf(a, head)
+//
+//	a.Save(f)
+//
+//	img, _ := arena.FromImage(data) // In another process, with root saved aside.
+//	for n := root.In(img); n != nil; n = n.Next.In(img) {
+//	    ...
+//	}
+type Offset[T any] struct {
+	off uint64 // Offset from the start of the buffer plus one.
+}
+
+// OffsetOf returns the offset of p, which must have been allocated from the arena a
+// backed by a buffer.
+//
+// It returns the zero offset if p is nil, and panics if p is outside of the arena.
+func OffsetOf[T any](a *Arena, p *T) Offset[T] {
+	if p == nil {
+		return Offset[T]{}
+	}
+
+	base := a.base()
+	addr := xunsafe.AddrOf(xunsafe.Cast[byte](p))
+
+	if a.buf == nil || addr < base || addr >= a.next {
+		panic(fmt.Errorf("arena: %v is outside of the arena %v:%v", addr, base, a.next))
+	}
+
+	return Offset[T]{uint64(addr.Sub(base)) + 1}
+}
+
+// IsNil returns true if the offset references nothing.
+func (o Offset[T]) IsNil() bool { return o.off == 0 }
+
+// In returns the pointer to the value at the offset in the arena a backed by a buffer,
+// or nil if the offset is zero.
+//
+// It panics if the value would overflow the allocated memory of the arena, or if
+// the offset is misaligned for T, e.g. when read from a corrupted image.
+func (o Offset[T]) In(a *Arena) *T {
+	if o.off == 0 {
+		return nil
+	}
+
+	base := a.base()
+	off, n := o.off-1, uint64(a.next.Sub(base))
+
+	if a.buf == nil || off > n || uint64(layout.Size[T]()) > n-off {
+		panic(fmt.Errorf("arena: offset %d is outside of the arena %v:%v", off, base, a.next))
+	}
+
+	if off%uint64(layout.Align[T]()) != 0 {
+		panic(fmt.Errorf("arena: offset %d is misaligned for %T", off, (*T)(nil)))
+	}
+
+	return xunsafe.Cast[T](base.Add(int(off)).AssertValid())
+}
+
+// Save writes the allocated memory of an arena backed by a buffer as one contiguous
+// image, which can be loaded again with [FromImage].
+//
+// It returns [ErrNotContiguous] if the arena is not backed by a buffer.
+func (a *Arena) Save(w io.Writer) (int64, error) {
+	if a.buf == nil {
+		return 0, ErrNotContiguous
+	}
+
+	base := a.base()
+	if a.next == base {
+		return 0, nil
+	}
+
+	n, err := w.Write(unsafe.Slice(base.AssertValid(), a.next.Sub(base)))
+
+	return int64(n), err
+}
+
+// FromImage returns an arena whose allocated memory is the given image, as written by
+// [Arena.Save], so that its values can be referenced with the same offsets.
+//
+// The image is typically a file mapped read-only into memory, so the arena is full:
+// allocating from it fails with [ErrOutOfMemory], and it must not be reset, which
+// would clear the image. The image must be aligned to [Align], as page-aligned
+// mappings are, otherwise [ErrMisaligned] is returned.
+func FromImage(image []byte) (*Arena, error) {
+	if image == nil {
+		image = []byte{}
+	}
+
+	start := xunsafe.AddrOf(unsafe.SliceData(image))
+	if start.Padding(Align) != 0 || len(image)%Align != 0 {
+		return nil, ErrMisaligned
+	}
+
+	a := &Arena{buf: image}
+
+	if len(image) > 0 {
+		a.next = start.Add(len(image))
+		a.end = a.next
+		a.cap = len(image)
+	}
+
+	return a, nil
+}
+
+// base returns the start of the buffer backing the arena.
+func (a *Arena) base() xunsafe.Addr[byte] {
+	return a.end.Add(-a.cap)
+}
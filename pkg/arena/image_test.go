@@ -0,0 +1,129 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+type imageNode struct {
+	Value int
+	Next  arena.Offset[imageNode]
+}
+
+// alignedCopy copies b into a buffer aligned to arena.Align.
+func alignedCopy(b []byte) []byte {
+	words := make([]uint64, (len(b)+7)/8)
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(words))), len(b))
+
+	copy(buf, b)
+
+	return buf
+}
+
+func TestArena_Save(t *testing.T) {
+	Convey("Given a linked list in an arena backed by a buffer", t, func() {
+		a := arena.FromBuffer(make([]byte, 1024))
+
+		var next arena.Offset[imageNode]
+
+		for i := 3; i > 0; i-- {
+			next = arena.OffsetOf(a, arena.New(a, imageNode{Value: i, Next: next}))
+		}
+
+		root := next
+
+		So(root.IsNil(), ShouldBeFalse)
+		So(root.In(a).Value, ShouldEqual, 1)
+
+		Convey("When saving and loading it at another address", func() {
+			var buf bytes.Buffer
+
+			n, err := a.Save(&buf)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, int64(3*unsafe.Sizeof(imageNode{})))
+
+			img, err := arena.FromImage(alignedCopy(buf.Bytes()))
+			So(err, ShouldBeNil)
+
+			Convey("Then the offsets should resolve in the image", func() {
+				var values []int
+
+				for n := root.In(img); n != nil; n = n.Next.In(img) {
+					values = append(values, n.Value)
+				}
+
+				So(values, ShouldResemble, []int{1, 2, 3})
+			})
+
+			Convey("Then the image should be full", func() {
+				_, err := img.TryAlloc(8)
+
+				So(err, ShouldEqual, arena.ErrOutOfMemory)
+			})
+
+			Convey("Then an offset outside of the image should panic", func() {
+				out := arena.OffsetOf(a, arena.New(a, imageNode{}))
+
+				So(func() { out.In(img) }, ShouldPanic)
+			})
+
+			Convey("Then a corrupted offset should panic", func() {
+				next := &root.In(img).Next
+				raw := (*uint64)(unsafe.Pointer(next))
+
+				*raw = 1<<63 + 1
+				So(func() { next.In(img) }, ShouldPanic)
+
+				*raw = 1<<64 - 1
+				So(func() { next.In(img) }, ShouldPanic)
+
+				*raw = 3 // Misaligned.
+				So(func() { next.In(img) }, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a growable arena", t, func() {
+		a := new(arena.Arena)
+		p := arena.New(a, 42)
+
+		Convey("Then it should not be saved", func() {
+			_, err := a.Save(new(bytes.Buffer))
+
+			So(err, ShouldEqual, arena.ErrNotContiguous)
+			So(func() { arena.OffsetOf(a, p) }, ShouldPanic)
+		})
+	})
+
+	Convey("Given an empty arena backed by a buffer", t, func() {
+		a := arena.FromBuffer(make([]byte, 64))
+
+		n, err := a.Save(new(bytes.Buffer))
+
+		So(n, ShouldEqual, int64(0))
+		So(err, ShouldBeNil)
+		So(arena.OffsetOf[int](a, nil).IsNil(), ShouldBeTrue)
+		So(arena.Offset[int]{}.In(a), ShouldBeNil)
+	})
+
+	Convey("Given a misaligned image", t, func() {
+		buf := alignedCopy(make([]byte, 32))
+
+		_, err := arena.FromImage(buf[1:17])
+		So(err, ShouldEqual, arena.ErrMisaligned)
+
+		_, err = arena.FromImage(buf[:12])
+		So(err, ShouldEqual, arena.ErrMisaligned)
+
+		img, err := arena.FromImage(nil)
+		So(err, ShouldBeNil)
+		So(img.Remaining(), ShouldEqual, 0)
+	})
+}
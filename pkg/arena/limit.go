@@ -0,0 +1,143 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"sync/atomic"
+)
+
+// Pressure is the level of memory pressure reported by a [Limit].
+type Pressure int
+
+const (
+	// PressureSoft is reported when the memory used crosses the soft limit.
+	PressureSoft Pressure = iota + 1
+
+	// PressureHard is reported when an allocation is refused by the hard limit.
+	PressureHard
+)
+
+func (p Pressure) String() string {
+	switch p {
+	case PressureSoft:
+		return "soft"
+	case PressureHard:
+		return "hard"
+	default:
+		return "none"
+	}
+}
+
+// Limit accounts for the memory of the blocks allocated by a set of arenas, see
+// [Arena.SetLimit], and applies backpressure before the process runs out of memory.
+//
+// Once the memory used crosses the soft limit, the pressure callback is called with
+// [PressureSoft], once until the usage drops back below the soft limit. An arena
+// which would grow beyond the hard limit panics with [ErrOutOfMemory] instead, or
+// returns it from [Arena.TryAlloc], after calling the callback with [PressureHard].
+//
+// The callback is called synchronously on the goroutine which grows the arena, it
+// must not allocate from that arena. A Limit can be shared by arenas used from
+// different goroutines.
+//
+// Example:
+//
+//	limit := arena.NewLimit(256<<20, 512<<20, func(p arena.Pressure, used int) {
+//	    log.Printf("arena memory pressure: %v, %d bytes used", p, used)
+//	})
+//
+//	a := new(arena.Arena)
+//	a.SetLimit(limit)
+type Limit struct {
+	soft, hard int // Zero means no limit.
+	onPressure func(p Pressure, used int)
+
+	used atomic.Int64
+	over atomic.Bool // Above the soft limit, and the callback has been called.
+}
+
+// NewLimit returns a limit with the given soft and hard limits in bytes, zero
+// meaning no limit, and an optional pressure callback.
+func NewLimit(soft, hard int, onPressure func(p Pressure, used int)) *Limit {
+	return &Limit{soft: soft, hard: hard, onPressure: onPressure}
+}
+
+// Used returns the number of bytes used by the arenas sharing the limit.
+func (l *Limit) Used() int { return int(l.used.Load()) }
+
+// Soft returns the soft limit in bytes, zero meaning no limit.
+func (l *Limit) Soft() int { return l.soft }
+
+// Hard returns the hard limit in bytes, zero meaning no limit.
+func (l *Limit) Hard() int { return l.hard }
+
+// charge accounts for n more bytes, or returns [ErrOutOfMemory] if that would
+// exceed the hard limit, unless forced.
+func (l *Limit) charge(n int, force bool) error {
+	used := int(l.used.Add(int64(n)))
+
+	if !force && l.hard > 0 && used > l.hard {
+		l.used.Add(-int64(n))
+		l.notify(PressureHard, used)
+
+		return ErrOutOfMemory
+	}
+
+	if l.soft > 0 && used > l.soft && l.over.CompareAndSwap(false, true) {
+		l.notify(PressureSoft, used)
+	}
+
+	return nil
+}
+
+// refund accounts for n bytes being freed.
+func (l *Limit) refund(n int) {
+	if used := int(l.used.Add(-int64(n))); used <= l.soft {
+		l.over.Store(false)
+	}
+}
+
+func (l *Limit) notify(p Pressure, used int) {
+	if l.onPressure != nil {
+		l.onPressure(p, used)
+	}
+}
+
+// SetLimit makes the blocks of memory allocated by the arena count against l, or
+// removes the arena from its limit if l is nil.
+//
+// The blocks the arena already holds are moved from its previous limit to l, even if
+// that exceeds the hard limit of l. Resetting the arena refunds the blocks it discards,
+// so an arena should be reset with [Arena.ResetKeep](0), or removed from its limit,
+// before being dropped.
+func (a *Arena) SetLimit(l *Limit) {
+	if a.limit == l {
+		return
+	}
+
+	n := a.blockBytes(0)
+
+	if a.limit != nil {
+		a.limit.refund(n)
+	}
+
+	if l != nil && n > 0 {
+		_ = l.charge(n, true)
+	}
+
+	a.limit = l
+}
+
+// Limit returns the limit of the arena, or nil if it has none.
+func (a *Arena) Limit() *Limit { return a.limit }
+
+// blockBytes returns the total size of the blocks from the given log size.
+func (a *Arena) blockBytes(first int) (n int) {
+	for i := first; i < len(a.blocks); i++ {
+		if a.blocks[i] != nil {
+			n += 1 << i
+		}
+	}
+
+	return
+}
@@ -0,0 +1,122 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+func TestLimit(t *testing.T) {
+	Convey("Given arenas sharing a limit", t, func() {
+		type event struct {
+			p    arena.Pressure
+			used int
+		}
+
+		var events []event
+
+		limit := arena.NewLimit(4096, 8192, func(p arena.Pressure, used int) {
+			events = append(events, event{p, used})
+		})
+
+		a, b := new(arena.Arena), new(arena.Arena)
+		a.SetLimit(limit)
+		b.SetLimit(limit)
+
+		So(a.Limit(), ShouldEqual, limit)
+		So(limit.Soft(), ShouldEqual, 4096)
+		So(limit.Hard(), ShouldEqual, 8192)
+
+		Convey("When allocating below the soft limit", func() {
+			a.Alloc(1024)
+			b.Alloc(1024)
+
+			Convey("Then the blocks should be accounted without pressure", func() {
+				So(limit.Used(), ShouldEqual, 2048)
+				So(events, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When crossing the soft limit", func() {
+			a.Alloc(4096)
+			b.Alloc(16)
+			b.Alloc(1024)
+
+			Convey("Then the callback should be called once", func() {
+				So(limit.Used(), ShouldBeGreaterThan, 4096)
+				So(events, ShouldHaveLength, 1)
+				So(events[0].p, ShouldEqual, arena.PressureSoft)
+				So(events[0].used, ShouldBeGreaterThan, 4096)
+			})
+
+			Convey("Then it should be called again after dropping below the soft limit", func() {
+				a.ResetKeep(0)
+				So(limit.Used(), ShouldBeLessThanOrEqualTo, 4096)
+
+				a.Alloc(4096)
+				So(events, ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When growing beyond the hard limit", func() {
+			a.Alloc(4096)
+			used := limit.Used()
+
+			Convey("Then Alloc should panic with ErrOutOfMemory", func() {
+				So(arena.CatchOutOfMemory(func() { b.Alloc(8192) }), ShouldEqual, arena.ErrOutOfMemory)
+				So(limit.Used(), ShouldEqual, used)
+				So(events[len(events)-1].p, ShouldEqual, arena.PressureHard)
+			})
+
+			Convey("Then TryAlloc should return ErrOutOfMemory", func() {
+				p, err := b.TryAlloc(8192)
+				So(err, ShouldEqual, arena.ErrOutOfMemory)
+				So(p, ShouldBeNil)
+
+				p, err = b.TryAlloc(64)
+				So(err, ShouldBeNil)
+				So(p, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When resetting an arena", func() {
+			a.Alloc(1024)
+			a.Alloc(2048)
+			used := limit.Used()
+
+			a.Reset()
+
+			Convey("Then the discarded blocks should be refunded", func() {
+				So(limit.Used(), ShouldBeLessThan, used)
+				So(limit.Used(), ShouldEqual, a.Cap())
+
+				a.Alloc(64)
+				So(limit.Used(), ShouldEqual, a.Cap())
+			})
+		})
+
+		Convey("When removing an arena from the limit", func() {
+			a.Alloc(1024)
+			b.Alloc(64)
+
+			a.SetLimit(nil)
+
+			Convey("Then its blocks should be refunded", func() {
+				So(limit.Used(), ShouldEqual, b.Cap())
+				So(a.Limit(), ShouldBeNil)
+			})
+
+			Convey("Then moving it to another limit should charge its blocks", func() {
+				other := arena.NewLimit(0, 1, nil)
+				a.SetLimit(other)
+
+				So(other.Used(), ShouldEqual, a.Cap())
+				So(arena.CatchOutOfMemory(func() { a.Alloc(a.Cap()) }), ShouldEqual, arena.ErrOutOfMemory)
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"math/bits"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// Mark is a position in an [Arena], which everything allocated after it can be
+// released back to, see [Arena.Mark].
+type Mark struct {
+	next, end xunsafe.Addr[byte]
+	cap       int
+	keep      int
+}
+
+// Mark returns the current position of the arena, so that the memory allocated
+// after it can be released by [Arena.ReleaseTo].
+//
+// This allows stack-discipline region allocation, e.g. a parser can speculatively
+// allocate while trying an alternative, and backtrack without leaking the memory
+// of the failed attempt until the next [Arena.Reset]:
+//
+//	m := a.Mark()
+//
+//	if node, ok := parseExpr(a, input); ok {
+//	    return node
+//	}
+//
+//	a.ReleaseTo(m) // Discard everything the failed attempt allocated.
+//
+// A mark is invalidated by [Arena.Reset], and by releasing to an earlier mark.
+func (a *Arena) Mark() Mark {
+	return Mark{a.next, a.end, a.cap, len(a.keep)}
+}
+
+// ReleaseTo frees everything allocated after the mark m, which must have been
+// returned by [Arena.Mark] on this arena and must still be valid.
+//
+// The released memory is cleared and reused by later allocations, any pointer
+// into it must not be used anymore. The fini functions of the [Typed] facades of
+// the arena run first, on their values allocated after the mark.
+func (a *Arena) ReleaseTo(m Mark) {
+	for _, release := range a.releases {
+		release(m)
+	}
+
+	if m.cap == a.cap {
+		debug.Assert(m.end == a.end && m.next <= a.next, "arena: invalid mark %v:%v, at %v:%v", m.next, m.end, a.next, a.end)
+
+		xunsafe.Clear(m.next.AssertValid(), a.next.Sub(m.next))
+	} else {
+		debug.Assert(m.cap < a.cap && a.buf == nil, "arena: invalid mark %v:%v:%d, at %v:%v:%d",
+			m.next, m.end, m.cap, a.next, a.end, a.cap)
+
+		// The arena has grown since the mark, clear the rest of the block of the
+		// mark, the blocks it has grown through, and the used part of the current one.
+		first := 0
+
+		if m.cap > 0 {
+			xunsafe.Clear(m.next.AssertValid(), m.end.Sub(m.next))
+
+			first = bits.TrailingZeros(uint(m.cap)) + 1
+		}
+
+		last := bits.TrailingZeros(uint(a.cap))
+
+		for i := first; i < last; i++ {
+			if a.blocks[i] != nil {
+				xunsafe.Clear(a.blocks[i], 1<<i)
+			}
+		}
+
+		start := a.end.Add(-a.cap)
+
+		xunsafe.Clear(start.AssertValid(), a.next.Sub(start))
+	}
+
+	a.next, a.end, a.cap = m.next, m.end, m.cap
+
+	debug.Assert(m.keep <= len(a.keep), "arena: invalid mark, %d kept values, at %d", m.keep, len(a.keep))
+
+	clear(a.keep[m.keep:])
+	a.keep = a.keep[:m.keep]
+
+	a.Log("release-to", "%v:%v:%d", m.next, m.end, m.cap)
+}
+
+// allocatedAfter returns true if p, allocated in a block of the given capacity,
+// was allocated after the mark.
+//
+// Since a reset, an arena only grows into larger blocks, and allocates upwards
+// in each block.
+func (m Mark) allocatedAfter(p xunsafe.Addr[byte], cap int) bool {
+	return cap > m.cap || cap == m.cap && p >= m.next
+}
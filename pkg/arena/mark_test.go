@@ -0,0 +1,133 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+func TestArena_Mark(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := new(arena.Arena)
+
+		p := arena.New(a, int64(1))
+
+		Convey("When releasing to a mark in the same block", func() {
+			m := a.Mark()
+
+			q := arena.New(a, int64(2))
+			r := arena.New(a, int64(3))
+
+			a.ReleaseTo(m)
+
+			Convey("Then the released memory should be cleared and reused", func() {
+				So(*q, ShouldEqual, 0)
+				So(*r, ShouldEqual, 0)
+
+				s := a.Alloc(8)
+
+				So(unsafe.Pointer(s), ShouldEqual, unsafe.Pointer(q))
+				So(*(*int64)(unsafe.Pointer(s)), ShouldEqual, 0)
+			})
+
+			Convey("And the memory before the mark should be kept", func() {
+				So(*p, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When releasing to a mark after growing the arena", func() {
+			m := a.Mark()
+			c := a.Cap()
+
+			var ps []*int64
+
+			for i := 0; i < 1000; i++ {
+				ps = append(ps, arena.New(a, int64(i+1)))
+			}
+
+			So(a.Cap(), ShouldBeGreaterThan, c)
+
+			a.ReleaseTo(m)
+
+			Convey("Then the arena should be back to the mark", func() {
+				So(a.Cap(), ShouldEqual, c)
+				So(*p, ShouldEqual, 1)
+
+				for _, q := range ps {
+					So(*q, ShouldEqual, 0)
+				}
+			})
+
+			Convey("And growing again should reuse cleared memory", func() {
+				for i := 0; i < 1000; i++ {
+					So(*(*int64)(unsafe.Pointer(a.Alloc(8))), ShouldEqual, 0)
+				}
+			})
+		})
+
+		Convey("When nesting marks", func() {
+			outer := a.Mark()
+
+			q := arena.New(a, int64(2))
+			inner := a.Mark()
+			r := arena.New(a, int64(3))
+
+			a.ReleaseTo(inner)
+
+			So(*q, ShouldEqual, 2)
+			So(*r, ShouldEqual, 0)
+
+			a.ReleaseTo(outer)
+
+			So(*q, ShouldEqual, 0)
+			So(*p, ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given an empty arena", t, func() {
+		a := new(arena.Arena)
+		m := a.Mark()
+
+		Convey("When releasing to its initial mark", func() {
+			q := arena.New(a, int64(1))
+
+			a.ReleaseTo(m)
+
+			Convey("Then it should be empty again", func() {
+				So(a.Cap(), ShouldEqual, 0)
+				So(*q, ShouldEqual, 0)
+				So(*arena.New(a, int64(2)), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given an arena backed by a buffer", t, func() {
+		a := arena.FromBuffer(make([]byte, 64))
+
+		arena.New(a, int64(1))
+
+		m := a.Mark()
+
+		for i := 0; i < 7; i++ {
+			arena.New(a, int64(i))
+		}
+
+		_, err := a.TryAlloc(8)
+		So(err, ShouldEqual, arena.ErrOutOfMemory)
+
+		Convey("When releasing to a mark", func() {
+			a.ReleaseTo(m)
+
+			Convey("Then the memory should be available again", func() {
+				_, err := a.TryAlloc(56)
+
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,70 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+const (
+	// memmoveChunk is the number of bytes copied by Memmove between two pre-emption points.
+	memmoveChunk = 256 << 10
+
+	// memmoveAlign is the alignment of the chunk boundaries in the destination,
+	// a cache line, which is a multiple of the widest vector registers.
+	memmoveAlign = 64
+)
+
+// Memmove copies n bytes from src to dst, which may overlap, like the builtin copy.
+//
+// The runtime copies memory in a single non-preemptible call, so copying a buffer of
+// several megabytes, e.g. when growing a large slice, delays the scheduling of the other
+// goroutines and the stop-the-world phases of the GC. Memmove copies large buffers in
+// chunks of a few hundred kilobytes instead, between which the goroutine can be
+// pre-empted. The chunk boundaries are aligned to a cache line of dst, so that every
+// chunk but the first and last is copied with full-width vector moves.
+//
+// The bytes are copied without write barriers, so dst must not be memory scanned by
+// the GC holding pointers, such as the memory of an arena. The caller must keep the
+// memory of src and dst alive, e.g. their arenas, until it returns.
+func Memmove(dst, src xunsafe.Addr[byte], n int) {
+	if n <= 0 || dst == src {
+		return
+	}
+
+	if n <= memmoveChunk {
+		copy(bytesAt(dst, n), bytesAt(src, n))
+
+		return
+	}
+
+	if dst < src || dst >= src.Add(n) {
+		// Copy forwards, the destination doesn't overlap the rest of the source.
+		for k := memmoveChunk - int(dst)&(memmoveAlign-1); n > 0; k = memmoveChunk {
+			k = min(k, n)
+
+			copy(bytesAt(dst, k), bytesAt(src, k))
+
+			dst, src, n = dst.Add(k), src.Add(k), n-k
+		}
+
+		return
+	}
+
+	// Copy backwards, the destination overlaps the rest of the source.
+	end := dst.Add(n)
+
+	for k := memmoveChunk - int(-end)&(memmoveAlign-1); n > 0; k = memmoveChunk {
+		k = min(k, n)
+		n -= k
+
+		copy(bytesAt(dst.Add(n), k), bytesAt(src.Add(n), k))
+	}
+}
+
+// bytesAt returns the n bytes at p.
+func bytesAt(p xunsafe.Addr[byte], n int) []byte {
+	return unsafe.Slice(p.AssertValid(), n)
+}
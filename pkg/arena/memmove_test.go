@@ -0,0 +1,78 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+func TestMemmove(t *testing.T) {
+	Convey("Given a large buffer", t, func() {
+		const n = 3<<20 + 123
+
+		buf := make([]byte, n+4096)
+		rand.New(rand.NewSource(1)).Read(buf)
+
+		addr := func(i int) xunsafe.Addr[byte] { return xunsafe.AddrOf(&buf[i]) }
+
+		check := func(dst, src, n int) {
+			want := bytes.Clone(buf)
+			copy(want[dst:dst+n], want[src:src+n])
+
+			Memmove(addr(dst), addr(src), n)
+
+			So(bytes.Equal(buf, want), ShouldBeTrue)
+		}
+
+		Convey("When copying to a disjoint destination", func() {
+			dst := make([]byte, n)
+
+			Memmove(xunsafe.AddrOf(&dst[0]), addr(7), n)
+
+			So(bytes.Equal(dst, buf[7:7+n]), ShouldBeTrue)
+		})
+
+		Convey("When copying forwards over an overlapping destination", func() {
+			check(3, 1001, n)
+		})
+
+		Convey("When copying backwards over an overlapping destination", func() {
+			check(1001, 3, n)
+		})
+
+		Convey("When copying small or empty ranges", func() {
+			check(10, 0, 100)
+			check(0, 10, 100)
+			check(5, 5, 100)
+			check(0, 1, 0)
+		})
+	})
+}
+
+func BenchmarkMemmove(b *testing.B) {
+	src := make([]byte, 16<<20)
+	dst := make([]byte, len(src))
+
+	b.Run("copy", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+
+		for i := 0; i < b.N; i++ {
+			copy(dst, src)
+		}
+	})
+
+	b.Run("Memmove", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+
+		for i := 0; i < b.N; i++ {
+			Memmove(xunsafe.AddrOf(&dst[0]), xunsafe.AddrOf(&src[0]), len(src))
+		}
+	})
+}
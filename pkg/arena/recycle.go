@@ -141,7 +141,9 @@ func (a *Recycled) Alloc(size int) *byte {
 
 	if a.free != nil {
 		alignedSize := alignUp(size)
-		log := sizeClassIndex(alignedSize)
+
+		// The free lists hold blocks of at least 1<<log bytes, so round the size class up.
+		log := bits.Len(uint(alignedSize) - 1)
 
 		if p := a.free[log].AssertValid(); p != nil {
 			a.free[log] = xunsafe.Addr[byte](*xunsafe.Cast[uintptr](p))
@@ -160,12 +162,12 @@ func (a *Recycled) Alloc(size int) *byte {
 		// Initialize free slice if needed
 		a.ensureFreeList()
 
-		for n > Align {
+		for n >= Align {
 			log := sizeClassIndex(n)
 
-			a.free[log] = xunsafe.AddrOf(a.next.AssertValid())
+			a.Release(a.next.AssertValid(), 1<<log)
 
-			a.next.Add(1 << log)
+			a.next = a.next.Add(1 << log)
 
 			n -= 1 << log
 		}
@@ -0,0 +1,243 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// RecycledSync is a thread-safe variant of [Recycled].
+//
+// Its per-size-class free lists are lock-free Treiber stacks, so [RecycledSync.Alloc]
+// and [RecycledSync.Release] can be called from multiple goroutines without external
+// locking. Only the fallback path, which bump-allocates fresh memory from the
+// underlying [Arena], takes a mutex.
+//
+// Unlike [Recycled], every request is rounded up to a power-of-two size class,
+// so that any block popped from a free list is large enough for the request,
+// and an allocation is never grown in place, see [RecycledSync.End].
+//
+// # ABA Protection
+//
+// Each stack head packs the address of the top block together with a counter
+// which is bumped on every successful push and pop, in the same way as the Go
+// runtime's lfstack. This assumes that addresses fit in 48 bits on 64-bit
+// platforms, which holds for amd64 and arm64.
+//
+// # Memory Safety
+//
+//   - The first machine word of a released block is used as the "next" link,
+//     and may still be read by a concurrent Alloc that loses the race for it.
+//   - [RecycledSync.Reset] must not be called concurrently with any other method.
+//
+// # Example
+//
+//	a := &arena.RecycledSync{}
+//
+//	var wg sync.WaitGroup
+//	for range 4 {
+//		wg.Add(1)
+//		go func() {
+//			defer wg.Done()
+//
+//			p := arena.New(a, MyStruct{})
+//			arena.Free(a, p)
+//		}()
+//	}
+//	wg.Wait()
+type RecycledSync struct {
+	mu    sync.Mutex
+	arena Arena
+
+	// free holds the per-size-class free lists, indexed by log2 of the block size.
+	free [freeListCapacity]lfstack
+}
+
+var _ AllocatorExt = (*RecycledSync)(nil)
+
+// Alloc allocates size bytes of memory, popping a recycled block of the matching
+// size class when available.
+//
+// Recycled blocks are cleared to zero before being returned.
+//
+// Do not use this method directly, use [New] instead.
+func (a *RecycledSync) Alloc(size int) *byte {
+	if size == 0 {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		return a.arena.Alloc(size)
+	}
+
+	log := syncSizeClassIndex(size)
+
+	if p := a.free[log].pop(); p != nil {
+		xunsafe.Clear(p, 1<<log)
+
+		debug.Log([]any{"%p", a}, "reuse", "%p, %d:%d", p, size, 1<<log)
+
+		return p
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Recycle the trailing capacity of the current chunk before growing.
+	if a.arena.next != 0 && a.arena.next.Add(1<<log) > a.arena.end {
+		n := a.arena.end.Sub(a.arena.next)
+
+		for n >= Align {
+			log := sizeClassIndex(n)
+
+			a.free[log].push(a.arena.next.AssertValid())
+			a.arena.next = a.arena.next.Add(1 << log)
+
+			n -= 1 << log
+		}
+	}
+
+	return a.arena.Alloc(1 << log)
+}
+
+// Release pushes a previously allocated block back onto the free list of its
+// size class.
+//
+// Blocks smaller than [Align] are ignored.
+//
+// Do not use this method directly, use [Free] instead.
+func (a *RecycledSync) Release(p *byte, size int) {
+	if p == nil || size < Align {
+		return
+	}
+
+	log := syncSizeClassIndex(size)
+
+	a.free[log].push(p)
+
+	debug.Log([]any{"%p", a}, "release", "%p, %d:%d", p, size, 1<<log)
+}
+
+// Reset clears all free lists and resets the underlying [Arena].
+//
+// Reset must not be called concurrently with any other method, and any pointer
+// into memory managed by the allocator becomes invalid.
+func (a *RecycledSync) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.free {
+		(*atomic.Uint64)(&a.free[i]).Store(0)
+	}
+
+	a.arena.Reset()
+}
+
+// Next returns zero, see [RecycledSync.End].
+func (a *RecycledSync) Next() xunsafe.Addr[byte] { return 0 }
+
+// End returns zero, so that no allocation is grown in place: the blocks are handed
+// out by size class, and may be allocated concurrently by other goroutines.
+func (a *RecycledSync) End() xunsafe.Addr[byte] { return 0 }
+
+// Cap returns the capacity of the current chunk of the underlying [Arena].
+func (a *RecycledSync) Cap() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.arena.Cap()
+}
+
+// Advance panics unless n is zero, since no allocation is grown in place.
+func (a *RecycledSync) Advance(n int) {
+	if n != 0 {
+		panic("arena: RecycledSync can't grow an allocation in place")
+	}
+}
+
+func (a *RecycledSync) Log(op, format string, args ...any) {
+	debug.Log([]any{"%p", a}, op, format, args...)
+}
+
+// syncSizeClassIndex computes the size-class index (log2) for a size, rounding
+// up to the next power of two no smaller than [Align].
+func syncSizeClassIndex(size int) int {
+	return bits.Len(uint(alignUp(size)) - 1)
+}
+
+const (
+	// addrBits is the number of significant bits in a pointer.
+	addrBits = 32 + 16*(Align/8)
+
+	// alignBits is the number of low bits which are always zero in a block address.
+	alignBits = 2 + Align/8
+
+	// cntBits is the number of bits left for the ABA counter.
+	cntBits = 64 - addrBits + alignBits
+)
+
+// lfstack is the head of a lock-free stack of memory blocks.
+//
+// Each block stores the address of the next one in its first machine word.
+type lfstack atomic.Uint64
+
+func lfstackPack(p uintptr, cnt uint64) uint64 {
+	return uint64(p)<<(64-addrBits) | cnt&(1<<cntBits-1)
+}
+
+func lfstackUnpack(v uint64) *byte {
+	return xunsafe.Addr[byte](v >> cntBits << alignBits).AssertValid()
+}
+
+// lfstackCheck asserts that the address p has been packed into v without loss.
+func lfstackCheck(v uint64, p uintptr) {
+	debug.Assert(uintptr(unsafe.Pointer(lfstackUnpack(v))) == p, "lfstack: address %#x cannot be packed", p)
+}
+
+func (s *lfstack) push(p *byte) {
+	head := (*atomic.Uint64)(s)
+	next := (*uintptr)(unsafe.Pointer(p))
+
+	for {
+		old := head.Load()
+
+		atomic.StoreUintptr(next, uintptr(unsafe.Pointer(lfstackUnpack(old))))
+
+		v := lfstackPack(uintptr(unsafe.Pointer(p)), old+1)
+		lfstackCheck(v, uintptr(unsafe.Pointer(p)))
+
+		if head.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
+
+func (s *lfstack) pop() *byte {
+	head := (*atomic.Uint64)(s)
+
+	for {
+		old := head.Load()
+
+		p := lfstackUnpack(old)
+		if p == nil {
+			return nil
+		}
+
+		// The link may be stale if p has been popped and reused concurrently, in which
+		// case the CAS fails. It is only checked, and used as a pointer, once the CAS
+		// has succeeded.
+		next := atomic.LoadUintptr((*uintptr)(unsafe.Pointer(p)))
+		v := lfstackPack(next, old+1)
+
+		if head.CompareAndSwap(old, v) {
+			lfstackCheck(v, next)
+
+			return p
+		}
+	}
+}
@@ -0,0 +1,128 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/arena"
+)
+
+func TestRecycledSync(t *testing.T) {
+	Convey("Given a RecycledSync arena", t, func() {
+		a := &RecycledSync{}
+
+		Convey("When allocating and releasing a block", func() {
+			p := a.Alloc(24)
+
+			So(p, ShouldNotBeNil)
+			So(uintptr(unsafe.Pointer(p))%uintptr(Align), ShouldEqual, uintptr(0))
+
+			*p = 0xAA
+			a.Release(p, 24)
+
+			Convey("Then a request of the same size class should reuse it cleared", func() {
+				q := a.Alloc(32)
+
+				So(unsafe.Pointer(q), ShouldEqual, unsafe.Pointer(p))
+				So(*q, ShouldEqual, 0)
+			})
+
+			Convey("Then a larger request should not reuse it", func() {
+				q := a.Alloc(64)
+
+				So(unsafe.Pointer(q), ShouldNotEqual, unsafe.Pointer(p))
+			})
+		})
+
+		Convey("When releasing several blocks of the same size class", func() {
+			ptrs := []*byte{a.Alloc(16), a.Alloc(16), a.Alloc(16)}
+
+			for _, p := range ptrs {
+				a.Release(p, 16)
+			}
+
+			Convey("Then they should be reused in LIFO order", func() {
+				So(unsafe.Pointer(a.Alloc(16)), ShouldEqual, unsafe.Pointer(ptrs[2]))
+				So(unsafe.Pointer(a.Alloc(16)), ShouldEqual, unsafe.Pointer(ptrs[1]))
+				So(unsafe.Pointer(a.Alloc(16)), ShouldEqual, unsafe.Pointer(ptrs[0]))
+			})
+		})
+
+		Convey("When using New and Free", func() {
+			v := New(a, int64(42))
+			So(*v, ShouldEqual, 42)
+
+			Free(a, v)
+
+			w := New(a, int64(7))
+			So(unsafe.Pointer(w), ShouldEqual, unsafe.Pointer(v))
+			So(*w, ShouldEqual, 7)
+		})
+
+		Convey("When resetting the arena", func() {
+			p := a.Alloc(16)
+			a.Release(p, 16)
+
+			a.Reset()
+
+			So(a.Alloc(16), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRecycledSync_Concurrent(t *testing.T) {
+	Convey("Given a RecycledSync arena shared by multiple goroutines", t, func() {
+		const (
+			workers = 8
+			rounds  = 1000
+		)
+
+		a := &RecycledSync{}
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			failed int
+		)
+
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+
+			go func(w int) {
+				defer wg.Done()
+
+				var live [3]*int64
+
+				for i := 0; i < rounds; i++ {
+					for j := range live {
+						live[j] = New(a, int64(w*rounds*len(live)+i*len(live)+j))
+					}
+
+					runtime.Gosched()
+
+					for j, p := range live {
+						if *p != int64(w*rounds*len(live)+i*len(live)+j) {
+							mu.Lock()
+							failed++
+							mu.Unlock()
+						}
+
+						Free(a, p)
+					}
+				}
+			}(w)
+		}
+
+		wg.Wait()
+
+		Convey("Then no block should be handed out twice", func() {
+			So(failed, ShouldEqual, 0)
+		})
+	})
+}
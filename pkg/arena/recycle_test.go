@@ -1455,3 +1455,54 @@ func TestRecycledArena_ConcurrentAccessPatterns(t *testing.T) {
 		})
 	})
 }
+
+func TestRecycledArena_SmallerBlocks(t *testing.T) {
+	Convey("Given a Recycled arena with a released block", t, func() {
+		arena := &Recycled{}
+
+		small := arena.Alloc(16)
+		arena.Release(small, 16)
+
+		addr := xunsafe.AddrOf(small)
+
+		Convey("When allocating a larger size of the same size class", func() {
+			p := arena.Alloc(24)
+
+			Convey("Then the smaller block should not be reused", func() {
+				So(xunsafe.AddrOf(p), ShouldNotEqual, addr)
+				So(xunsafe.AddrOf(arena.Alloc(16)), ShouldEqual, addr)
+			})
+		})
+	})
+
+	Convey("Given a Recycled arena with trailing capacity", t, func() {
+		arena := &Recycled{}
+
+		arena.Alloc(1024)
+		arena.Alloc(8) // Grows a larger block.
+
+		Convey("When a larger allocation recycles the trailing capacity", func() {
+			n := int(arena.End() - arena.Next())
+
+			So(n, ShouldBeGreaterThan, Align)
+
+			big := arena.Alloc(n + 1)
+
+			Convey("Then the recycled blocks should not overlap each other", func() {
+				var blocks []xunsafe.Addr[byte]
+
+				for size := Align; size <= n; size *= 2 {
+					blocks = append(blocks, xunsafe.AddrOf(arena.Alloc(size)))
+				}
+
+				blocks = append(blocks, xunsafe.AddrOf(big))
+
+				for i, p := range blocks {
+					for _, q := range blocks[i+1:] {
+						So(p, ShouldNotEqual, q)
+					}
+				}
+			})
+		})
+	})
+}
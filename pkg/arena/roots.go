@@ -0,0 +1,150 @@
+//go:build go1.22
+
+package arena
+
+import (
+	"fmt"
+)
+
+// Roots is a side table holding the heap objects referenced from arena memory.
+//
+// The GC doesn't scan arena memory, so a pointer to a heap object, such as an
+// *os.File, must not be stored in an arena-allocated value: the object could be
+// collected while still in use. Instead, the object is pinned into a Roots table,
+// which is ordinary GC-visible memory, and the arena-allocated value stores the
+// pointer-free [Ref] returned by [Pin].
+//
+// The Roots of an [Arena], see [Arena.Roots], lives as long as the arena itself and
+// is cleared when it is reset. A standalone Roots must be kept alive by its owner for
+// as long as the values referencing it, e.g. in the same struct as the arena.
+//
+// A zero Roots is empty and ready to use. Like an arena, it is not safe for
+// concurrent use.
+//
+// Example:
+//
+//	type Conn struct {
+//	    ID   uint64
+//	    File arena.Ref[*os.File] // Not *os.File.
+//	}
+//
+//	roots := a.Roots()
+//	c := arena.New(a, Conn{ID: 1, File: arena.Pin(roots, f)})
+//
+//	c.File.Get(roots).Write(data)
+//	c.File.Unpin(roots)
+type Roots struct {
+	slots []root
+	free  []uint32
+	n     int
+}
+
+// root is a slot of a [Roots] table.
+type root struct {
+	v   any
+	gen uint32 // Incremented each time the slot is released.
+}
+
+// Ref is a pointer-free reference to a heap object pinned into a [Roots] table,
+// which can be stored in arena memory.
+//
+// The zero Ref references nothing. Once a Ref is unpinned, its copies are released
+// too, and never reference an object pinned later into the same slot.
+type Ref[T any] struct {
+	i   uint32 // Index of the slot plus one.
+	gen uint32 // Generation of the slot when pinned.
+}
+
+// Pin pins a heap object into the table, and returns a reference to it.
+//
+// The object is kept alive until the reference is unpinned, or the table is reset.
+func Pin[T any](r *Roots, v T) Ref[T] {
+	r.n++
+
+	if n := len(r.free); n > 0 {
+		i := r.free[n-1]
+		r.free = r.free[:n-1]
+		r.slots[i].v = v
+
+		return Ref[T]{i + 1, r.slots[i].gen}
+	}
+
+	r.slots = append(r.slots, root{v: v})
+
+	return Ref[T]{uint32(len(r.slots)), 0}
+}
+
+// Len returns the number of pinned objects.
+func (r *Roots) Len() int { return r.n }
+
+// Reset unpins all the objects, invalidating all the references to the table.
+func (r *Roots) Reset() {
+	*r = Roots{}
+}
+
+// IsNil returns true if the reference references nothing.
+func (p Ref[T]) IsNil() bool { return p.i == 0 }
+
+// Get returns the object referenced from the table.
+//
+// It returns the zero value of T if the reference is nil, and panics if it has been
+// unpinned through a copy.
+func (p Ref[T]) Get(r *Roots) (v T) {
+	if p.i == 0 {
+		return
+	}
+
+	return r.pinned(p.i, p.gen).v.(T) //nolint:forcetypeassert
+}
+
+// Set replaces the object referenced from the table.
+//
+// It panics if the reference is nil, or has been unpinned through a copy.
+func (p Ref[T]) Set(r *Roots, v T) {
+	r.pinned(p.i, p.gen).v = v
+}
+
+// Unpin releases the object from the table, so that it can be collected once
+// nothing else references it, and resets the reference to nil.
+//
+// It does nothing if the reference is nil, or has already been unpinned through a copy.
+func (p *Ref[T]) Unpin(r *Roots) {
+	if p.i == 0 {
+		return
+	}
+
+	if s := r.slot(p.i); s.gen == p.gen {
+		*s = root{gen: s.gen + 1}
+		r.free = append(r.free, p.i-1)
+		r.n--
+	}
+
+	*p = Ref[T]{}
+}
+
+// slot returns the slot of the reference i, panicking if it is invalid.
+func (r *Roots) slot(i uint32) *root {
+	if i == 0 || int(i) > len(r.slots) {
+		panic(fmt.Errorf("arena: invalid reference %d to roots of length %d", i, len(r.slots)))
+	}
+
+	return &r.slots[i-1]
+}
+
+// pinned returns the slot of the reference i pinned at generation gen, panicking if
+// it has been released since.
+func (r *Roots) pinned(i, gen uint32) *root {
+	s := r.slot(i)
+	if s.gen != gen {
+		panic(fmt.Errorf("arena: reference %d to a released root", i))
+	}
+
+	return s
+}
+
+// Roots returns the table of heap objects referenced from the memory of the arena.
+//
+// The table lives as long as the arena, and is cleared when the arena is reset.
+func (a *Arena) Roots() *Roots {
+	return &a.roots
+}
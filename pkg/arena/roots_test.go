@@ -0,0 +1,137 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+type file struct {
+	name string
+}
+
+type conn struct {
+	id   uint64
+	file arena.Ref[*file]
+}
+
+func TestRoots(t *testing.T) {
+	Convey("Given an arena and its roots", t, func() {
+		a := new(arena.Arena)
+		roots := a.Roots()
+
+		So(roots.Len(), ShouldEqual, 0)
+
+		Convey("When a heap object is pinned", func() {
+			f := &file{name: "foo"}
+			c := arena.New(a, conn{id: 1, file: arena.Pin(roots, f)})
+
+			So(roots.Len(), ShouldEqual, 1)
+			So(c.file.IsNil(), ShouldBeFalse)
+			So(c.file.Get(roots), ShouldEqual, f)
+
+			Convey("Then it can be replaced", func() {
+				g := &file{name: "bar"}
+				c.file.Set(roots, g)
+
+				So(c.file.Get(roots), ShouldEqual, g)
+				So(roots.Len(), ShouldEqual, 1)
+			})
+
+			Convey("Then it can be unpinned", func() {
+				c.file.Unpin(roots)
+
+				So(c.file.IsNil(), ShouldBeTrue)
+				So(c.file.Get(roots), ShouldBeNil)
+				So(roots.Len(), ShouldEqual, 0)
+
+				c.file.Unpin(roots)
+
+				So(roots.Len(), ShouldEqual, 0)
+
+				Convey("And its slot is reused", func() {
+					r := arena.Pin(roots, "baz")
+
+					So(r.Get(roots), ShouldEqual, "baz")
+					So(roots.Len(), ShouldEqual, 1)
+				})
+			})
+
+			Convey("Then its copies are released when it is unpinned", func() {
+				ref := c.file
+
+				c.file.Unpin(roots)
+
+				So(func() { ref.Get(roots) }, ShouldPanic)
+				So(func() { ref.Set(roots, f) }, ShouldPanic)
+
+				r := arena.Pin(roots, &file{name: "baz"})
+
+				So(func() { ref.Get(roots) }, ShouldPanic)
+
+				ref.Unpin(roots)
+
+				So(ref.IsNil(), ShouldBeTrue)
+				So(roots.Len(), ShouldEqual, 1)
+				So(r.Get(roots).name, ShouldEqual, "baz")
+
+				Convey("And the slot is released only once", func() {
+					r.Unpin(roots)
+
+					So(roots.Len(), ShouldEqual, 0)
+
+					x, y := arena.Pin(roots, &file{name: "x"}), arena.Pin(roots, &file{name: "y"})
+
+					So(x.Get(roots).name, ShouldEqual, "x")
+					So(y.Get(roots).name, ShouldEqual, "y")
+					So(roots.Len(), ShouldEqual, 2)
+				})
+			})
+
+			Convey("Then it is kept alive by the roots", func() {
+				collected := make(chan struct{})
+				g := &file{name: "bar"}
+				runtime.SetFinalizer(g, func(*file) { close(collected) })
+
+				c.file.Set(roots, g)
+				g = nil //nolint:wastedassign
+
+				for i := 0; i < 3; i++ {
+					runtime.GC()
+				}
+
+				alive := true
+				select {
+				case <-collected:
+					alive = false
+				default:
+				}
+
+				So(alive, ShouldBeTrue)
+				So(c.file.Get(roots).name, ShouldEqual, "bar")
+			})
+
+			Convey("Then the roots are cleared when the arena is reset", func() {
+				ref := c.file
+
+				a.Reset()
+
+				So(roots.Len(), ShouldEqual, 0)
+				So(func() { ref.Get(roots) }, ShouldPanic)
+			})
+		})
+
+		Convey("When a nil reference is used", func() {
+			var r arena.Ref[*file]
+
+			So(r.IsNil(), ShouldBeTrue)
+			So(r.Get(roots), ShouldBeNil)
+			So(func() { r.Set(roots, nil) }, ShouldPanic)
+		})
+	})
+}
@@ -0,0 +1,123 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"fmt"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+	"github.com/flier/goutil/pkg/xunsafe/layout"
+)
+
+// CacheLineSize is the cache line size assumed by [MakePadded] callers which
+// want to avoid false sharing between elements.
+const CacheLineSize = 64
+
+// MakeAligned allocates a slice of the given length whose first element is
+// aligned to align bytes, which must be a power of two.
+//
+// The arena only aligns its allocations to [arena.Align], so the slice is carved
+// out of a larger block, and the padding before it is wasted until the arena is reset.
+func MakeAligned[T any](a arena.Allocator, n, align int) Slice[T] {
+	checkAlign(align)
+
+	size := layout.Size[T]()
+	if align <= arena.Align || size == 0 {
+		return Make[T](a, n)
+	}
+
+	bytes := alignedLayout(size*n, align)
+	base := xunsafe.AddrOf(a.Alloc(bytes))
+	p := base.RoundUpTo(align)
+
+	return FromParts(xunsafe.Cast[T](p.AssertValid()), uint32(n), uint32((bytes-int(p-base))/size)).reuse()
+}
+
+// Padded is a fixed-length sequence in an arena whose elements are each padded
+// to a multiple of an alignment, and aligned to it.
+//
+// With the alignment of a cache line, each element lives in its own cache lines,
+// which avoids false sharing when the elements are written concurrently, e.g.
+// per-CPU counters. The price is the memory of the padding.
+//
+// Example:
+//
+//	counters := slice.MakePadded[atomic.Int64](a, runtime.GOMAXPROCS(0), slice.CacheLineSize)
+//
+//	counters.Get(shard).Add(1)
+type Padded[T any] struct {
+	ptr         *T
+	len, stride uint32
+}
+
+// MakePadded allocates a padded sequence of the given length, whose elements are
+// padded and aligned to align bytes, which must be a power of two.
+func MakePadded[T any](a arena.Allocator, n, align int) Padded[T] {
+	checkAlign(align)
+
+	if n < 0 {
+		panic(fmt.Errorf("runtime error: makeslice: len out of range [%d]", n))
+	}
+
+	if align < arena.Align {
+		align = arena.Align
+	}
+
+	stride := layout.RoundUp(layout.Size[T](), align)
+	if stride == 0 {
+		stride = align
+	}
+
+	base := xunsafe.AddrOf(a.Alloc(alignedLayout(stride*n, align)))
+	p := base.RoundUpTo(align)
+
+	xunsafe.Clear(p.AssertValid(), stride*n)
+
+	return Padded[T]{xunsafe.Cast[T](p.AssertValid()), uint32(n), uint32(stride)}
+}
+
+// Len returns the number of elements.
+func (p Padded[T]) Len() int { return int(p.len) }
+
+// Stride returns the distance in bytes between two consecutive elements.
+func (p Padded[T]) Stride() int { return int(p.stride) }
+
+// Get returns the pointer to the n-th element.
+func (p Padded[T]) Get(n int) *T {
+	if uint(n) >= uint(p.len) {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with length %d", n, p.len))
+	}
+
+	return xunsafe.ByteAdd[T](p.ptr, n*int(p.stride))
+}
+
+// Load returns the n-th element.
+func (p Padded[T]) Load(n int) T { return *p.Get(n) }
+
+// Store stores v at the n-th element.
+func (p Padded[T]) Store(n int, v T) { *p.Get(n) = v }
+
+// Release releases the memory of the elements back to the arena.
+//
+// The padding before the first element is not released.
+func (p Padded[T]) Release(a arena.Allocator) {
+	if p.ptr != nil {
+		a.Release(xunsafe.Cast[byte](p.ptr), int(p.len)*int(p.stride))
+	}
+}
+
+// alignedLayout returns the number of bytes to allocate for size bytes aligned to align.
+func alignedLayout(size, align int) int {
+	if align <= arena.Align {
+		return size
+	}
+
+	return size + align - arena.Align
+}
+
+func checkAlign(align int) {
+	if align <= 0 || align&(align-1) != 0 {
+		panic(fmt.Errorf("slice: alignment %d is not a power of two", align))
+	}
+}
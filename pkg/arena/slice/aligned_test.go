@@ -0,0 +1,116 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestMakeAligned(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := &arena.Arena{}
+
+		Convey("When making aligned slices", func() {
+			for _, align := range []int{1, 8, 16, 64, 128, 4096} {
+				a.Alloc(8) // Misalign the next allocation.
+
+				s := slice.MakeAligned[int32](a, 10, align)
+
+				So(s.Len(), ShouldEqual, 10)
+				So(s.Cap(), ShouldBeGreaterThanOrEqualTo, 10)
+				So(uintptr(unsafe.Pointer(s.Ptr()))%uintptr(align), ShouldEqual, uintptr(0))
+
+				for i := range s.Raw() {
+					s.Store(i, int32(i))
+				}
+
+				So(s.Load(9), ShouldEqual, 9)
+			}
+		})
+
+		Convey("When the alignment is not a power of two", func() {
+			So(func() { slice.MakeAligned[int](a, 1, 0) }, ShouldPanic)
+			So(func() { slice.MakeAligned[int](a, 1, 24) }, ShouldPanic)
+		})
+	})
+}
+
+func TestMakePadded(t *testing.T) {
+	Convey("Given padded counters", t, func() {
+		a := &arena.Arena{}
+		a.Alloc(8)
+
+		counters := slice.MakePadded[atomic.Int64](a, 4, slice.CacheLineSize)
+
+		So(counters.Len(), ShouldEqual, 4)
+		So(counters.Stride(), ShouldEqual, slice.CacheLineSize)
+
+		Convey("Then each element should live in its own cache line", func() {
+			for i := 0; i < counters.Len(); i++ {
+				p := uintptr(unsafe.Pointer(counters.Get(i)))
+
+				So(p%slice.CacheLineSize, ShouldEqual, uintptr(0))
+				So(counters.Get(i).Load(), ShouldEqual, int64(0))
+			}
+		})
+
+		Convey("Then they can be updated concurrently", func() {
+			var wg sync.WaitGroup
+
+			for i := 0; i < counters.Len(); i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+
+					for j := 0; j < 1000; j++ {
+						counters.Get(i).Add(1)
+					}
+				}(i)
+			}
+
+			wg.Wait()
+
+			for i := 0; i < counters.Len(); i++ {
+				So(counters.Get(i).Load(), ShouldEqual, int64(1000))
+			}
+		})
+
+		Convey("Then out of range indexes should panic", func() {
+			So(func() { counters.Get(4) }, ShouldPanic)
+			So(func() { counters.Get(-1) }, ShouldPanic)
+		})
+
+		Convey("Then they can be released", func() {
+			r := &arena.Recycled{}
+			p := slice.MakePadded[int](r, 2, 128)
+
+			So(p.Stride(), ShouldEqual, 128)
+
+			p.Store(1, 42)
+			So(p.Load(1), ShouldEqual, 42)
+
+			p.Release(r)
+		})
+	})
+
+	Convey("Given elements larger than the alignment", t, func() {
+		a := &arena.Arena{}
+		p := slice.MakePadded[[9]int64](a, 3, 16)
+
+		So(p.Stride(), ShouldEqual, 80)
+
+		p.Store(2, [9]int64{8: 1})
+
+		So(p.Load(2)[8], ShouldEqual, 1)
+		So(func() { slice.MakePadded[int](a, -1, 8) }, ShouldPanic)
+	})
+}
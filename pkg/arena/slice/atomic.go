@@ -0,0 +1,52 @@
+package slice
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// Word is an integer type which can be accessed atomically, such as an index or
+// an offset into a table.
+type Word interface {
+	~int32 | ~uint32 | ~int64 | ~uint64 | ~int | ~uint | ~uintptr
+}
+
+// AtomicLoad atomically loads the element at the given index, see [sync/atomic].
+//
+// Together with [AtomicStore] and [CompareAndSwap], it allows lock-free arrays to
+// live in arena memory, such as tables of child indexes shared between goroutines.
+// On 32-bit platforms, 64-bit elements must be 8-byte aligned, which the arena only
+// guarantees for the first element of a slice.
+func AtomicLoad[T Word](s Slice[T], i int) T {
+	p := unsafe.Pointer(s.Get(i))
+
+	if unsafe.Sizeof(*s.ptr) == 4 {
+		return T(atomic.LoadUint32((*uint32)(p)))
+	}
+
+	return T(atomic.LoadUint64((*uint64)(p)))
+}
+
+// AtomicStore atomically stores v at the given index, see [AtomicLoad].
+func AtomicStore[T Word](s Slice[T], i int, v T) {
+	p := unsafe.Pointer(s.Get(i))
+
+	if unsafe.Sizeof(v) == 4 {
+		atomic.StoreUint32((*uint32)(p), uint32(v))
+	} else {
+		atomic.StoreUint64((*uint64)(p), uint64(v))
+	}
+}
+
+// CompareAndSwap atomically stores new at the given index if it holds old, see [AtomicLoad].
+//
+// It returns true if the element has been swapped.
+func CompareAndSwap[T Word](s Slice[T], i int, old, new T) bool {
+	p := unsafe.Pointer(s.Get(i))
+
+	if unsafe.Sizeof(old) == 4 {
+		return atomic.CompareAndSwapUint32((*uint32)(p), uint32(old), uint32(new))
+	}
+
+	return atomic.CompareAndSwapUint64((*uint64)(p), uint64(old), uint64(new))
+}
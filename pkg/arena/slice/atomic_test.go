@@ -0,0 +1,57 @@
+package slice_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestAtomic(t *testing.T) {
+	Convey("Given a slice of 32-bit words", t, func() {
+		a := &arena.Arena{}
+		s := slice.Of[int32](a, 1, -2, 3)
+
+		So(slice.AtomicLoad(s, 1), ShouldEqual, -2)
+
+		slice.AtomicStore(s, 1, -5)
+		So(s.Load(1), ShouldEqual, -5)
+
+		So(slice.CompareAndSwap(s, 2, 4, 5), ShouldBeFalse)
+		So(slice.CompareAndSwap(s, 2, 3, -6), ShouldBeTrue)
+		So(s.Raw(), ShouldResemble, []int32{1, -5, -6})
+	})
+
+	Convey("Given a slice of 64-bit words shared by goroutines", t, func() {
+		a := &arena.Arena{}
+		s := slice.Make[uint64](a, 4)
+
+		var wg sync.WaitGroup
+
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for i := 0; i < 1000; i++ {
+					for {
+						v := slice.AtomicLoad(s, i%4)
+						if slice.CompareAndSwap(s, i%4, v, v+1) {
+							break
+						}
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		Convey("Then no increment should be lost", func() {
+			So(s.Raw(), ShouldResemble, []uint64{2000, 2000, 2000, 2000})
+		})
+	})
+}
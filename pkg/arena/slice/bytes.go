@@ -0,0 +1,59 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+var (
+	// ErrSizeMismatch is returned by [CastBytes] when the length of the bytes
+	// isn't a multiple of the size of the element type.
+	ErrSizeMismatch = errors.New("slice: length isn't a multiple of the element size")
+
+	// ErrMisaligned is returned by [CastBytes] when the bytes aren't aligned for
+	// the element type.
+	ErrMisaligned = errors.New("slice: misaligned for the element type")
+)
+
+// BytesOf reinterprets the elements of s as their bytes, without copying, e.g. to
+// write a posting list of uint32 to a network buffer.
+//
+// The bytes are in the native byte order, and share memory with s.
+func BytesOf[T Scalar](s Slice[T]) Slice[byte] {
+	if s.ptr == nil {
+		return Slice[byte]{}
+	}
+
+	size := uint32(unsafe.Sizeof(*s.ptr))
+
+	return Slice[byte]{xunsafe.Cast[byte](s.ptr), s.len * size, s.cap * size}
+}
+
+// CastBytes reinterprets bytes as elements of type T, without copying, which is the
+// reverse of [BytesOf].
+//
+// It returns [ErrSizeMismatch] if the length of s isn't a multiple of the size of T,
+// or [ErrMisaligned] if s isn't aligned for T, e.g. when it was sliced at an odd offset.
+// The spare capacity which doesn't fit a whole element is dropped.
+func CastBytes[T Scalar](s Slice[byte]) (Slice[T], error) {
+	var zero T
+
+	size, align := uint32(unsafe.Sizeof(zero)), uintptr(unsafe.Alignof(zero))
+
+	if s.len%size != 0 {
+		return Slice[T]{}, fmt.Errorf("%w: %d bytes, %d per %T", ErrSizeMismatch, s.len, size, zero)
+	}
+
+	if s.ptr == nil {
+		return Slice[T]{}, nil
+	}
+
+	if uintptr(unsafe.Pointer(s.ptr))%align != 0 {
+		return Slice[T]{}, fmt.Errorf("%w: %p, %d-byte alignment for %T", ErrMisaligned, s.ptr, align, zero)
+	}
+
+	return Slice[T]{xunsafe.Cast[T](s.ptr), s.len / size, s.cap / size}, nil
+}
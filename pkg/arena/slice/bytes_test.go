@@ -0,0 +1,62 @@
+//go:build go1.21
+
+package slice_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestBytesOf(t *testing.T) {
+	Convey("Given a slice of uint32", t, func() {
+		a := new(arena.Arena)
+		s := slice.Of(a, uint32(1), 2, 3)
+
+		Convey("When converting it to bytes", func() {
+			b := slice.BytesOf(s)
+
+			Convey("Then the bytes share memory with the slice", func() {
+				So(b.Len(), ShouldEqual, 12)
+				So(b.Cap(), ShouldEqual, s.Cap()*4)
+				So(binary.NativeEndian.Uint32(b.Raw()[4:]), ShouldEqual, 2)
+
+				s.Store(1, 42)
+				So(binary.NativeEndian.Uint32(b.Raw()[4:]), ShouldEqual, 42)
+			})
+
+			Convey("Then they can be converted back", func() {
+				r, err := slice.CastBytes[uint32](b)
+				So(err, ShouldBeNil)
+				So(r.Raw(), ShouldResemble, s.Raw())
+				So(r.Cap(), ShouldEqual, s.Cap())
+			})
+
+			Convey("Then a partial element is rejected", func() {
+				_, err := slice.CastBytes[uint32](b.Slice(0, 10))
+				So(err, ShouldWrap, slice.ErrSizeMismatch)
+			})
+
+			Convey("Then a misaligned start is rejected", func() {
+				_, err := slice.CastBytes[uint32](b.Slice(2, 10))
+				So(err, ShouldWrap, slice.ErrMisaligned)
+
+				r, err := slice.CastBytes[uint16](b.Slice(2, 10))
+				So(err, ShouldBeNil)
+				So(r.Len(), ShouldEqual, 4)
+			})
+		})
+
+		Convey("When converting an empty slice", func() {
+			So(slice.BytesOf(slice.Slice[uint64]{}).Len(), ShouldEqual, 0)
+
+			r, err := slice.CastBytes[uint64](slice.Slice[byte]{})
+			So(err, ShouldBeNil)
+			So(r.Len(), ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,248 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// Columns is a sequence of structs stored as a struct of arrays in an arena,
+// with the values of each field of T stored contiguously in its own column.
+//
+// Scanning a few fields of wide structs only touches the memory of their columns,
+// instead of loading whole structs into the cache. The fields are found by
+// reflection when the columns are allocated, and accessed by their offsets.
+//
+// Each column is aligned to the alignment of its field, so the fields needing
+// more than [arena.Align] are properly aligned too.
+//
+// The GC doesn't scan the memory of an arena, so the fields of T must not hold
+// pointers, such as strings, slices or maps.
+//
+// Example:
+//
+//	type Order struct {
+//	    ID    uint64
+//	    Price float64
+//	    Note  [200]byte
+//	}
+//
+//	orders := slice.NewColumns[Order](a, 0)
+//	orders = orders.Append(a, Order{ID: 1, Price: 9.99})
+//
+//	var total float64
+//	for _, price := range slice.Column[float64](orders, "Price").Raw() {
+//	    total += price
+//	}
+type Columns[T any] struct {
+	cols     []column
+	len, cap int
+}
+
+// column is the memory of a field of the struct.
+type column struct {
+	name   string
+	typ    reflect.Type
+	offset uintptr
+	size   int
+	align  int
+	base   *byte
+}
+
+// NewColumns allocates the columns of n zero structs.
+//
+// It panics if T is not a struct, or if any of its fields holds pointers.
+func NewColumns[T any](a arena.Allocator, n int) Columns[T] {
+	if n < 0 {
+		panic(fmt.Errorf("runtime error: makeslice: len out of range [%d]", n))
+	}
+
+	c := Columns[T]{cols: columnsOf(reflect.TypeOf((*T)(nil)).Elem()), len: n, cap: n}
+
+	for i := range c.cols {
+		c.cols[i].base = c.cols[i].alloc(a, n)
+	}
+
+	return c
+}
+
+// columnsOf returns the columns of the non-zero-sized fields of the struct type t.
+func columnsOf(t reflect.Type) []column {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("slice: columns of %v, which is not a struct", t))
+	}
+
+	cols := make([]column, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Size() == 0 {
+			continue
+		}
+
+		if hasPointers(f.Type) {
+			panic(fmt.Errorf("slice: column %s of type %v holds pointers, which the GC doesn't scan in an arena", f.Name, f.Type))
+		}
+
+		cols = append(cols, column{
+			name:   f.Name,
+			typ:    f.Type,
+			offset: f.Offset,
+			size:   int(f.Type.Size()),
+			align:  f.Type.Align(),
+		})
+	}
+
+	return cols
+}
+
+// hasPointers returns true if the values of type t hold pointers.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+
+		return false
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer,
+		reflect.Slice, reflect.String, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// alloc allocates the memory of n values of the column.
+func (c *column) alloc(a arena.Allocator, n int) *byte {
+	size := alignedLayout(c.size*n, c.align)
+	if size == 0 {
+		return nil
+	}
+
+	return xunsafe.AddrOf(a.Alloc(size)).RoundUpTo(c.align).AssertValid()
+}
+
+// at returns the pointer to the i-th value of the column.
+func (c *column) at(i int) unsafe.Pointer {
+	return unsafe.Pointer(xunsafe.ByteAdd[byte](c.base, i*c.size))
+}
+
+// copy copies the value of the column at src to dst.
+func (c *column) copy(dst, src unsafe.Pointer) {
+	copy(unsafe.Slice((*byte)(dst), c.size), unsafe.Slice((*byte)(src), c.size))
+}
+
+// Len returns the number of structs.
+func (c Columns[T]) Len() int { return c.len }
+
+// Cap returns the number of structs the columns can hold without being reallocated.
+func (c Columns[T]) Cap() int { return c.cap }
+
+// Names returns the names of the fields stored in columns, in declaration order.
+//
+// The zero-sized fields have no column.
+func (c Columns[T]) Names() []string {
+	names := make([]string, len(c.cols))
+	for i := range c.cols {
+		names[i] = c.cols[i].name
+	}
+
+	return names
+}
+
+// Get reassembles the n-th struct from its columns.
+func (c Columns[T]) Get(n int) (v T) {
+	c.checkIndex(n)
+
+	for i := range c.cols {
+		col := &c.cols[i]
+		col.copy(unsafe.Add(unsafe.Pointer(&v), col.offset), col.at(n))
+	}
+
+	return
+}
+
+// Set scatters v into the n-th position of the columns.
+func (c Columns[T]) Set(n int, v T) {
+	c.checkIndex(n)
+
+	for i := range c.cols {
+		col := &c.cols[i]
+		col.copy(col.at(n), unsafe.Add(unsafe.Pointer(&v), col.offset))
+	}
+}
+
+// Append appends v to the columns, reallocating them on the given arena if necessary.
+func (c Columns[T]) Append(a arena.Allocator, v T) Columns[T] {
+	if c.cols == nil {
+		c = NewColumns[T](a, 0)
+	}
+
+	if c.len == c.cap {
+		c = c.grow(a, max(2*c.cap, 8))
+	}
+
+	c.len++
+	c.Set(c.len-1, v)
+
+	return c
+}
+
+// grow reallocates the columns with the given capacity.
+func (c Columns[T]) grow(a arena.Allocator, n int) Columns[T] {
+	cols := make([]column, len(c.cols))
+	copy(cols, c.cols)
+
+	for i := range cols {
+		col := &cols[i]
+		col.base = col.alloc(a, n)
+
+		if c.len > 0 {
+			arena.Memmove(xunsafe.AddrOf(col.base), xunsafe.AddrOf(c.cols[i].base), c.len*col.size)
+		}
+	}
+
+	c.cols, c.cap = cols, n
+
+	return c
+}
+
+// Column returns the column of the named field as a slice sharing memory with the columns.
+//
+// It panics if T has no such field, or if its type is not F.
+func Column[F, T any](c Columns[T], name string) Slice[F] {
+	for i := range c.cols {
+		col := &c.cols[i]
+		if col.name != name {
+			continue
+		}
+
+		if t := reflect.TypeOf((*F)(nil)).Elem(); t != col.typ {
+			panic(fmt.Errorf("slice: column %s of type %v, not %v", name, col.typ, t))
+		}
+
+		if c.len == 0 {
+			return Slice[F]{}
+		}
+
+		return FromParts((*F)(unsafe.Pointer(col.base)), uint32(c.len), uint32(c.len))
+	}
+
+	panic(fmt.Errorf("slice: no column %s in %T", name, c))
+}
+
+func (c Columns[T]) checkIndex(n int) {
+	if uint(n) >= uint(c.len) {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with length %d", n, c.len))
+	}
+}
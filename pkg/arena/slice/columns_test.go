@@ -0,0 +1,142 @@
+//go:build go1.23
+
+package slice_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+type order struct {
+	ID    int64
+	Flag  bool
+	_     struct{}
+	Price float64
+	Code  [3]byte
+	Note  [8]byte
+}
+
+func note(s string) (n [8]byte) {
+	copy(n[:], s)
+
+	return
+}
+
+func TestColumns(t *testing.T) {
+	Convey("Given columns of structs", t, func() {
+		a := &arena.Arena{}
+		c := slice.NewColumns[order](a, 2)
+
+		So(c.Len(), ShouldEqual, 2)
+		So(c.Names(), ShouldResemble, []string{"ID", "Flag", "Price", "Code", "Note"})
+		So(c.Get(1), ShouldResemble, order{})
+
+		Convey("When setting and appending structs", func() {
+			c.Set(0, order{ID: 1, Price: 1.5, Code: [3]byte{'a', 'b', 'c'}, Note: note("first")})
+			c.Set(1, order{ID: 2, Flag: true, Price: 2.5})
+
+			for i := 3; i <= 20; i++ {
+				c = c.Append(a, order{ID: int64(i), Price: float64(i) + 0.5})
+			}
+
+			Convey("Then the structs are reassembled from the columns", func() {
+				So(c.Len(), ShouldEqual, 20)
+				So(c.Cap(), ShouldBeGreaterThanOrEqualTo, 20)
+				So(c.Get(0), ShouldResemble, order{ID: 1, Price: 1.5, Code: [3]byte{'a', 'b', 'c'}, Note: note("first")})
+				So(c.Get(1), ShouldResemble, order{ID: 2, Flag: true, Price: 2.5})
+				So(c.Get(19), ShouldResemble, order{ID: 20, Price: 20.5})
+
+				n := 0
+				for i, o := range c.All() {
+					So(o.ID, ShouldEqual, i+1)
+
+					n++
+				}
+
+				So(n, ShouldEqual, 20)
+			})
+
+			Convey("Then a column can be scanned on its own", func() {
+				ids := slice.Column[int64](c, "ID")
+
+				So(ids.Len(), ShouldEqual, 20)
+				So(ids.Load(19), ShouldEqual, 20)
+
+				var total float64
+				for price := range slice.ColumnValues[float64](c, "Price") {
+					total += price
+				}
+
+				So(total, ShouldEqual, 220)
+				So(slices.Collect(slice.ColumnValues[[8]byte](c, "Note"))[:2], ShouldResemble, [][8]byte{note("first"), {}})
+			})
+
+			Convey("Then a column shares memory with the columns", func() {
+				slice.Column[float64](c, "Price").Store(1, 42)
+
+				So(c.Get(1).Price, ShouldEqual, 42)
+			})
+		})
+
+		Convey("Then invalid accesses panic", func() {
+			So(func() { c.Get(2) }, ShouldPanic)
+			So(func() { slice.Column[int64](c, "Missing") }, ShouldPanic)
+			So(func() { slice.Column[int32](c, "ID") }, ShouldPanic)
+			So(func() { slice.NewColumns[int](a, 1) }, ShouldPanic)
+			So(func() { slice.NewColumns[struct{ Name string }](a, 1) }, ShouldPanic)
+			So(func() { slice.NewColumns[struct{ IDs [2]*int64 }](a, 1) }, ShouldPanic)
+		})
+	})
+
+	Convey("Given zero columns", t, func() {
+		a := &arena.Arena{}
+
+		var c slice.Columns[order]
+
+		So(c.Len(), ShouldEqual, 0)
+		So(slice.Column[int64](slice.NewColumns[order](a, 0), "ID").Len(), ShouldEqual, 0)
+
+		Convey("When appending to them", func() {
+			c = c.Append(a, order{ID: 7})
+
+			So(c.Len(), ShouldEqual, 1)
+			So(c.Get(0).ID, ShouldEqual, 7)
+		})
+	})
+}
+
+func BenchmarkColumns(b *testing.B) {
+	type wide struct {
+		Price float64
+		Pad   [120]byte
+	}
+
+	const n = 1 << 16
+
+	a := &arena.Arena{}
+	rows := slice.Make[wide](a, n)
+	cols := slice.NewColumns[wide](a, n)
+
+	b.Run("Rows", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var total float64
+			for _, v := range rows.Raw() {
+				total += v.Price
+			}
+		}
+	})
+
+	b.Run("Column", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var total float64
+			for _, v := range slice.Column[float64](cols, "Price").Raw() {
+				total += v
+			}
+		}
+	})
+}
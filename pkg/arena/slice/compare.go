@@ -0,0 +1,83 @@
+//go:build go1.21
+
+package slice
+
+import "cmp"
+
+// EqualFunc returns true if a and b have the same length and eq returns true
+// for every pair of elements, compared in increasing index order.
+//
+// Unlike [Equal], the element types don't need to be comparable, and a nil
+// slice is equal to an empty one, like [slices.EqualFunc].
+func EqualFunc[T, U any](a Slice[T], b Slice[U], eq func(T, U) bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		if !eq(a.unsafeLoad(i), b.unsafeLoad(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Compare compares the elements of a and b lexicographically, like [slices.Compare].
+//
+// It returns 0 if a == b, -1 if a < b, and +1 if a > b. If one slice is a prefix
+// of the other, the shorter slice is less.
+func Compare[T cmp.Ordered](a, b Slice[T]) int {
+	return CompareFunc(a, b, cmp.Compare[T])
+}
+
+// CompareFunc compares the elements of a and b lexicographically with a custom
+// comparison function, like [slices.CompareFunc].
+//
+// The result is the first non-zero result of cmp; if cmp returns 0 for all pairs,
+// the shorter slice is less.
+func CompareFunc[T, U any](a Slice[T], b Slice[U], cmp func(T, U) int) int {
+	n := min(a.Len(), b.Len())
+
+	for i := 0; i < n; i++ {
+		if c := cmp(a.unsafeLoad(i), b.unsafeLoad(i)); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case a.Len() < b.Len():
+		return -1
+	case a.Len() > b.Len():
+		return +1
+	default:
+		return 0
+	}
+}
+
+// ElementsMatch returns true if a and b contain the same elements regardless of
+// their order, with each element occurring the same number of times in both.
+//
+// It mirrors testify's ElementsMatch, e.g. [1, 1, 2] matches [2, 1, 1] but not [1, 2, 2].
+func ElementsMatch[T comparable](a, b Slice[T]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	counts := make(map[T]int, a.Len())
+
+	for i := 0; i < a.Len(); i++ {
+		counts[a.unsafeLoad(i)]++
+	}
+
+	for i := 0; i < b.Len(); i++ {
+		v := b.unsafeLoad(i)
+		if counts[v] == 0 {
+			return false
+		}
+
+		counts[v]--
+	}
+
+	return true
+}
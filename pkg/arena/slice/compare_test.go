@@ -0,0 +1,69 @@
+//go:build go1.21
+
+package slice_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestEqualFunc(t *testing.T) {
+	Convey("Given slices of different element types", t, func() {
+		a := &arena.Arena{}
+		words := slice.Of(a, "one", "two", "three")
+		lengths := slice.Of(a, 3, 3, 5)
+
+		byLen := func(s string, n int) bool { return len(s) == n }
+
+		So(slice.EqualFunc(words, lengths, byLen), ShouldBeTrue)
+		So(slice.EqualFunc(words, slice.Of(a, 3, 3, 4), byLen), ShouldBeFalse)
+		So(slice.EqualFunc(words, slice.Of(a, 3, 3), byLen), ShouldBeFalse)
+
+		Convey("When comparing empty slices", func() {
+			So(slice.EqualFunc(slice.Slice[string]{}, slice.Make[int](a, 0), byLen), ShouldBeTrue)
+		})
+
+		Convey("When comparing case-insensitively", func() {
+			So(slice.EqualFunc(words, slice.Of(a, "ONE", "Two", "three"), strings.EqualFold), ShouldBeTrue)
+		})
+	})
+}
+
+func TestCompare(t *testing.T) {
+	Convey("Given ordered slices", t, func() {
+		a := &arena.Arena{}
+
+		So(slice.Compare(slice.Of(a, 1, 2, 3), slice.Of(a, 1, 2, 3)), ShouldEqual, 0)
+		So(slice.Compare(slice.Of(a, 1, 2, 3), slice.Of(a, 1, 3)), ShouldEqual, -1)
+		So(slice.Compare(slice.Of(a, 1, 3), slice.Of(a, 1, 2, 3)), ShouldEqual, 1)
+		So(slice.Compare(slice.Of(a, 1, 2), slice.Of(a, 1, 2, 3)), ShouldEqual, -1)
+		So(slice.Compare(slice.Of(a, 1, 2, 3), slice.Of(a, 1, 2)), ShouldEqual, 1)
+		So(slice.Compare(slice.Slice[int]{}, slice.Make[int](a, 0)), ShouldEqual, 0)
+	})
+
+	Convey("Given a custom comparator", t, func() {
+		a := &arena.Arena{}
+		byLen := func(s string, n int) int { return len(s) - n }
+
+		So(slice.CompareFunc(slice.Of(a, "a", "bb"), slice.Of(a, 1, 2), byLen), ShouldEqual, 0)
+		So(slice.CompareFunc(slice.Of(a, "a", "bb"), slice.Of(a, 1, 3), byLen), ShouldBeLessThan, 0)
+		So(slice.CompareFunc(slice.Of(a, "abc"), slice.Of(a, 1, 2), byLen), ShouldBeGreaterThan, 0)
+	})
+}
+
+func TestElementsMatch(t *testing.T) {
+	Convey("Given slices with the same elements", t, func() {
+		a := &arena.Arena{}
+
+		So(slice.ElementsMatch(slice.Of(a, 1, 1, 2), slice.Of(a, 2, 1, 1)), ShouldBeTrue)
+		So(slice.ElementsMatch(slice.Of(a, 1, 1, 2), slice.Of(a, 1, 2, 2)), ShouldBeFalse)
+		So(slice.ElementsMatch(slice.Of(a, 1, 2), slice.Of(a, 1, 2, 2)), ShouldBeFalse)
+		So(slice.ElementsMatch(slice.Of(a, "x", "y"), slice.Of(a, "y", "x")), ShouldBeTrue)
+		So(slice.ElementsMatch(slice.Slice[int]{}, slice.Make[int](a, 0)), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,182 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"fmt"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// GapBuffer is an arena-backed sequence which keeps a gap of unused capacity at
+// the position of the last edit.
+//
+// Inserting or deleting at the gap is O(1) amortized, and moving the gap only
+// copies the elements between the old and the new position, so a run of edits
+// around a cursor, such as typing in an editor, doesn't shift the whole tail of
+// the sequence like inserting into a [Slice] does.
+//
+// A zero GapBuffer is empty and ready to use.
+//
+// Example:
+//
+//	var b slice.GapBuffer[byte]
+//
+//	b.Insert(a, 0, []byte("hello world")...)
+//	b.Delete(5, 6)
+//	b.Insert(a, 5, []byte(", gopher")...)
+//
+//	string(b.AppendTo(nil)) // hello, gopher
+type GapBuffer[T any] struct {
+	buf        Slice[T] // buf.Len() == buf.Cap()
+	start, end int      // The gap is buf[start:end].
+}
+
+// NewGapBuffer returns an empty gap buffer with room for at least n elements.
+func NewGapBuffer[T any](a arena.Allocator, n int) GapBuffer[T] {
+	buf := Make[T](a, n)
+	buf = buf.SetLen(buf.Cap())
+
+	return GapBuffer[T]{buf: buf, end: buf.Len()}
+}
+
+// Len returns the number of elements in the buffer.
+func (b *GapBuffer[T]) Len() int { return b.buf.Len() - b.gap() }
+
+// Cap returns the number of elements the buffer can hold without growing.
+func (b *GapBuffer[T]) Cap() int { return b.buf.Len() }
+
+// Empty returns true if the buffer has no elements.
+func (b *GapBuffer[T]) Empty() bool { return b.Len() == 0 }
+
+// Cursor returns the position of the gap.
+func (b *GapBuffer[T]) Cursor() int { return b.start }
+
+func (b *GapBuffer[T]) gap() int { return b.end - b.start }
+
+// index maps a logical position to an index into the underlying buffer.
+func (b *GapBuffer[T]) index(n int) int {
+	if n < 0 || n >= b.Len() {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with length %d", n, b.Len()))
+	}
+
+	if n < b.start {
+		return n
+	}
+
+	return n + b.gap()
+}
+
+// Get returns the pointer to the element at the given position.
+//
+// The pointer is only valid until the next edit of the buffer.
+func (b *GapBuffer[T]) Get(n int) *T { return b.buf.unsafeGet(b.index(n)) }
+
+// Load loads the element at the given position.
+func (b *GapBuffer[T]) Load(n int) T { return b.buf.unsafeLoad(b.index(n)) }
+
+// Store stores a value at the given position.
+func (b *GapBuffer[T]) Store(n int, v T) { *b.buf.unsafeGet(b.index(n)) = v }
+
+// Parts returns the elements before and after the gap.
+//
+// The returned slices share memory with the buffer and are only valid until the
+// next edit of the buffer.
+func (b *GapBuffer[T]) Parts() (before, after Slice[T]) {
+	return b.buf.Slice(0, b.start), b.buf.Slice(b.end, b.buf.Len())
+}
+
+// AppendTo appends the elements of the buffer to dst in order.
+func (b *GapBuffer[T]) AppendTo(dst []T) []T {
+	before, after := b.Parts()
+
+	return append(append(dst, before.Raw()...), after.Raw()...)
+}
+
+// Clone allocates a contiguous slice holding the elements of the buffer.
+func (b *GapBuffer[T]) Clone(a arena.Allocator) Slice[T] {
+	s := Make[T](a, b.Len())
+	b.AppendTo(s.Raw()[:0])
+
+	return s
+}
+
+// MoveTo moves the gap to the given position, copying only the elements in between.
+func (b *GapBuffer[T]) MoveTo(n int) {
+	if n < 0 || n > b.Len() {
+		panic(fmt.Errorf("runtime error: cursor out of range [%d] with length %d", n, b.Len()))
+	}
+
+	raw := b.buf.Raw()
+
+	switch {
+	case n < b.start:
+		// Shift buf[n:start] to the end of the gap.
+		copy(raw[b.end-(b.start-n):b.end], raw[n:b.start])
+		b.end -= b.start - n
+		b.start = n
+	case n > b.start:
+		// Shift buf[end:end+(n-start)] to the start of the gap.
+		copy(raw[b.start:n], raw[b.end:b.end+(n-b.start)])
+		b.end += n - b.start
+		b.start = n
+	}
+}
+
+// Insert inserts the values at the given position, growing the buffer on the
+// given arena if the gap is too small.
+func (b *GapBuffer[T]) Insert(a arena.Allocator, n int, values ...T) {
+	b.MoveTo(n)
+
+	if b.gap() < len(values) {
+		b.grow(a, len(values))
+	}
+
+	copy(b.buf.Raw()[b.start:], values)
+	b.start += len(values)
+}
+
+// Delete removes count elements starting at the given position.
+func (b *GapBuffer[T]) Delete(n, count int) {
+	if count < 0 || n+count > b.Len() {
+		panic(fmt.Errorf("runtime error: slice bounds out of range [%d:%d] with length %d", n, n+count, b.Len()))
+	}
+
+	b.MoveTo(n)
+
+	b.end += count
+}
+
+// Reset removes all elements, keeping the capacity.
+func (b *GapBuffer[T]) Reset() {
+	b.start, b.end = 0, b.buf.Len()
+}
+
+// Release releases the memory of the buffer back to the arena.
+func (b *GapBuffer[T]) Release(a arena.Allocator) {
+	if b.buf.Cap() > 0 {
+		b.buf.Release(a)
+	}
+
+	*b = GapBuffer[T]{}
+}
+
+// grow reallocates the buffer so that the gap has room for at least n elements.
+func (b *GapBuffer[T]) grow(a arena.Allocator, n int) {
+	old := b.buf
+
+	buf := Make[T](a, max(2*old.Len(), b.Len()+n, 16))
+	buf = buf.SetLen(buf.Cap())
+
+	tail := old.Len() - b.end
+	end := buf.Len() - tail
+
+	if old.Len() > 0 {
+		copy(buf.Raw(), old.Raw()[:b.start])
+		copy(buf.Raw()[end:], old.Raw()[b.end:])
+
+		old.Release(a)
+	}
+
+	b.buf, b.end = buf, end
+}
@@ -0,0 +1,109 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestGapBuffer(t *testing.T) {
+	Convey("Given an empty gap buffer", t, func() {
+		a := &arena.Arena{}
+		var b slice.GapBuffer[byte]
+
+		So(b.Len(), ShouldEqual, 0)
+		So(b.Empty(), ShouldBeTrue)
+		So(b.AppendTo(nil), ShouldBeEmpty)
+
+		Convey("When inserting and deleting in the middle", func() {
+			b.Insert(a, 0, []byte("hello world")...)
+			b.Delete(5, 6)
+			b.Insert(a, 5, []byte(", gopher")...)
+
+			So(string(b.AppendTo(nil)), ShouldEqual, "hello, gopher")
+			So(b.Len(), ShouldEqual, 13)
+			So(b.Cursor(), ShouldEqual, 13)
+
+			Convey("Then the elements should be accessible by position", func() {
+				So(b.Load(0), ShouldEqual, 'h')
+				So(b.Load(5), ShouldEqual, ',')
+				So(*b.Get(12), ShouldEqual, 'r')
+
+				b.MoveTo(6)
+				So(b.Load(5), ShouldEqual, ',')
+				So(b.Load(6), ShouldEqual, ' ')
+
+				b.Store(0, 'H')
+				So(string(b.AppendTo(nil)), ShouldEqual, "Hello, gopher")
+			})
+
+			Convey("Then the parts should surround the gap", func() {
+				b.MoveTo(5)
+
+				before, after := b.Parts()
+				So(string(before.Raw()), ShouldEqual, "hello")
+				So(string(after.Raw()), ShouldEqual, ", gopher")
+
+				So(string(b.Clone(a).Raw()), ShouldEqual, "hello, gopher")
+			})
+
+			Convey("Then out of range positions should panic", func() {
+				So(func() { b.Load(13) }, ShouldPanic)
+				So(func() { b.MoveTo(14) }, ShouldPanic)
+				So(func() { b.Delete(10, 4) }, ShouldPanic)
+			})
+
+			Convey("Then Reset should remove all elements", func() {
+				b.Reset()
+
+				So(b.Len(), ShouldEqual, 0)
+				So(b.Cap(), ShouldBeGreaterThan, 0)
+			})
+		})
+
+		Convey("When editing randomly", func() {
+			var want []byte
+
+			r := rand.New(rand.NewSource(42))
+
+			for i := 0; i < 1000; i++ {
+				n := r.Intn(len(want) + 1)
+
+				if len(want) > 0 && r.Intn(3) == 0 {
+					count := r.Intn(len(want) - n + 1)
+
+					b.Delete(n, count)
+					want = append(want[:n], want[n+count:]...)
+				} else {
+					v := []byte{byte(i), byte(i >> 8)}
+
+					b.Insert(a, n, v...)
+					want = append(want[:n], append(v, want[n:]...)...)
+				}
+			}
+
+			Convey("Then the buffer should match the reference", func() {
+				So(b.Len(), ShouldEqual, len(want))
+				So(b.AppendTo(nil), ShouldResemble, want)
+			})
+		})
+	})
+
+	Convey("Given a pre-sized gap buffer", t, func() {
+		a := &arena.Arena{}
+		b := slice.NewGapBuffer[int](a, 100)
+
+		So(b.Cap(), ShouldBeGreaterThanOrEqualTo, 100)
+
+		b.Insert(a, 0, 1, 2, 3)
+		b.Insert(a, 1, 4)
+
+		So(b.AppendTo(nil), ShouldResemble, []int{1, 4, 2, 3})
+	})
+}
@@ -0,0 +1,107 @@
+package slice
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"unsafe"
+)
+
+// Scalar is a fixed-size type without padding, whose values are fully described by their bytes.
+type Scalar interface {
+	~bool |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// Hash returns a fast non-cryptographic hash of the bytes of s, computed in place
+// over the arena memory.
+//
+// The hash is a variant of wyhash, it is stable across processes and platforms for
+// the same seed, so it can be used for content-addressed deduplication, but it must
+// not be used where an attacker controls the input.
+func Hash(s Slice[byte], seed uint64) uint64 {
+	return wyhash(s.Raw(), seed)
+}
+
+// Fingerprint returns the hash of the bytes of the elements of s, like [Hash].
+//
+// Floating-point values are hashed by their bits, so 0.0 and -0.0 don't collide
+// while two NaNs with the same bits do. The byte order of the elements is the
+// native one, so fingerprints of multi-byte types differ across endianness.
+func Fingerprint[T Scalar](s Slice[T], seed uint64) uint64 {
+	if s.Len() == 0 {
+		return wyhash(nil, seed)
+	}
+
+	var zero T
+
+	return wyhash(unsafe.Slice((*byte)(unsafe.Pointer(s.Ptr())), s.Len()*int(unsafe.Sizeof(zero))), seed)
+}
+
+const (
+	wyp0 = 0xa0761d6478bd642f
+	wyp1 = 0xe7037ed1a0b428db
+	wyp2 = 0x8ebc6af09c88c6e3
+	wyp3 = 0x589965cc75374cc3
+	wyp4 = 0x1d8e4e27c47d124f
+)
+
+func wymix(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+
+	return hi ^ lo
+}
+
+func wyr4(b []byte) uint64 { return uint64(binary.LittleEndian.Uint32(b)) }
+func wyr8(b []byte) uint64 { return binary.LittleEndian.Uint64(b) }
+
+func wyhash(p []byte, seed uint64) uint64 {
+	var a, b uint64
+
+	n := len(p)
+	seed ^= wyp0
+
+	switch {
+	case n == 0:
+		return seed
+	case n < 4:
+		a = uint64(p[0]) | uint64(p[n>>1])<<8 | uint64(p[n-1])<<16
+	case n == 4:
+		a = wyr4(p)
+		b = a
+	case n < 8:
+		a = wyr4(p)
+		b = wyr4(p[n-4:])
+	case n == 8:
+		a = wyr8(p)
+		b = a
+	case n <= 16:
+		a = wyr8(p)
+		b = wyr8(p[n-8:])
+	default:
+		q := p
+
+		if len(q) > 48 {
+			seed1, seed2 := seed, seed
+
+			for ; len(q) > 48; q = q[48:] {
+				seed = wymix(wyr8(q)^wyp1, wyr8(q[8:])^seed)
+				seed1 = wymix(wyr8(q[16:])^wyp2, wyr8(q[24:])^seed1)
+				seed2 = wymix(wyr8(q[32:])^wyp3, wyr8(q[40:])^seed2)
+			}
+
+			seed ^= seed1 ^ seed2
+		}
+
+		for ; len(q) > 16; q = q[16:] {
+			seed = wymix(wyr8(q)^wyp1, wyr8(q[8:])^seed)
+		}
+
+		// The last 16 bytes may overlap with the bytes already mixed.
+		a = wyr8(p[n-16:])
+		b = wyr8(p[n-8:])
+	}
+
+	return wymix(wyp4^uint64(n), wymix(a^wyp1, b^seed))
+}
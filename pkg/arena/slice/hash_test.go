@@ -0,0 +1,87 @@
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestHash(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := new(arena.Arena)
+
+		Convey("The hash depends on the content and the seed only", func() {
+			s := slice.FromString(a, "hello world")
+
+			So(slice.Hash(s, 0), ShouldEqual, slice.Hash(slice.WrapString("hello world"), 0))
+			So(slice.Hash(s, 0), ShouldNotEqual, slice.Hash(s, 1))
+			So(slice.Hash(s, 0), ShouldNotEqual, slice.Hash(slice.WrapString("hello worle"), 0))
+		})
+
+		Convey("Every length hashes differently", func() {
+			buf := make([]byte, 256)
+			for i := range buf {
+				buf[i] = byte(i * 7)
+			}
+
+			seen := make(map[uint64]int)
+
+			for n := 0; n <= len(buf); n++ {
+				h := slice.Hash(slice.FromBytes(a, buf[:n]), 42)
+
+				_, dup := seen[h]
+				So(dup, ShouldBeFalse)
+
+				seen[h] = n
+			}
+		})
+
+		Convey("Every byte is hashed", func() {
+			buf := make([]byte, 100)
+			h := slice.Hash(slice.Wrap(buf), 0)
+
+			for i := range buf {
+				buf[i] ^= 1
+				So(slice.Hash(slice.Wrap(buf), 0), ShouldNotEqual, h)
+				buf[i] ^= 1
+			}
+		})
+
+		Convey("The hash is stable", func() {
+			So(slice.Hash(slice.Slice[byte]{}, 0), ShouldEqual, uint64(0xa0761d6478bd642f))
+			So(slice.Hash(slice.WrapString("a"), 0), ShouldEqual, uint64(0x740640eb877718be))
+			So(slice.Hash(slice.WrapString("hello world"), 0), ShouldEqual, uint64(0x186078f24b86cf2f))
+			So(slice.Hash(slice.WrapString("0123456789abcdef0123456789abcdef0123456789abcdef0123456789"), 0),
+				ShouldEqual, uint64(0x1adf85d4040b7b21))
+		})
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := new(arena.Arena)
+
+		Convey("Equal elements have the same fingerprint", func() {
+			x := slice.Of(a, uint32(1), 2, 3)
+			y := slice.Of(a, uint32(1), 2, 3)
+			z := slice.Of(a, uint32(1), 2, 4)
+
+			So(slice.Fingerprint(x, 0), ShouldEqual, slice.Fingerprint(y, 0))
+			So(slice.Fingerprint(x, 0), ShouldNotEqual, slice.Fingerprint(z, 0))
+			So(slice.Fingerprint(x.Slice(0, 2), 0), ShouldNotEqual, slice.Fingerprint(x, 0))
+		})
+
+		Convey("An empty slice hashes like no bytes", func() {
+			So(slice.Fingerprint(slice.Slice[float64]{}, 7), ShouldEqual, slice.Hash(slice.Slice[byte]{}, 7))
+		})
+
+		Convey("Bytes fingerprint like their hash", func() {
+			s := slice.FromString(a, "hello")
+
+			So(slice.Fingerprint(s, 3), ShouldEqual, slice.Hash(s, 3))
+		})
+	})
+}
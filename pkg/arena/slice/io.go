@@ -0,0 +1,329 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"errors"
+	"io"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// MinRead is the minimum free capacity [Buffer.ReadFrom] passes to a Read call.
+const MinRead = 512
+
+// Buffer adapts a byte slice to the io interfaces, appending the written bytes on
+// an arena, so that payloads can be read directly into arena memory without an
+// intermediate heap buffer.
+//
+// Like [bytes.Buffer], the bytes written to it are consumed by [Buffer.Read] and
+// [Buffer.WriteTo].
+//
+// Example:
+//
+//	b := slice.NewBuffer(a, slice.Slice[byte]{})
+//
+//	if _, err := b.ReadFrom(conn); err != nil {
+//	    return err
+//	}
+//
+//	payload := b.Bytes()
+type Buffer struct {
+	a   arena.AllocatorExt
+	buf Slice[byte]
+	off int // Read at buf[off:], written at buf[len(buf):].
+}
+
+var (
+	_ io.Reader       = (*Buffer)(nil)
+	_ io.Writer       = (*Buffer)(nil)
+	_ io.StringWriter = (*Buffer)(nil)
+	_ io.ByteWriter   = (*Buffer)(nil)
+	_ io.ReaderFrom   = (*Buffer)(nil)
+	_ io.WriterTo     = (*Buffer)(nil)
+)
+
+// NewBuffer returns a buffer which appends to s on the given arena.
+func NewBuffer(a arena.AllocatorExt, s Slice[byte]) *Buffer {
+	return &Buffer{a: a, buf: s}
+}
+
+// Bytes returns the unread bytes of the buffer.
+//
+// The returned slice shares memory with the buffer, and is only valid until the
+// next write to the buffer.
+func (b *Buffer) Bytes() Slice[byte] {
+	if b.off == b.buf.Len() {
+		return Slice[byte]{}
+	}
+
+	return FromParts(b.buf.unsafeGet(b.off), uint32(b.buf.Len()-b.off), uint32(b.buf.Cap()-b.off))
+}
+
+// Len returns the number of unread bytes of the buffer.
+func (b *Buffer) Len() int { return b.buf.Len() - b.off }
+
+// Reset empties the buffer, keeping its capacity for future writes.
+func (b *Buffer) Reset() {
+	b.buf = b.buf.SetLen(0)
+	b.off = 0
+}
+
+// Write appends p to the buffer, growing it on the arena if necessary.
+//
+// It always returns len(p) and a nil error.
+func (b *Buffer) Write(p []byte) (n int, err error) {
+	b.buf = b.buf.Append(b.a, p...)
+
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer, growing it on the arena if necessary.
+//
+// It always returns len(s) and a nil error.
+func (b *Buffer) WriteString(s string) (n int, err error) {
+	b.buf = AppendString(b.a, b.buf, s)
+
+	return len(s), nil
+}
+
+// WriteByte appends c to the buffer, growing it on the arena if necessary.
+//
+// It always returns a nil error.
+func (b *Buffer) WriteByte(c byte) error {
+	b.buf = b.buf.AppendOne(b.a, c)
+
+	return nil
+}
+
+// Read reads the next len(p) bytes from the buffer, or until the buffer is drained.
+//
+// It returns io.EOF if the buffer has no unread bytes and len(p) is not zero.
+func (b *Buffer) Read(p []byte) (n int, err error) {
+	if b.Len() == 0 {
+		b.Reset()
+
+		if len(p) == 0 {
+			return 0, nil
+		}
+
+		return 0, io.EOF
+	}
+
+	n = copy(p, b.buf.Raw()[b.off:])
+	b.off += n
+
+	return n, nil
+}
+
+// ReadFrom reads from r until EOF, appending the data to the buffer, which grows on
+// the arena as needed.
+//
+// It returns the number of bytes read, and any error except io.EOF encountered
+// during the read.
+func (b *Buffer) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		if b.buf.Cap()-b.buf.Len() < MinRead {
+			b.buf = b.buf.Grow(b.a, max(MinRead, b.buf.Cap()))
+		}
+
+		m, err := r.Read(b.buf.Rest())
+		if m < 0 {
+			panic(errors.New("slice: reader returned negative count from Read"))
+		}
+
+		b.buf.len += uint32(m)
+		n += int64(m)
+
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+	}
+}
+
+// WriteTo writes the unread bytes of the buffer to w, until the buffer is drained
+// or an error occurs.
+//
+// It returns the number of bytes written, and any error encountered during the write.
+func (b *Buffer) WriteTo(w io.Writer) (n int64, err error) {
+	if b.Len() == 0 {
+		return 0, nil
+	}
+
+	m, err := w.Write(b.buf.Raw()[b.off:])
+	if m > b.Len() {
+		panic(errors.New("slice: invalid Write count"))
+	}
+
+	b.off += m
+	n = int64(m)
+
+	if err == nil && b.Len() > 0 {
+		err = io.ErrShortWrite
+	}
+
+	if b.Len() == 0 {
+		b.Reset()
+	}
+
+	return n, err
+}
+
+// ErrTooLarge is returned by a [LimitedWriter] when the written data would exceed its limit.
+var ErrTooLarge = errors.New("slice: data exceeds the size limit")
+
+// LimitedWriter is like a write-only [Buffer], but refuses to grow beyond a maximum
+// size, so that untrusted payloads, e.g. read from the network, can be decoded directly
+// into arena memory with a bound on the memory they use.
+//
+// The capacity grows in chunks, doubling up to the limit, so it never exceeds the
+// limit by more than the rounding of the arena allocations.
+//
+// Example:
+//
+//	w := slice.NewLimitedWriter(a, slice.Slice[byte]{}, 1<<20)
+//
+//	if _, err := w.ReadFrom(conn); errors.Is(err, slice.ErrTooLarge) {
+//	    return errPayloadTooLarge
+//	}
+//
+//	payload := w.Bytes()
+type LimitedWriter struct {
+	a     arena.AllocatorExt
+	buf   Slice[byte]
+	limit int
+}
+
+var (
+	_ io.Writer       = (*LimitedWriter)(nil)
+	_ io.StringWriter = (*LimitedWriter)(nil)
+	_ io.ByteWriter   = (*LimitedWriter)(nil)
+	_ io.ReaderFrom   = (*LimitedWriter)(nil)
+)
+
+// NewLimitedWriter returns a writer which appends to s on the given arena, until s
+// holds limit bytes.
+func NewLimitedWriter(a arena.AllocatorExt, s Slice[byte], limit int) *LimitedWriter {
+	return &LimitedWriter{a: a, buf: s, limit: limit}
+}
+
+// Bytes returns the written bytes.
+//
+// The returned slice shares memory with the writer, and is only valid until the
+// next write to it.
+func (w *LimitedWriter) Bytes() Slice[byte] { return w.buf }
+
+// Len returns the number of written bytes.
+func (w *LimitedWriter) Len() int { return w.buf.Len() }
+
+// Limit returns the maximum number of bytes the writer holds.
+func (w *LimitedWriter) Limit() int { return w.limit }
+
+// Reset empties the writer, keeping its capacity for future writes.
+func (w *LimitedWriter) Reset() { w.buf = w.buf.SetLen(0) }
+
+// Write appends p to the writer, growing it on the arena if necessary.
+//
+// If p doesn't fit within the limit, it appends as many bytes as fit, and
+// returns [ErrTooLarge].
+func (w *LimitedWriter) Write(p []byte) (n int, err error) {
+	if n = w.limit - w.buf.Len(); n < len(p) {
+		err = ErrTooLarge
+
+		if n < 0 { // The initial slice may already exceed the limit.
+			n = 0
+		}
+	} else {
+		n = len(p)
+	}
+
+	w.reserve(n)
+	w.buf = w.buf.Append(w.a, p[:n]...)
+
+	return n, err
+}
+
+// WriteString appends s to the writer, like [LimitedWriter.Write].
+func (w *LimitedWriter) WriteString(s string) (n int, err error) {
+	if n = w.limit - w.buf.Len(); n < len(s) {
+		err = ErrTooLarge
+
+		if n < 0 { // The initial slice may already exceed the limit.
+			n = 0
+		}
+	} else {
+		n = len(s)
+	}
+
+	w.reserve(n)
+	w.buf = AppendString(w.a, w.buf, s[:n])
+
+	return n, err
+}
+
+// WriteByte appends c to the writer, or returns [ErrTooLarge] if it is full.
+func (w *LimitedWriter) WriteByte(c byte) error {
+	if w.buf.Len() >= w.limit {
+		return ErrTooLarge
+	}
+
+	w.reserve(1)
+	w.buf = w.buf.AppendOne(w.a, c)
+
+	return nil
+}
+
+// ReadFrom reads from r until EOF, appending the data to the writer, which grows on
+// the arena as needed.
+//
+// It returns the number of bytes read, and any error except io.EOF encountered
+// during the read. Once the limit is reached, it reads one more byte to tell whether
+// r is exhausted, and returns [ErrTooLarge] if it isn't; that byte is discarded.
+func (w *LimitedWriter) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		rest := w.limit - w.buf.Len()
+		if rest <= 0 {
+			var b [1]byte
+
+			m, err := io.ReadFull(r, b[:])
+			if m > 0 {
+				return n, ErrTooLarge
+			} else if err == io.EOF {
+				return n, nil
+			}
+
+			return n, err
+		}
+
+		w.reserve(min(MinRead, rest))
+
+		buf := w.buf.Rest()
+		if len(buf) > rest {
+			buf = buf[:rest]
+		}
+
+		m, err := r.Read(buf)
+		if m < 0 {
+			panic(errors.New("slice: reader returned negative count from Read"))
+		}
+
+		w.buf.len += uint32(m)
+		n += int64(m)
+
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+	}
+}
+
+// reserve grows the capacity so that n more bytes fit, doubling it without
+// exceeding the limit.
+func (w *LimitedWriter) reserve(n int) {
+	if free := w.buf.Cap() - w.buf.Len(); free < n {
+		w.buf = w.buf.Grow(w.a, min(max(n-free, w.buf.Cap()), w.limit-w.buf.Cap()))
+	}
+}
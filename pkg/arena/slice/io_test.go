@@ -0,0 +1,242 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestBuffer(t *testing.T) {
+	Convey("Given a buffer", t, func() {
+		a := &arena.Arena{}
+		b := slice.NewBuffer(a, slice.Slice[byte]{})
+
+		So(b.Len(), ShouldEqual, 0)
+		So(b.Bytes().Len(), ShouldEqual, 0)
+
+		Convey("When writing to it", func() {
+			n, err := b.Write([]byte("hello"))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 5)
+
+			So(b.WriteByte(','), ShouldBeNil)
+
+			n, err = b.WriteString(" world")
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 6)
+
+			Convey("Then the bytes should be appended", func() {
+				So(b.Len(), ShouldEqual, 12)
+				So(slice.String(b.Bytes()), ShouldEqual, "hello, world")
+			})
+
+			Convey("Then writing it out should drain it", func() {
+				var w bytes.Buffer
+
+				n, err := b.WriteTo(&w)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 12)
+				So(w.String(), ShouldEqual, "hello, world")
+				So(b.Len(), ShouldEqual, 0)
+
+				n, err = b.WriteTo(&w)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+			})
+
+			Convey("Then a short write should be reported", func() {
+				n, err := b.WriteTo(&limitedWriter{n: 5})
+				So(err, ShouldEqual, io.ErrShortWrite)
+				So(n, ShouldEqual, 5)
+				So(slice.String(b.Bytes()), ShouldEqual, ", world")
+			})
+		})
+
+		Convey("When reading a large payload into it", func() {
+			payload := strings.Repeat("0123456789", 1000)
+
+			n, err := b.ReadFrom(iotest.HalfReader(strings.NewReader(payload)))
+
+			Convey("Then the whole payload should be read into the arena", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, len(payload))
+				So(slice.String(b.Bytes()), ShouldEqual, payload)
+			})
+		})
+
+		Convey("When the reader fails", func() {
+			errBoom := errors.New("boom")
+
+			n, err := b.ReadFrom(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errBoom)))
+
+			Convey("Then the error should be returned with the bytes read so far", func() {
+				So(err, ShouldEqual, errBoom)
+				So(n, ShouldEqual, 3)
+				So(slice.String(b.Bytes()), ShouldEqual, "abc")
+			})
+		})
+
+		Convey("When copying through it", func() {
+			var w bytes.Buffer
+
+			n, err := io.Copy(&w, io.TeeReader(strings.NewReader("payload"), b))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 7)
+
+			n, err = io.Copy(&w, b)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 7)
+			So(w.String(), ShouldEqual, "payloadpayload")
+		})
+
+		Convey("When reading from it", func() {
+			b.WriteString("hello")
+
+			p := make([]byte, 3)
+
+			n, err := b.Read(p)
+			So(err, ShouldBeNil)
+			So(string(p[:n]), ShouldEqual, "hel")
+
+			n, err = b.Read(p)
+			So(err, ShouldBeNil)
+			So(string(p[:n]), ShouldEqual, "lo")
+
+			_, err = b.Read(p)
+			So(err, ShouldEqual, io.EOF)
+		})
+	})
+}
+
+func TestLimitedWriter(t *testing.T) {
+	Convey("Given a limited writer", t, func() {
+		a := &arena.Arena{}
+		w := slice.NewLimitedWriter(a, slice.Slice[byte]{}, 10)
+
+		So(w.Limit(), ShouldEqual, 10)
+		So(w.Len(), ShouldEqual, 0)
+
+		Convey("When writing within the limit", func() {
+			n, err := w.Write([]byte("hello"))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 5)
+
+			So(w.WriteByte(','), ShouldBeNil)
+
+			n, err = w.WriteString("abcd")
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 4)
+
+			Convey("Then the bytes should be appended", func() {
+				So(slice.String(w.Bytes()), ShouldEqual, "hello,abcd")
+				So(w.Bytes().Cap(), ShouldBeLessThan, 2*10)
+			})
+
+			Convey("Then writing more should fail", func() {
+				So(w.WriteByte('!'), ShouldEqual, slice.ErrTooLarge)
+
+				n, err := w.Write([]byte("!"))
+				So(err, ShouldEqual, slice.ErrTooLarge)
+				So(n, ShouldEqual, 0)
+				So(w.Len(), ShouldEqual, 10)
+			})
+
+			Convey("Then resetting it should allow writing again", func() {
+				w.Reset()
+
+				So(w.WriteByte('!'), ShouldBeNil)
+				So(slice.String(w.Bytes()), ShouldEqual, "!")
+			})
+		})
+
+		Convey("When writing beyond the limit", func() {
+			n, err := w.WriteString("hello, world")
+
+			Convey("Then the bytes within the limit should be appended", func() {
+				So(err, ShouldEqual, slice.ErrTooLarge)
+				So(n, ShouldEqual, 10)
+				So(slice.String(w.Bytes()), ShouldEqual, "hello, wor")
+			})
+		})
+	})
+
+	Convey("Given a limited writer of a slice longer than its limit", t, func() {
+		a := &arena.Arena{}
+		w := slice.NewLimitedWriter(a, slice.FromString(a, "hello, world"), 5)
+
+		Convey("When writing to it", func() {
+			n, err := w.Write([]byte("!"))
+			So(err, ShouldEqual, slice.ErrTooLarge)
+			So(n, ShouldEqual, 0)
+
+			n, err = w.WriteString("!")
+			So(err, ShouldEqual, slice.ErrTooLarge)
+			So(n, ShouldEqual, 0)
+
+			So(w.WriteByte('!'), ShouldEqual, slice.ErrTooLarge)
+
+			Convey("Then the slice should be unchanged", func() {
+				So(slice.String(w.Bytes()), ShouldEqual, "hello, world")
+			})
+		})
+
+		Convey("When reading into it", func() {
+			n, err := w.ReadFrom(strings.NewReader("!"))
+			So(err, ShouldEqual, slice.ErrTooLarge)
+			So(n, ShouldEqual, 0)
+			So(slice.String(w.Bytes()), ShouldEqual, "hello, world")
+		})
+	})
+
+	Convey("Given a limited writer of a large payload", t, func() {
+		a := &arena.Arena{}
+		payload := strings.Repeat("0123456789", 1000)
+
+		Convey("When the payload fits", func() {
+			w := slice.NewLimitedWriter(a, slice.Slice[byte]{}, len(payload))
+
+			n, err := w.ReadFrom(iotest.HalfReader(strings.NewReader(payload)))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, len(payload))
+			So(slice.String(w.Bytes()), ShouldEqual, payload)
+			So(w.Bytes().Cap(), ShouldBeLessThan, 2*len(payload))
+		})
+
+		Convey("When the payload is too large", func() {
+			w := slice.NewLimitedWriter(a, slice.Slice[byte]{}, 4096)
+
+			n, err := w.ReadFrom(strings.NewReader(payload))
+			So(err, ShouldEqual, slice.ErrTooLarge)
+			So(n, ShouldEqual, 4096)
+			So(slice.String(w.Bytes()), ShouldEqual, payload[:4096])
+			So(w.Bytes().Cap(), ShouldBeLessThan, 2*4096)
+		})
+
+		Convey("When the reader fails", func() {
+			boom := errors.New("boom")
+			w := slice.NewLimitedWriter(a, slice.Slice[byte]{}, 4096)
+
+			_, err := w.ReadFrom(iotest.ErrReader(boom))
+			So(err, ShouldEqual, boom)
+		})
+	})
+}
+
+type limitedWriter struct{ n int }
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.n)
+	w.n -= n
+
+	return n, nil
+}
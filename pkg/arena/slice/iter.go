@@ -0,0 +1,85 @@
+//go:build go1.23
+
+package slice
+
+import (
+	"iter"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// Collect allocates a slice on the given arena holding every value yielded by seq.
+//
+// This allows iterator pipelines to terminate directly into arena memory without
+// building an intermediate Go slice first.
+//
+// Example:
+//
+//	s := slice.Collect(a, tree.Values())
+func Collect[T any](a arena.AllocatorExt, seq iter.Seq[T]) Slice[T] {
+	return Slice[T]{}.AppendSeq(a, seq)
+}
+
+// AppendSeq appends every value yielded by seq to a slice, reallocating on the
+// given arena if necessary.
+func (s Slice[T]) AppendSeq(a arena.AllocatorExt, seq iter.Seq[T]) Slice[T] {
+	for v := range seq {
+		s = s.AppendOne(a, v)
+	}
+
+	return s
+}
+
+// AllPtr returns an iterator over the indexes and pointers of the elements, so that
+// a range loop can modify them in place without a bounds-checked Store per write.
+//
+// The pointers stay valid as long as the slice is not reallocated. When built with
+// the debug tag, the iteration panics if the slice has been moved by growing it, or
+// truncated below the current index, during the iteration.
+//
+// Example:
+//
+//	for _, p := range s.AllPtr() {
+//	    *p *= 2
+//	}
+func (s *Slice[T]) AllPtr() iter.Seq2[int, *T] {
+	return func(yield func(int, *T) bool) {
+		snapshot := *s
+
+		for i := 0; i < snapshot.Len(); i++ {
+			if debug.Enabled && (s.ptr != snapshot.ptr || i >= s.Len()) {
+				panic("slice: modified during iteration")
+			}
+
+			if !yield(i, snapshot.unsafeGet(i)) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the indexes and the structs reassembled from the columns.
+func (c Columns[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < c.len; i++ {
+			if !yield(i, c.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// ColumnValues returns an iterator over the values of the column of the named field,
+// which only reads the memory of that column, see [Column].
+func ColumnValues[F, T any](c Columns[T], name string) iter.Seq[F] {
+	col := Column[F](c, name)
+
+	return func(yield func(F) bool) {
+		for _, v := range col.Raw() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
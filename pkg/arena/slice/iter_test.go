@@ -0,0 +1,121 @@
+//go:build go1.23
+
+package slice_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+	"github.com/flier/goutil/pkg/xiter"
+)
+
+func TestCollect(t *testing.T) {
+	Convey("Given an arena", t, func() {
+		a := &arena.Arena{}
+
+		Convey("When collecting a sequence", func() {
+			s := slice.Collect(a, slices.Values([]int{1, 2, 3, 4, 5}))
+
+			So(s.Len(), ShouldEqual, 5)
+			So(s.Raw(), ShouldResemble, []int{1, 2, 3, 4, 5})
+		})
+
+		Convey("When collecting an empty sequence", func() {
+			s := slice.Collect(a, xiter.Empty[int]())
+
+			So(s.Len(), ShouldEqual, 0)
+			So(s.Raw(), ShouldBeNil)
+		})
+
+		Convey("When collecting a long pipeline", func() {
+			s := slice.Collect(a, xiter.Map(xiter.Range(0, 1000), func(i int) int { return i * 2 }))
+
+			So(s.Len(), ShouldEqual, 1000)
+			So(s.Load(0), ShouldEqual, 0)
+			So(s.Load(999), ShouldEqual, 1998)
+		})
+	})
+}
+
+func TestSlice_AppendSeq(t *testing.T) {
+	Convey("Given a slice with data", t, func() {
+		a := &arena.Arena{}
+		s := slice.Of(a, 1, 2, 3)
+
+		Convey("When appending a sequence", func() {
+			s = s.AppendSeq(a, slices.Values([]int{4, 5, 6}))
+
+			So(s.Raw(), ShouldResemble, []int{1, 2, 3, 4, 5, 6})
+		})
+
+		Convey("When appending an empty sequence", func() {
+			s = s.AppendSeq(a, xiter.Empty[int]())
+
+			So(s.Raw(), ShouldResemble, []int{1, 2, 3})
+		})
+	})
+}
+
+func TestSlice_AllPtr(t *testing.T) {
+	Convey("Given a slice", t, func() {
+		a := &arena.Arena{}
+		s := slice.Of(a, 1, 2, 3, 4, 5)
+
+		Convey("When modifying the elements in place", func() {
+			for i, p := range s.AllPtr() {
+				*p *= 10
+
+				So(p, ShouldEqual, s.Get(i))
+			}
+
+			So(s.Raw(), ShouldResemble, []int{10, 20, 30, 40, 50})
+		})
+
+		Convey("When stopping early", func() {
+			var indexes []int
+
+			for i := range s.AllPtr() {
+				indexes = append(indexes, i)
+
+				if i == 2 {
+					break
+				}
+			}
+
+			So(indexes, ShouldResemble, []int{0, 1, 2})
+		})
+
+		Convey("When iterating an empty slice", func() {
+			var empty slice.Slice[int]
+
+			for range empty.AllPtr() {
+				So("unreachable", ShouldBeEmpty)
+			}
+		})
+
+		if debug.Enabled {
+			Convey("When growing the slice during the iteration", func() {
+				slice.Of(a, 0) // Prevents growing in place.
+
+				So(func() {
+					for range s.AllPtr() {
+						s = s.Append(a, 6, 7, 8, 9, 10, 11, 12, 13)
+					}
+				}, ShouldPanicWith, "slice: modified during iteration")
+			})
+
+			Convey("When truncating the slice during the iteration", func() {
+				So(func() {
+					for range s.AllPtr() {
+						s = s.SetLen(1)
+					}
+				}, ShouldPanicWith, "slice: modified during iteration")
+			})
+		}
+	})
+}
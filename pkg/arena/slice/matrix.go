@@ -0,0 +1,86 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"fmt"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// Matrix is a rows×cols matrix stored contiguously in row-major order in an arena.
+//
+// It is meant for numeric scratch buffers which live next to other arena data,
+// with a single allocation for the whole matrix instead of one per row.
+//
+// Example:
+//
+//	m := slice.NewMatrix[float64](a, 3, 4)
+//	m.Fill(1)
+//
+//	*m.At(1, 2) = 5
+//
+//	for _, v := range m.Row(1).Raw() {
+//	    fmt.Print(v, " ") // 1 1 5 1
+//	}
+type Matrix[T any] struct {
+	data       Slice[T]
+	rows, cols uint32
+}
+
+// NewMatrix allocates a rows×cols matrix.
+func NewMatrix[T any](a arena.Allocator, rows, cols int) Matrix[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Errorf("runtime error: matrix dimensions out of range [%d×%d]", rows, cols))
+	}
+
+	return Matrix[T]{Make[T](a, rows*cols), uint32(rows), uint32(cols)}
+}
+
+// Rows returns the number of rows of the matrix.
+func (m Matrix[T]) Rows() int { return int(m.rows) }
+
+// Cols returns the number of columns of the matrix.
+func (m Matrix[T]) Cols() int { return int(m.cols) }
+
+// Data returns the elements of the matrix in row-major order.
+func (m Matrix[T]) Data() Slice[T] { return m.data }
+
+// At returns the pointer to the element at row i and column j.
+func (m Matrix[T]) At(i, j int) *T {
+	if uint(i) >= uint(m.rows) || uint(j) >= uint(m.cols) {
+		panic(fmt.Errorf("runtime error: index out of range [%d, %d] with dimensions %d×%d", i, j, m.rows, m.cols))
+	}
+
+	return m.data.unsafeGet(i*int(m.cols) + j)
+}
+
+// Row returns the i-th row of the matrix.
+//
+// The row shares memory with the matrix, and its capacity is capped to the
+// number of columns, so appending to it never overwrites the next row.
+func (m Matrix[T]) Row(i int) Slice[T] {
+	if uint(i) >= uint(m.rows) {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with rows %d", i, m.rows))
+	}
+
+	if m.cols == 0 {
+		return Slice[T]{}
+	}
+
+	return FromParts(m.data.unsafeGet(i*int(m.cols)), m.cols, m.cols)
+}
+
+// Fill sets every element of the matrix to v.
+func (m Matrix[T]) Fill(v T) {
+	raw := m.data.Raw()
+
+	for i := range raw {
+		raw[i] = v
+	}
+}
+
+// Release releases the memory of the matrix back to the arena.
+func (m Matrix[T]) Release(a arena.Allocator) {
+	m.data.Release(a)
+}
@@ -0,0 +1,78 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestMatrix(t *testing.T) {
+	Convey("Given a 3×4 matrix", t, func() {
+		a := &arena.Arena{}
+		m := slice.NewMatrix[int](a, 3, 4)
+
+		So(m.Rows(), ShouldEqual, 3)
+		So(m.Cols(), ShouldEqual, 4)
+		So(m.Data().Len(), ShouldEqual, 12)
+
+		Convey("When filling it", func() {
+			m.Fill(1)
+
+			Convey("Then every element should be set", func() {
+				So(m.Data().Raw(), ShouldResemble, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+			})
+		})
+
+		Convey("When setting elements by position", func() {
+			for i := 0; i < m.Rows(); i++ {
+				for j := 0; j < m.Cols(); j++ {
+					*m.At(i, j) = i*10 + j
+				}
+			}
+
+			Convey("Then they should be stored in row-major order", func() {
+				So(m.Data().Raw(), ShouldResemble, []int{0, 1, 2, 3, 10, 11, 12, 13, 20, 21, 22, 23})
+				So(*m.At(2, 1), ShouldEqual, 21)
+			})
+
+			Convey("Then the rows should share memory with the matrix", func() {
+				r := m.Row(1)
+
+				So(r.Raw(), ShouldResemble, []int{10, 11, 12, 13})
+				So(r.Cap(), ShouldEqual, 4)
+
+				r.Store(0, 99)
+				So(*m.At(1, 0), ShouldEqual, 99)
+			})
+
+			Convey("Then appending to a row should not overwrite the next one", func() {
+				r := m.Row(0).AppendOne(a, 4)
+
+				So(r.Raw(), ShouldResemble, []int{0, 1, 2, 3, 4})
+				So(m.Row(1).Raw(), ShouldResemble, []int{10, 11, 12, 13})
+			})
+		})
+
+		Convey("When accessing out of bounds", func() {
+			So(func() { m.At(3, 0) }, ShouldPanic)
+			So(func() { m.At(0, 4) }, ShouldPanic)
+			So(func() { m.At(-1, 0) }, ShouldPanic)
+			So(func() { m.Row(3) }, ShouldPanic)
+		})
+	})
+
+	Convey("Given an empty matrix", t, func() {
+		a := &arena.Arena{}
+		m := slice.NewMatrix[float64](a, 2, 0)
+
+		So(m.Rows(), ShouldEqual, 2)
+		So(m.Row(1).Len(), ShouldEqual, 0)
+		So(func() { m.Fill(1) }, ShouldNotPanic)
+		So(func() { slice.NewMatrix[int](a, -1, 2) }, ShouldPanic)
+	})
+}
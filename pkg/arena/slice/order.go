@@ -0,0 +1,72 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"math/rand"
+
+	"github.com/flier/goutil/internal/debug"
+)
+
+// Swap swaps the values at the given indices in place.
+func (s Slice[T]) Swap(i, j int) {
+	if debug.Enabled {
+		raw := s.Raw()
+		raw[i], raw[j] = raw[j], raw[i]
+
+		return
+	}
+
+	s.unsafeSwap(i, j)
+}
+
+// Reverse reverses the order of the values in place.
+func (s Slice[T]) Reverse() {
+	s.reverse(0, s.Len())
+}
+
+// Rotate rotates the values in place to the left by n positions, so that the value
+// at index n becomes the first one.
+//
+// A negative n rotates to the right, and n is taken modulo the length of the slice.
+func (s Slice[T]) Rotate(n int) {
+	l := s.Len()
+	if l == 0 {
+		return
+	}
+
+	if n %= l; n < 0 {
+		n += l
+	}
+
+	if n == 0 {
+		return
+	}
+
+	s.reverse(0, n)
+	s.reverse(n, l)
+	s.reverse(0, l)
+}
+
+// Shuffle pseudo-randomizes the order of the values in place using the given source
+// of randomness, or the default source of the math/rand package if rng is nil.
+func (s Slice[T]) Shuffle(rng *rand.Rand) {
+	if rng == nil {
+		rand.Shuffle(s.Len(), s.unsafeSwap)
+	} else {
+		rng.Shuffle(s.Len(), s.unsafeSwap)
+	}
+}
+
+// reverse reverses the values between the indices i and j (exclusive) in place.
+func (s Slice[T]) reverse(i, j int) {
+	for j--; i < j; i, j = i+1, j-1 {
+		s.unsafeSwap(i, j)
+	}
+}
+
+// unsafeSwap swaps the values at the given indices, the caller must ensure that they are in bounds.
+func (s Slice[T]) unsafeSwap(i, j int) {
+	p, q := s.unsafeGet(i), s.unsafeGet(j)
+	*p, *q = *q, *p
+}
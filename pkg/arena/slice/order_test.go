@@ -0,0 +1,91 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestSlice_Order(t *testing.T) {
+	Convey("Given a slice", t, func() {
+		a := &arena.Arena{}
+		s := slice.Of(a, 1, 2, 3, 4, 5)
+
+		Convey("When swapping values", func() {
+			s.Swap(0, 4)
+			s.Swap(1, 1)
+
+			So(s.Raw(), ShouldResemble, []int{5, 2, 3, 4, 1})
+		})
+
+		if debug.Enabled {
+			Convey("When swapping out of bounds", func() {
+				So(func() { s.Swap(0, 5) }, ShouldPanic)
+			})
+		}
+
+		Convey("When reversing", func() {
+			s.Reverse()
+
+			So(s.Raw(), ShouldResemble, []int{5, 4, 3, 2, 1})
+
+			s.Slice(0, 2).Reverse()
+
+			So(s.Raw(), ShouldResemble, []int{4, 5, 3, 2, 1})
+		})
+
+		Convey("When rotating to the left", func() {
+			s.Rotate(2)
+
+			So(s.Raw(), ShouldResemble, []int{3, 4, 5, 1, 2})
+
+			s.Rotate(7)
+
+			So(s.Raw(), ShouldResemble, []int{5, 1, 2, 3, 4})
+		})
+
+		Convey("When rotating to the right", func() {
+			s.Rotate(-1)
+
+			So(s.Raw(), ShouldResemble, []int{5, 1, 2, 3, 4})
+
+			s.Rotate(-5)
+
+			So(s.Raw(), ShouldResemble, []int{5, 1, 2, 3, 4})
+		})
+
+		Convey("When shuffling", func() {
+			s.Shuffle(rand.New(rand.NewSource(1)))
+
+			raw := append([]int(nil), s.Raw()...)
+			sort.Ints(raw)
+
+			So(raw, ShouldResemble, []int{1, 2, 3, 4, 5})
+
+			Convey("Then the same seed should give the same order", func() {
+				t := slice.Of(a, 1, 2, 3, 4, 5)
+				t.Shuffle(rand.New(rand.NewSource(1)))
+
+				So(t.Raw(), ShouldResemble, s.Raw())
+			})
+		})
+	})
+
+	Convey("Given an empty slice", t, func() {
+		var s slice.Slice[int]
+
+		So(func() {
+			s.Reverse()
+			s.Rotate(3)
+			s.Shuffle(nil)
+		}, ShouldNotPanic)
+	})
+}
@@ -0,0 +1,154 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// DefaultPageSize is the number of elements per page of a zero [Paged].
+const DefaultPageSize = 64
+
+// Paged is an arena-backed sequence which stores its elements in fixed-size pages,
+// with an index of the pages.
+//
+// Appending never moves the existing elements, only the index of the pages is
+// reallocated when it grows, so the pointers returned by [Paged.Get] and
+// [Paged.Push] stay valid across appends, which a [Slice] can't guarantee.
+// The price is an extra indirection per access, and elements which aren't
+// contiguous across pages.
+//
+// A zero Paged is empty and uses pages of [DefaultPageSize] elements.
+//
+// Example:
+//
+//	var p slice.Paged[Leaf]
+//
+//	l := p.Push(a, Leaf{Key: 1})
+//	p.Append(a, leaves...)
+//
+//	l.Key // still valid
+type Paged[T any] struct {
+	pages Slice[Slice[T]]
+	len   uint32
+	shift uint8 // log2 of the page size plus one, 0 for DefaultPageSize.
+}
+
+// NewPaged returns an empty paged sequence whose pages hold n elements,
+// rounded up to a power of two.
+func NewPaged[T any](n int) Paged[T] {
+	if n <= 0 || n > 1<<24 {
+		panic(fmt.Errorf("runtime error: page size out of range [%d]", n))
+	}
+
+	return Paged[T]{shift: uint8(bits.Len(uint(n-1))) + 1}
+}
+
+// Len returns the number of elements.
+func (p *Paged[T]) Len() int { return int(p.len) }
+
+// Empty returns true if there is no element.
+func (p *Paged[T]) Empty() bool { return p.len == 0 }
+
+// PageSize returns the number of elements per page.
+func (p *Paged[T]) PageSize() int { return 1 << p.pageShift() }
+
+// Pages returns the number of allocated pages.
+func (p *Paged[T]) Pages() int { return p.pages.Len() }
+
+func (p *Paged[T]) pageShift() uint {
+	if p.shift == 0 {
+		return uint(bits.TrailingZeros(DefaultPageSize))
+	}
+
+	return uint(p.shift) - 1
+}
+
+// Get returns the pointer to the n-th element, which stays valid until the
+// sequence is reset or released.
+func (p *Paged[T]) Get(n int) *T {
+	if uint(n) >= uint(p.len) {
+		panic(fmt.Errorf("runtime error: index out of range [%d] with length %d", n, p.len))
+	}
+
+	return p.unsafeGet(n)
+}
+
+func (p *Paged[T]) unsafeGet(n int) *T {
+	shift := p.pageShift()
+
+	return p.pages.unsafeGet(n >> shift).unsafeGet(n & (1<<shift - 1))
+}
+
+// pageOf returns the elements of the i-th page.
+func (p *Paged[T]) pageOf(i int) []T {
+	return p.pages.Load(i).Raw()
+}
+
+// Load returns the n-th element.
+func (p *Paged[T]) Load(n int) T { return *p.Get(n) }
+
+// Store stores v at the n-th element.
+func (p *Paged[T]) Store(n int, v T) { *p.Get(n) = v }
+
+// Push appends an element, allocating a new page if the last one is full.
+//
+// It returns the pointer to the appended element.
+func (p *Paged[T]) Push(a arena.AllocatorExt, v T) *T {
+	if int(p.len) == p.pages.Len()<<p.pageShift() {
+		p.pages = p.pages.AppendOne(a, Make[T](a, p.PageSize()))
+	}
+
+	p.len++
+
+	e := p.unsafeGet(int(p.len) - 1)
+	*e = v
+
+	return e
+}
+
+// Append appends the elements, allocating new pages as needed.
+func (p *Paged[T]) Append(a arena.AllocatorExt, elems ...T) {
+	for _, v := range elems {
+		p.Push(a, v)
+	}
+}
+
+// AppendTo appends the elements to dst, in order.
+func (p *Paged[T]) AppendTo(dst []T) []T {
+	n := int(p.len)
+
+	for i := 0; n > 0; i++ {
+		page := p.pageOf(i)
+		page = page[:min(n, len(page))]
+
+		dst = append(dst, page...)
+		n -= len(page)
+	}
+
+	return dst
+}
+
+// Reset removes all elements, keeping the pages for reuse.
+//
+// The pointers to the elements are invalidated, since the pages are overwritten
+// by the next appends.
+func (p *Paged[T]) Reset() {
+	p.len = 0
+}
+
+// Release releases the memory of the pages back to the arena.
+func (p *Paged[T]) Release(a arena.Allocator) {
+	for i := 0; i < p.pages.Len(); i++ {
+		p.pages.unsafeLoad(i).Release(a)
+	}
+
+	if p.pages.Cap() > 0 {
+		p.pages.Release(a)
+	}
+
+	*p = Paged[T]{shift: p.shift}
+}
@@ -0,0 +1,100 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestPaged(t *testing.T) {
+	Convey("Given an empty paged sequence", t, func() {
+		a := &arena.Arena{}
+
+		var p slice.Paged[int]
+
+		So(p.Len(), ShouldEqual, 0)
+		So(p.Empty(), ShouldBeTrue)
+		So(p.PageSize(), ShouldEqual, slice.DefaultPageSize)
+		So(p.AppendTo(nil), ShouldBeEmpty)
+
+		Convey("When pushing elements across pages", func() {
+			first := p.Push(a, 0)
+
+			var want []int
+
+			want = append(want, 0)
+
+			for i := 1; i < 200; i++ {
+				p.Push(a, i)
+				want = append(want, i)
+			}
+
+			Convey("Then the elements should be stored in order", func() {
+				So(p.Len(), ShouldEqual, 200)
+				So(p.Pages(), ShouldEqual, 4)
+				So(p.AppendTo(nil), ShouldResemble, want)
+				So(p.Load(150), ShouldEqual, 150)
+			})
+
+			Convey("Then the pointers should stay valid", func() {
+				So(first, ShouldEqual, p.Get(0))
+
+				*first = 42
+
+				So(p.Load(0), ShouldEqual, 42)
+			})
+
+			Convey("Then the elements can be stored", func() {
+				p.Store(199, -1)
+
+				So(p.Load(199), ShouldEqual, -1)
+			})
+
+			Convey("Then out of range indexes should panic", func() {
+				So(func() { p.Get(200) }, ShouldPanic)
+				So(func() { p.Get(-1) }, ShouldPanic)
+			})
+
+			Convey("Then resetting it should reuse the pages", func() {
+				p.Reset()
+
+				So(p.Len(), ShouldEqual, 0)
+
+				p.Append(a, 1, 2, 3)
+
+				So(p.Pages(), ShouldEqual, 4)
+				So(p.AppendTo(nil), ShouldResemble, []int{1, 2, 3})
+				So(first, ShouldEqual, p.Get(0))
+			})
+
+			Convey("Then releasing it should empty it", func() {
+				p.Release(a)
+
+				So(p.Len(), ShouldEqual, 0)
+				So(p.Pages(), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a paged sequence with a custom page size", t, func() {
+		a := &arena.Arena{}
+		p := slice.NewPaged[int](3)
+
+		So(p.PageSize(), ShouldEqual, 4)
+
+		p.Append(a, 1, 2, 3, 4, 5)
+
+		So(p.Pages(), ShouldEqual, 2)
+		So(p.AppendTo(nil), ShouldResemble, []int{1, 2, 3, 4, 5})
+
+		q := slice.NewPaged[int](1)
+
+		So(q.PageSize(), ShouldEqual, 1)
+		So(func() { slice.NewPaged[int](0) }, ShouldPanic)
+	})
+}
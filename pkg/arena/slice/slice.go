@@ -32,7 +32,9 @@ func FromBytes(a arena.Allocator, b []byte) Slice[byte] {
 
 // FromString allocates a slice for the given string.
 func FromString(a arena.Allocator, s string) Slice[byte] {
-	return Of(a, []byte(s)...)
+	b := Make[byte](a, len(s))
+	copy(b.Raw(), s)
+	return b
 }
 
 // FromParts assembles a slice from its raw components.
@@ -62,7 +64,7 @@ func Wrap[T any](s []T) Slice[T] {
 		return Slice[T]{}
 	}
 
-	return Slice[T]{xunsafe.Cast[T](unsafe.SliceData(s)), uint32(len(s)), uint32(cap(s))}
+	return Slice[T]{xunsafe.Cast[T](unsafe.SliceData(s)), uint32(len(s)), uint32(cap(s))}.reuse()
 }
 
 // Of allocates a slice for the given values.
@@ -72,9 +74,18 @@ func Of[T any](a arena.Allocator, values ...T) Slice[T] {
 	return s
 }
 
-// Clone clones a slice.
+// Clone clones a slice into the given allocator.
+//
+// The source slice can live in any allocator, or be off-arena, so this is also
+// how a slice is migrated between arenas, e.g. before resetting a scratch arena.
 func Clone[T any](a arena.Allocator, s Slice[T]) Slice[T] {
-	return Of(a, s.Raw()...)
+	c := Make[T](a, s.Len())
+
+	// Large slices are copied in chunks, see arena.Memmove.
+	src := xunsafe.AddrOf(unsafe.SliceData(s.Raw()))
+	arena.Memmove(xunsafe.Addr[byte](c.Addr().Ptr), xunsafe.Addr[byte](src), s.Len()*layout.Size[T]())
+
+	return c
 }
 
 // Make allocates a slice of the given length.
@@ -84,7 +95,7 @@ func Make[T any](a arena.Allocator, n int) Slice[T] {
 
 	size := layout.Size[T]()
 	s := FromParts(p, uint32(n), uint32(cap/size))
-	return s
+	return s.reuse()
 }
 
 // Release releases the slice.
@@ -245,12 +256,18 @@ func (s Slice[T]) Store(n int, v T) {
 
 // Raw returns the underlying slice for this slice.
 //
-// The return value of this function must never escape outside of this module.
+// The return value of this function must never escape outside of this module,
+// use [Slice.SnapshotRaw] to retain the elements. In debug builds, it panics with
+// [ErrSuperseded] if the slice has been moved by a reallocation.
 func (s Slice[T]) Raw() []T {
 	if s.ptr == nil || s.len == 0 {
 		return nil
 	}
 
+	if debug.Enabled {
+		superseded.check(xunsafe.AddrOf(xunsafe.Cast[byte](s.ptr)))
+	}
+
 	return unsafe.Slice(s.Ptr(), s.cap)[:s.len]
 }
 
@@ -458,7 +475,7 @@ func (s Slice[T]) Grow(a arena.AllocatorExt, n int) Slice[T] {
 		cap := sliceLayout[T](n)
 		s.ptr = xunsafe.Cast[T](a.Alloc(cap))
 		s.cap = uint32(cap) / uint32(size)
-		return s
+		return s.reuse()
 	}
 
 	oldSize := sliceLayout[T](s.Cap())
@@ -473,7 +490,7 @@ func (s Slice[T]) Grow(a arena.AllocatorExt, n int) Slice[T] {
 		i := a.Next().Add(-oldSize)
 		j := i.Add(newSize)
 		if xunsafe.AddrOf(p) == i && j <= a.End() {
-			a.Advance(newSize)
+			a.Advance(newSize - oldSize)
 			a.Log("fast realloc", "%p, %d->%d:%d", p, oldSize, newSize, arena.Align)
 			break
 		}
@@ -486,15 +503,16 @@ func (s Slice[T]) Grow(a arena.AllocatorExt, n int) Slice[T] {
 		q := a.Alloc(newSize)
 		a.Log("realloc", "%p->%p, %d->%d:%d", p, q, oldSize, newSize, arena.Align)
 		if oldSize > 0 {
-			xunsafe.Copy(q, p, oldSize)
+			arena.Memmove(xunsafe.AddrOf(q), xunsafe.AddrOf(p), oldSize)
 		}
 
+		s.supersede(xunsafe.Cast[T](q))
 		p = q
 	}
 
 	s.ptr = xunsafe.Cast[T](p)
 	s.cap = uint32(newSize) / uint32(size)
-	return s
+	return s.reuse()
 }
 
 // Format implements [fmt.Formatter].
@@ -240,6 +240,29 @@ func TestSlice_Grow(t *testing.T) {
 
 			So(s.Cap(), ShouldBeGreaterThanOrEqualTo, 37) // 2 + 5 + 10 + 20
 		})
+
+		Convey("When growing the last allocation in place", func() {
+			a := &arena.Arena{}
+			a.Reserve(1024)
+
+			s := slice.Make[int](a, 2)
+			s = s.Grow(a, 5)
+			s = s.SetLen(s.Cap())
+
+			for i := range s.Raw() {
+				s.Store(i, i+1)
+			}
+
+			next := slice.Of(a, -1, -1)
+
+			Convey("Then the next allocation should not overlap the slice", func() {
+				So(next.Load(0), ShouldEqual, -1)
+
+				for i, v := range s.Raw() {
+					So(v, ShouldEqual, i+1)
+				}
+			})
+		})
 	})
 }
 
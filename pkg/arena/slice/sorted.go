@@ -0,0 +1,101 @@
+//go:build go1.21
+
+package slice
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// SortedSet is a set of ordered values kept sorted in an arena slice.
+//
+// Lookups are binary searches, and insertions and deletions shift the following
+// values, like the keys of the small nodes of an adaptive radix tree. It beats a map
+// for small sets, or sets which are mostly read and iterated in order.
+//
+// A zero SortedSet is empty and ready to use.
+//
+// Example:
+//
+//	var s slice.SortedSet[int]
+//
+//	s.Insert(a, 3)
+//	s.Insert(a, 1)
+//	s.Insert(a, 2)
+//
+//	s.Values().Raw() // [1 2 3]
+type SortedSet[T cmp.Ordered] struct {
+	s Slice[T]
+}
+
+// Len returns the number of values.
+func (s *SortedSet[T]) Len() int { return s.s.Len() }
+
+// Values returns the values in increasing order.
+//
+// The slice shares memory with the set, so it must not be modified, and is
+// invalidated by the next insertion or deletion.
+func (s *SortedSet[T]) Values() Slice[T] { return s.s }
+
+// Search returns the index of v in the values, or the index where it would be
+// inserted, and whether it was found.
+func (s *SortedSet[T]) Search(v T) (int, bool) {
+	return slices.BinarySearch(s.s.Raw(), v)
+}
+
+// Contains returns true if v is in the set.
+func (s *SortedSet[T]) Contains(v T) bool {
+	_, ok := s.Search(v)
+
+	return ok
+}
+
+// Insert adds v to the set, keeping the values sorted.
+//
+// It returns false if v was already in the set.
+func (s *SortedSet[T]) Insert(a arena.AllocatorExt, v T) bool {
+	i, ok := s.Search(v)
+	if ok {
+		return false
+	}
+
+	s.s = s.s.AppendOne(a, v)
+
+	raw := s.s.Raw()
+	copy(raw[i+1:], raw[i:len(raw)-1])
+	raw[i] = v
+
+	return true
+}
+
+// Delete removes v from the set.
+//
+// It returns false if v was not in the set.
+func (s *SortedSet[T]) Delete(v T) bool {
+	i, ok := s.Search(v)
+	if !ok {
+		return false
+	}
+
+	raw := s.s.Raw()
+	copy(raw[i:], raw[i+1:])
+	s.s.Truncate(len(raw) - 1)
+
+	return true
+}
+
+// Reset removes all the values, keeping the memory for reuse.
+func (s *SortedSet[T]) Reset() {
+	s.s.Truncate(0)
+}
+
+// Release releases the memory of the set back to the arena.
+func (s *SortedSet[T]) Release(a arena.Allocator) {
+	if s.s.Cap() > 0 {
+		s.s.Release(a)
+	}
+
+	s.s = Slice[T]{}
+}
@@ -0,0 +1,98 @@
+//go:build go1.21
+
+package slice_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestSortedSet(t *testing.T) {
+	Convey("Given an empty sorted set", t, func() {
+		a := &arena.Arena{}
+
+		var s slice.SortedSet[int]
+
+		So(s.Len(), ShouldEqual, 0)
+		So(s.Contains(1), ShouldBeFalse)
+		So(s.Delete(1), ShouldBeFalse)
+
+		Convey("When inserting values out of order", func() {
+			for _, v := range []int{5, 1, 3, 9, 7} {
+				So(s.Insert(a, v), ShouldBeTrue)
+			}
+
+			Convey("Then they should be kept sorted", func() {
+				So(s.Len(), ShouldEqual, 5)
+				So(s.Values().Raw(), ShouldResemble, []int{1, 3, 5, 7, 9})
+				So(s.Contains(7), ShouldBeTrue)
+				So(s.Contains(4), ShouldBeFalse)
+
+				i, ok := s.Search(4)
+				So(i, ShouldEqual, 2)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then duplicates should be ignored", func() {
+				So(s.Insert(a, 3), ShouldBeFalse)
+				So(s.Len(), ShouldEqual, 5)
+			})
+
+			Convey("Then values can be deleted", func() {
+				So(s.Delete(1), ShouldBeTrue)
+				So(s.Delete(9), ShouldBeTrue)
+				So(s.Delete(4), ShouldBeFalse)
+				So(s.Values().Raw(), ShouldResemble, []int{3, 5, 7})
+			})
+
+			Convey("Then resetting it should empty it", func() {
+				s.Reset()
+
+				So(s.Len(), ShouldEqual, 0)
+				So(s.Insert(a, 2), ShouldBeTrue)
+				So(s.Values().Raw(), ShouldResemble, []int{2})
+			})
+
+			Convey("Then releasing it should empty it", func() {
+				s.Release(a)
+
+				So(s.Len(), ShouldEqual, 0)
+				So(s.Values().Cap(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When inserting and deleting random values", func() {
+			rng := rand.New(rand.NewSource(1))
+			want := map[int]bool{}
+
+			for i := 0; i < 1000; i++ {
+				v := rng.Intn(200)
+
+				if rng.Intn(3) == 0 {
+					So(s.Delete(v), ShouldEqual, want[v])
+					delete(want, v)
+				} else {
+					So(s.Insert(a, v), ShouldEqual, !want[v])
+					want[v] = true
+				}
+			}
+
+			Convey("Then it should match a sorted map", func() {
+				var keys []int
+				for k := range want {
+					keys = append(keys, k)
+				}
+
+				sort.Ints(keys)
+
+				So(s.Values().Raw(), ShouldResemble, keys)
+			})
+		})
+	})
+}
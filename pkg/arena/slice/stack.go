@@ -0,0 +1,49 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+)
+
+// Push appends a value to the end of the slice in place, using it as a stack.
+func (s *Slice[T]) Push(a arena.AllocatorExt, v T) {
+	*s = s.AppendOne(a, v)
+}
+
+// Pop removes the last value of the slice in place and returns it.
+//
+// It returns false if the slice is empty.
+func (s *Slice[T]) Pop() (v T, ok bool) {
+	if s.len == 0 {
+		return
+	}
+
+	s.len--
+
+	return s.unsafeLoad(int(s.len)), true
+}
+
+// Last returns the last value of the slice.
+//
+// It returns false if the slice is empty.
+func (s Slice[T]) Last() (v T, ok bool) {
+	if s.len == 0 {
+		return
+	}
+
+	return s.unsafeLoad(int(s.len) - 1), true
+}
+
+// Truncate shortens the slice in place to n values, keeping its capacity.
+//
+// It has no effect if n is greater than or equal to the length of the slice.
+func (s *Slice[T]) Truncate(n int) {
+	if n < 0 {
+		n = 0
+	}
+
+	if n < s.Len() {
+		*s = s.SetLen(n)
+	}
+}
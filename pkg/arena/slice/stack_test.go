@@ -0,0 +1,64 @@
+//go:build go1.20
+
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestSlice_Stack(t *testing.T) {
+	Convey("Given an empty slice", t, func() {
+		a := &arena.Arena{}
+		var s slice.Slice[int]
+
+		_, ok := s.Pop()
+		So(ok, ShouldBeFalse)
+
+		_, ok = s.Last()
+		So(ok, ShouldBeFalse)
+
+		Convey("When pushing values", func() {
+			for i := 1; i <= 10; i++ {
+				s.Push(a, i)
+			}
+
+			So(s.Len(), ShouldEqual, 10)
+
+			v, ok := s.Last()
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, 10)
+
+			Convey("Then popping should return them in reverse order", func() {
+				for i := 10; i >= 1; i-- {
+					v, ok := s.Pop()
+
+					So(ok, ShouldBeTrue)
+					So(v, ShouldEqual, i)
+				}
+
+				_, ok := s.Pop()
+				So(ok, ShouldBeFalse)
+				So(s.Cap(), ShouldBeGreaterThanOrEqualTo, 10)
+			})
+
+			Convey("Then truncating should keep the first values", func() {
+				s.Truncate(3)
+
+				So(s.Raw(), ShouldResemble, []int{1, 2, 3})
+
+				s.Truncate(5)
+
+				So(s.Raw(), ShouldResemble, []int{1, 2, 3})
+
+				s.Truncate(-1)
+
+				So(s.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+}
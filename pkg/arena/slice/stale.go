@@ -0,0 +1,116 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// ErrSuperseded is raised as a panic in debug builds by [Slice.Raw] and the accessors
+// of a slice whose memory has been moved by a reallocation, e.g. a copy of a slice taken
+// before [Slice.Append] grew it: writes through it are lost, and its reads miss the
+// later writes.
+var ErrSuperseded = errors.New("slice: use of a slice superseded by a reallocation")
+
+// superseded tracks the memory of the slices moved by [Slice.Grow] in debug builds.
+var superseded staleSet
+
+// staleRange is the memory range [start, end) of a superseded slice.
+type staleRange struct {
+	start, end xunsafe.Addr[byte]
+}
+
+// staleSet is a set of non-overlapping ranges sorted by their start address.
+type staleSet struct {
+	mu     sync.Mutex
+	ranges []staleRange
+}
+
+// supersede marks the memory of size bytes at p as superseded.
+func (s *staleSet) supersede(p xunsafe.Addr[byte], size int) {
+	if size == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, j := s.overlap(p, size)
+	s.ranges = append(s.ranges[:i], append([]staleRange{{p, p.Add(size)}}, s.ranges[j:]...)...)
+}
+
+// reuse clears the marks of the memory of size bytes at p, which is allocated again.
+func (s *staleSet) reuse(p xunsafe.Addr[byte], size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ranges) == 0 {
+		return
+	}
+
+	if size == 0 {
+		size = 1
+	}
+
+	i, j := s.overlap(p, size)
+	s.ranges = append(s.ranges[:i], s.ranges[j:]...)
+}
+
+// check panics with [ErrSuperseded] if p points into superseded memory.
+func (s *staleSet) check(p xunsafe.Addr[byte]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, j := s.overlap(p, 1); i < j {
+		panic(fmt.Errorf("%w: %v moved from %v:%v", ErrSuperseded, p, s.ranges[i].start, s.ranges[i].end))
+	}
+}
+
+// overlap returns the range of indexes of the ranges overlapping the size bytes at p.
+func (s *staleSet) overlap(p xunsafe.Addr[byte], size int) (i, j int) {
+	end := p.Add(size)
+
+	i = sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].end > p })
+	j = i
+
+	for j < len(s.ranges) && s.ranges[j].start < end {
+		j++
+	}
+
+	return
+}
+
+// supersede marks the memory of s as superseded, after it has been moved to q.
+func (s Slice[T]) supersede(q *T) {
+	if debug.Enabled && s.ptr != nil && s.ptr != q {
+		superseded.supersede(xunsafe.AddrOf(xunsafe.Cast[byte](s.ptr)), sliceLayout[T](s.Cap()))
+	}
+}
+
+// reuse clears the marks of the memory of s, which is allocated again.
+func (s Slice[T]) reuse() Slice[T] {
+	if debug.Enabled && s.ptr != nil {
+		superseded.reuse(xunsafe.AddrOf(xunsafe.Cast[byte](s.ptr)), sliceLayout[T](s.Cap()))
+	}
+
+	return s
+}
+
+// SnapshotRaw returns a copy of the elements of the slice in ordinary Go memory.
+//
+// Unlike [Slice.Raw], the snapshot is unaffected by later writes to the slice, by its
+// reallocation when it grows, and by the reset of its arena, so it can be retained or
+// iterated over while the slice is modified. It returns nil if the slice is empty.
+func (s Slice[T]) SnapshotRaw() []T {
+	if s.ptr == nil || s.len == 0 {
+		return nil
+	}
+
+	return append([]T(nil), s.Raw()...)
+}
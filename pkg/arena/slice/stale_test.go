@@ -0,0 +1,73 @@
+//go:build go1.22
+
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestSlice_SnapshotRaw(t *testing.T) {
+	Convey("Given a slice in an arena", t, func() {
+		a := &arena.Arena{}
+		s := slice.Of(a, 1, 2, 3)
+
+		Convey("When taking a snapshot", func() {
+			snap := s.SnapshotRaw()
+
+			So(snap, ShouldResemble, []int{1, 2, 3})
+
+			Convey("Then it is unaffected by writes and reallocations", func() {
+				s.Store(0, 42)
+
+				_ = slice.Make[int](a, 1) // Prevents growing in place.
+
+				s = s.Append(a, make([]int, s.Cap())...)
+
+				So(s.Load(0), ShouldEqual, 42)
+				So(snap, ShouldResemble, []int{1, 2, 3})
+			})
+		})
+
+		Convey("Then the snapshot of an empty slice is nil", func() {
+			So(slice.Slice[int]{}.SnapshotRaw(), ShouldBeNil)
+			So(s.SetLen(0).SnapshotRaw(), ShouldBeNil)
+		})
+
+		Convey("When the slice is moved by growing it", func() {
+			old := s
+
+			_ = slice.Make[int](a, 1)
+
+			s = s.Append(a, make([]int, s.Cap())...)
+
+			So(s.Load(2), ShouldEqual, 3)
+
+			Convey("Then the superseded slice is flagged in debug builds", func() {
+				if debug.Enabled {
+					So(func() {
+						defer func() { So(recover(), ShouldWrap, slice.ErrSuperseded) }()
+
+						old.Raw()
+					}, ShouldNotPanic)
+					So(func() { old.Load(0) }, ShouldPanic)
+				} else {
+					So(old.Load(0), ShouldEqual, 1)
+				}
+			})
+
+			Convey("Then the memory allocated again is no longer flagged", func() {
+				a.Reset()
+
+				r := slice.Of(a, 4, 5, 6, 7)
+
+				So(r.Raw(), ShouldResemble, []int{4, 5, 6, 7})
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+//go:build go1.20
+
+package slice
+
+import (
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+// String converts a byte slice into a string without copying.
+//
+// The returned string shares memory with the slice, so it is only valid as long
+// as the owning arena is alive and not reset, and the slice must not be modified
+// while the string is in use. Copy the string, e.g. with [strings.Clone], before
+// it escapes the lifetime of the arena.
+//
+// When built with the debug tag, the bytes are copied instead, so that misuse
+// shows up as stale data rather than memory corruption.
+func String(s Slice[byte]) string {
+	if s.Len() == 0 {
+		return ""
+	}
+
+	if debug.Enabled {
+		return string(s.Raw())
+	}
+
+	return xunsafe.SliceToString(s.Raw())
+}
+
+// WrapString creates a Slice[byte] from a string without copying or allocating memory.
+//
+// The returned slice shares memory with the string, which is immutable, so it must
+// never be written to or appended in place; use [FromString] for a mutable copy.
+func WrapString(s string) Slice[byte] {
+	return Wrap(xunsafe.StringToSlice[[]byte](s))
+}
+
+// AppendString appends the bytes of a string to a byte slice, reallocating on the
+// given arena if necessary.
+//
+// Unlike s.Append(a, []byte(str)...), this never converts the string into an
+// intermediate byte slice.
+func AppendString(a arena.AllocatorExt, s Slice[byte], str string) Slice[byte] {
+	a.Log("append", "%p[%d:%d], string x %d", s.ptr, s.len, s.cap, len(str))
+
+	if s.Cap()-s.Len() < len(str) {
+		s = s.Grow(a, len(str))
+	}
+
+	copy(s.Rest(), str)
+	s.len += uint32(len(str))
+
+	return s
+}
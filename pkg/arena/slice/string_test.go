@@ -0,0 +1,85 @@
+//go:build go1.22
+
+package slice_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/internal/debug"
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+func TestString(t *testing.T) {
+	Convey("Given a byte slice", t, func() {
+		a := &arena.Arena{}
+		s := slice.FromString(a, "hello world")
+
+		Convey("When converting to a string", func() {
+			str := slice.String(s)
+
+			So(str, ShouldEqual, "hello world")
+
+			if !debug.Enabled {
+				Convey("Then the string should share memory with the slice", func() {
+					s.Store(0, 'H')
+
+					So(str, ShouldEqual, "Hello world")
+				})
+			}
+		})
+
+		Convey("When converting an empty slice", func() {
+			So(slice.String(slice.Slice[byte]{}), ShouldEqual, "")
+			So(slice.String(s.Slice(0, 0)), ShouldEqual, "")
+		})
+	})
+}
+
+func TestWrapString(t *testing.T) {
+	Convey("Given a string", t, func() {
+		str := "hello world"
+
+		Convey("When wrapping it", func() {
+			s := slice.WrapString(str)
+
+			So(s.Len(), ShouldEqual, len(str))
+			So(slice.String(s), ShouldEqual, str)
+		})
+
+		Convey("When wrapping an empty string", func() {
+			s := slice.WrapString("")
+
+			So(s.Len(), ShouldEqual, 0)
+			So(s.Ptr(), ShouldBeNil)
+		})
+	})
+}
+
+func TestAppendString(t *testing.T) {
+	Convey("Given a byte slice", t, func() {
+		a := &arena.Arena{}
+		s := slice.FromString(a, "hello")
+
+		Convey("When appending strings", func() {
+			s = slice.AppendString(a, s, ", ")
+			s = slice.AppendString(a, s, "world")
+
+			So(slice.String(s), ShouldEqual, "hello, world")
+		})
+
+		Convey("When appending to an empty slice", func() {
+			s := slice.AppendString(a, slice.Slice[byte]{}, "world")
+
+			So(slice.String(s), ShouldEqual, "world")
+		})
+
+		Convey("When appending an empty string", func() {
+			s = slice.AppendString(a, s, "")
+
+			So(slice.String(s), ShouldEqual, "hello")
+		})
+	})
+}
@@ -0,0 +1,154 @@
+//go:build go1.20
+
+// Package smallvec provides a vector which stores a few elements inline and only
+// spills to an arena once it outgrows them.
+//
+// It is intended for short-lived stacks and buffers, such as the path of a tree
+// traversal, which are usually tiny but occasionally deep.
+package smallvec
+
+import (
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/slice"
+)
+
+// Vec is a vector which keeps its elements in inline storage provided by the caller,
+// typically an array on the stack, and spills them to an arena beyond its capacity.
+//
+// Go generics can't be parameterized by a constant, so the inline storage is passed
+// to [New] instead of being part of the type.
+//
+// Example:
+//
+//	var buf [8]node.Ref[T]
+//	stack := smallvec.New(buf[:])
+//
+//	stack.Push(a, root)
+//	for !stack.Empty() {
+//	    ref, _ := stack.Pop()
+//	    // ...
+//	}
+//
+// The zero Vec has no inline storage and allocates from the arena on the first push.
+type Vec[T any] struct {
+	inline  []T
+	spill   slice.Slice[T]
+	spilled bool
+}
+
+// New returns an empty vector which stores up to len(buf) elements in buf.
+func New[T any](buf []T) Vec[T] {
+	return Vec[T]{inline: buf[:0:len(buf)]}
+}
+
+// Len returns the number of elements in the vector.
+func (v *Vec[T]) Len() int {
+	if v.spilled {
+		return v.spill.Len()
+	}
+
+	return len(v.inline)
+}
+
+// Empty returns true if the vector has no elements.
+func (v *Vec[T]) Empty() bool { return v.Len() == 0 }
+
+// Spilled returns true if the elements have been moved from the inline storage to the arena.
+func (v *Vec[T]) Spilled() bool { return v.spilled }
+
+// Get returns the pointer to the element at the given index.
+func (v *Vec[T]) Get(n int) *T {
+	if v.spilled {
+		return v.spill.Get(n)
+	}
+
+	return &v.inline[n]
+}
+
+// Load returns the element at the given index.
+func (v *Vec[T]) Load(n int) T { return *v.Get(n) }
+
+// Raw returns the elements of the vector as a Go slice.
+//
+// The returned slice is only valid until the next modification of the vector.
+func (v *Vec[T]) Raw() []T {
+	if v.spilled {
+		return v.spill.Raw()
+	}
+
+	return v.inline
+}
+
+// Push appends an element to the end of the vector.
+//
+// Once the inline storage is full, all elements are moved to a slice allocated from the arena.
+func (v *Vec[T]) Push(a arena.AllocatorExt, elem T) {
+	if !v.spilled {
+		if len(v.inline) < cap(v.inline) {
+			v.inline = append(v.inline, elem)
+			return
+		}
+
+		v.spill = slice.Make[T](a, 2*cap(v.inline)+1).SetLen(0).Append(a, v.inline...)
+		v.spilled = true
+	}
+
+	v.spill.Push(a, elem)
+}
+
+// Pop removes the last element of the vector and returns it.
+//
+// It returns false if the vector is empty.
+func (v *Vec[T]) Pop() (elem T, ok bool) {
+	if v.spilled {
+		return v.spill.Pop()
+	}
+
+	if n := len(v.inline); n > 0 {
+		elem, ok = v.inline[n-1], true
+		v.inline = v.inline[:n-1]
+	}
+
+	return
+}
+
+// Last returns the last element of the vector.
+//
+// It returns false if the vector is empty.
+func (v *Vec[T]) Last() (elem T, ok bool) {
+	if v.spilled {
+		return v.spill.Last()
+	}
+
+	if n := len(v.inline); n > 0 {
+		elem, ok = v.inline[n-1], true
+	}
+
+	return
+}
+
+// Truncate shortens the vector to n elements.
+//
+// It has no effect if n is greater than or equal to the length of the vector.
+func (v *Vec[T]) Truncate(n int) {
+	if v.spilled {
+		v.spill.Truncate(n)
+	} else if n < 0 {
+		v.inline = v.inline[:0]
+	} else if n < len(v.inline) {
+		v.inline = v.inline[:n]
+	}
+}
+
+// Reset removes all elements and switches back to the inline storage.
+//
+// The spilled elements are released to the arena.
+func (v *Vec[T]) Reset(a arena.Allocator) {
+	if v.spilled {
+		v.spill.Release(a)
+		v.spill = slice.Slice[T]{}
+		v.spilled = false
+	}
+
+	v.inline = v.inline[:0]
+}
@@ -0,0 +1,103 @@
+//go:build go1.20
+
+package smallvec_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+	"github.com/flier/goutil/pkg/arena/smallvec"
+)
+
+func TestVec(t *testing.T) {
+	Convey("Given a vector with inline storage", t, func() {
+		a := &arena.Arena{}
+
+		var buf [4]int
+		v := smallvec.New(buf[:])
+
+		So(v.Empty(), ShouldBeTrue)
+
+		Convey("When pushing within the inline capacity", func() {
+			next := a.Next()
+
+			for i := 1; i <= 4; i++ {
+				v.Push(a, i)
+			}
+
+			Convey("Then it should not allocate from the arena", func() {
+				So(v.Spilled(), ShouldBeFalse)
+				So(a.Next(), ShouldEqual, next)
+				So(v.Raw(), ShouldResemble, []int{1, 2, 3, 4})
+				So(buf, ShouldResemble, [4]int{1, 2, 3, 4})
+			})
+
+			Convey("Then it should work as a stack", func() {
+				last, ok := v.Last()
+				So(ok, ShouldBeTrue)
+				So(last, ShouldEqual, 4)
+
+				for i := 4; i >= 1; i-- {
+					e, ok := v.Pop()
+					So(ok, ShouldBeTrue)
+					So(e, ShouldEqual, i)
+				}
+
+				_, ok = v.Pop()
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When pushing beyond the inline capacity", func() {
+			for i := 1; i <= 100; i++ {
+				v.Push(a, i)
+			}
+
+			Convey("Then it should spill to the arena", func() {
+				So(v.Spilled(), ShouldBeTrue)
+				So(v.Len(), ShouldEqual, 100)
+				So(v.Load(0), ShouldEqual, 1)
+				So(*v.Get(99), ShouldEqual, 100)
+
+				v.Truncate(10)
+
+				last, _ := v.Last()
+				So(last, ShouldEqual, 10)
+
+				e, ok := v.Pop()
+				So(ok, ShouldBeTrue)
+				So(e, ShouldEqual, 10)
+				So(v.Len(), ShouldEqual, 9)
+			})
+
+			Convey("Then resetting should switch back to the inline storage", func() {
+				v.Reset(a)
+
+				So(v.Spilled(), ShouldBeFalse)
+				So(v.Empty(), ShouldBeTrue)
+
+				v.Push(a, 42)
+
+				So(buf[0], ShouldEqual, 42)
+			})
+		})
+	})
+
+	Convey("Given a zero vector", t, func() {
+		a := &arena.Arena{}
+
+		var v smallvec.Vec[string]
+
+		v.Push(a, "foo")
+		v.Push(a, "bar")
+
+		So(v.Spilled(), ShouldBeTrue)
+		So(v.Raw(), ShouldResemble, []string{"foo", "bar"})
+
+		v.Truncate(0)
+
+		So(v.Empty(), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,144 @@
+//go:build go1.22
+
+package arena
+
+import "github.com/flier/goutil/pkg/xunsafe"
+
+// Typed is a facade of an arena allocating values of type T, which runs an init
+// function on each new value, and a fini function on each value freed, or still
+// live when the arena is reset or released to an earlier [Mark].
+//
+// It replaces the bookkeeping of the values with small cleanup needs, such as
+// returning a buffer to a pool, which would otherwise be leaked by a reset.
+//
+// Like an arena, it is not safe for concurrent use.
+//
+// Example:
+//
+//	conns := arena.NewTyped(a, nil, func(c *Conn) {
+//	    bufPool.Put(c.Buf.Get(a.Roots()))
+//	})
+//
+//	c := conns.New()
+//	c.Buf = arena.Pin(a.Roots(), bufPool.Get())
+//
+//	a.Reset() // Returns the buffers of all the live connections.
+type Typed[T any] struct {
+	a          *Arena
+	init, fini func(*T)
+
+	live  []typedValue[T] // The values in allocation order, including the freed ones.
+	index map[*T]int      // The index of each live value in live.
+	freed int             // The number of freed values in live.
+}
+
+// typedValue is a value allocated by a [Typed] facade.
+type typedValue[T any] struct {
+	p     *T
+	cap   int  // The capacity of the arena block holding p, see [Mark.allocatedAfter].
+	freed bool // True if p has been freed.
+}
+
+// NewTyped returns a typed facade of the arena, with the optional init and fini
+// functions of its values.
+//
+// The fini function runs before the [Roots] of the arena are cleared, so that it
+// can still release the heap objects referenced by the values. It also runs on
+// the values released by [Arena.ReleaseTo].
+func NewTyped[T any](a *Arena, init, fini func(*T)) *Typed[T] {
+	t := &Typed[T]{a: a, init: init, fini: fini, index: make(map[*T]int)}
+
+	a.releases = append(a.releases, t.finalize)
+
+	return t
+}
+
+// Arena returns the arena of the values.
+func (t *Typed[T]) Arena() *Arena { return t.a }
+
+// Len returns the number of live values.
+func (t *Typed[T]) Len() int { return len(t.index) }
+
+// New allocates a zero value, and runs the init function on it.
+func (t *Typed[T]) New() *T {
+	var zero T
+
+	p := New(t.a, zero)
+
+	if t.init != nil {
+		t.init(p)
+	}
+
+	t.index[p] = len(t.live)
+	t.live = append(t.live, typedValue[T]{p: p, cap: t.a.cap})
+
+	return p
+}
+
+// Free runs the fini function on a value allocated by [Typed.New], and releases it.
+//
+// A value which is not live, e.g. already freed or released by a reset of the
+// arena, is ignored.
+func (t *Typed[T]) Free(p *T) {
+	i, ok := t.index[p]
+	if !ok {
+		return
+	}
+
+	delete(t.index, p)
+	t.live[i].freed = true
+	t.freed++
+
+	if t.fini != nil {
+		t.fini(p)
+	}
+
+	Free(t.a, p)
+
+	if t.freed > len(t.live)/2 {
+		t.compact()
+	}
+}
+
+// compact drops the freed values from live, so that it doesn't grow with the
+// values allocated and freed in turn.
+func (t *Typed[T]) compact() {
+	n := 0
+
+	for _, v := range t.live {
+		if !v.freed {
+			t.index[v.p] = n
+			t.live[n] = v
+			n++
+		}
+	}
+
+	clear(t.live[n:])
+	t.live = t.live[:n]
+	t.freed = 0
+}
+
+// finalize runs the fini function on the live values allocated after the mark,
+// in reverse allocation order, and forgets them.
+func (t *Typed[T]) finalize(m Mark) {
+	n := len(t.live)
+
+	for n > 0 && m.allocatedAfter(xunsafe.AddrOf(xunsafe.Cast[byte](t.live[n-1].p)), t.live[n-1].cap) {
+		n--
+
+		v := t.live[n]
+		if v.freed {
+			t.freed--
+			continue
+		}
+
+		delete(t.index, v.p)
+
+		if t.fini != nil {
+			t.fini(v.p)
+		}
+	}
+
+	clear(t.live[n:])
+	t.live = t.live[:n]
+}
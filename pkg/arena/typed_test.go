@@ -0,0 +1,167 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/arena"
+)
+
+func TestTyped(t *testing.T) {
+	type conn struct {
+		ID  int
+		Buf Ref[[]byte]
+	}
+
+	Convey("Given a typed arena with init and fini functions", t, func() {
+		a := new(Arena)
+		roots := a.Roots()
+
+		var (
+			nextID   int
+			returned []int
+		)
+
+		conns := NewTyped(a, func(c *conn) {
+			nextID++
+			c.ID = nextID
+			c.Buf = Pin(roots, make([]byte, 16))
+		}, func(c *conn) {
+			So(c.Buf.Get(roots), ShouldHaveLength, 16)
+
+			returned = append(returned, c.ID)
+			c.Buf.Unpin(roots)
+		})
+
+		So(conns.Arena(), ShouldEqual, a)
+
+		c1, c2, c3 := conns.New(), conns.New(), conns.New()
+
+		Convey("Then New runs the init function", func() {
+			So([]int{c1.ID, c2.ID, c3.ID}, ShouldResemble, []int{1, 2, 3})
+			So(conns.Len(), ShouldEqual, 3)
+			So(roots.Len(), ShouldEqual, 3)
+		})
+
+		Convey("When freeing a value", func() {
+			conns.Free(c2)
+			conns.Free(c2)
+
+			Convey("Then its fini function runs once", func() {
+				So(returned, ShouldResemble, []int{2})
+				So(conns.Len(), ShouldEqual, 2)
+				So(roots.Len(), ShouldEqual, 2)
+			})
+
+			Convey("Then resetting the arena runs the fini function of the live values", func() {
+				a.Reset()
+
+				So(returned, ShouldResemble, []int{2, 3, 1})
+				So(conns.Len(), ShouldEqual, 0)
+
+				Convey("And the typed arena can be used again", func() {
+					c := conns.New()
+
+					So(c.ID, ShouldEqual, 4)
+					So(conns.Len(), ShouldEqual, 1)
+
+					a.Reset()
+
+					So(returned, ShouldResemble, []int{2, 3, 1, 4})
+				})
+			})
+		})
+	})
+
+	Convey("Given a typed arena without functions", t, func() {
+		a := new(Arena)
+		ints := NewTyped[int](a, nil, nil)
+
+		p := ints.New()
+		*p = 42
+
+		So(*p, ShouldEqual, 42)
+		So(ints.Len(), ShouldEqual, 1)
+
+		ints.Free(p)
+		q := ints.New()
+		*q = 7
+
+		Convey("Then freeing a value which is not live is ignored", func() {
+			ints.Free(p)
+
+			So(ints.Len(), ShouldEqual, 1)
+
+			a.Reset()
+			ints.Free(q)
+
+			So(ints.Len(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a typed arena and a mark", t, func() {
+		a := new(Arena)
+
+		var returned []int
+
+		ints := NewTyped(a, nil, func(p *int) { returned = append(returned, *p) })
+
+		*ints.New() = 1
+		m := a.Mark()
+
+		Convey("When releasing the values allocated after the mark", func() {
+			*ints.New() = 2
+			for i := 3; i <= 100; i++ {
+				*ints.New() = i
+				a.Alloc(1000) // Grows the arena past the block of the mark.
+			}
+
+			a.ReleaseTo(m)
+
+			Convey("Then their fini function runs in reverse allocation order", func() {
+				So(returned, ShouldHaveLength, 99)
+				So(returned[0], ShouldEqual, 100)
+				So(returned[98], ShouldEqual, 2)
+				So(ints.Len(), ShouldEqual, 1)
+			})
+
+			Convey("Then the next reset only runs it on the values allocated before the mark", func() {
+				returned = nil
+
+				a.Reset()
+
+				So(returned, ShouldResemble, []int{1})
+			})
+		})
+	})
+
+	Convey("Given a typed arena with values allocated and freed in turn", t, func() {
+		a := new(Arena)
+
+		var returned []int
+
+		ints := NewTyped(a, nil, func(p *int) { returned = append(returned, *p) })
+
+		for i := 0; i < 1000; i++ {
+			p := ints.New()
+			*p = i
+
+			if i%10 != 0 {
+				ints.Free(p)
+			}
+		}
+
+		Convey("Then resetting the arena runs the fini function of the live values only", func() {
+			returned = nil
+
+			a.Reset()
+
+			So(returned, ShouldHaveLength, 100)
+			So(returned[0], ShouldEqual, 990)
+			So(returned[99], ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,12 @@
+//go:build go1.22
+
+package arena
+
+import "reflect"
+
+// TypeStat is the number of values of a type allocated with [New], see [TypeStats].
+type TypeStat struct {
+	Type  reflect.Type
+	Count int // The number of values allocated.
+	Bytes int // The number of bytes allocated.
+}
@@ -0,0 +1,19 @@
+//go:build go1.22 && !arenastats
+
+package arena
+
+// TypeStatsEnabled is true if built with the arenastats tag, which counts the values
+// allocated with [New] per type, see [TypeStats].
+const TypeStatsEnabled = false
+
+// TypeStats returns the number of values and bytes allocated with [New] per type,
+// by decreasing number of bytes.
+//
+// The statistics are only maintained when built with the arenastats tag, see
+// [TypeStatsEnabled]; otherwise it returns nil.
+func TypeStats() []TypeStat { return nil }
+
+// ResetTypeStats clears the statistics returned by [TypeStats].
+func ResetTypeStats() {}
+
+func countType[T any](int) {}
@@ -0,0 +1,78 @@
+//go:build go1.22 && arenastats
+
+package arena
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// TypeStatsEnabled is true if built with the arenastats tag, which counts the values
+// allocated with [New] per type, see [TypeStats].
+const TypeStatsEnabled = true
+
+type typeCounter struct {
+	count, bytes atomic.Int64
+}
+
+var typeCounters sync.Map // reflect.Type -> *typeCounter
+
+// TypeStats returns the number of values and bytes allocated with [New] per type,
+// by decreasing number of bytes, so that the structures dominating the arena usage
+// can be found, which a heap profile can't see inside the arenas.
+//
+// The values are counted across all the allocators since the program started, or
+// since the last [ResetTypeStats], and are not decremented when they are freed.
+//
+// The statistics are only maintained when built with the arenastats tag, see
+// [TypeStatsEnabled]; otherwise it returns nil.
+func TypeStats() []TypeStat {
+	var stats []TypeStat
+
+	typeCounters.Range(func(k, v any) bool {
+		c := v.(*typeCounter)
+
+		if n := c.count.Load(); n > 0 {
+			stats = append(stats, TypeStat{k.(reflect.Type), int(n), int(c.bytes.Load())})
+		}
+
+		return true
+	})
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Bytes != stats[j].Bytes {
+			return stats[i].Bytes > stats[j].Bytes
+		}
+
+		return stats[i].Type.String() < stats[j].Type.String()
+	})
+
+	return stats
+}
+
+// ResetTypeStats clears the statistics returned by [TypeStats].
+func ResetTypeStats() {
+	typeCounters.Range(func(_, v any) bool {
+		c := v.(*typeCounter)
+		c.count.Store(0)
+		c.bytes.Store(0)
+
+		return true
+	})
+}
+
+// countType counts a value of type T allocated with size bytes.
+func countType[T any](size int) {
+	t := reflect.TypeFor[T]()
+
+	v, ok := typeCounters.Load(t)
+	if !ok {
+		v, _ = typeCounters.LoadOrStore(t, new(typeCounter))
+	}
+
+	c := v.(*typeCounter)
+	c.count.Add(1)
+	c.bytes.Add(int64(size))
+}
@@ -0,0 +1,63 @@
+//go:build go1.22
+
+package arena_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/arena"
+)
+
+type statPoint struct {
+	X, Y int64
+}
+
+func TestTypeStats(t *testing.T) {
+	Convey("Given values allocated with New", t, func() {
+		arena.ResetTypeStats()
+
+		a := new(arena.Arena)
+
+		for i := 0; i < 3; i++ {
+			arena.New(a, statPoint{int64(i), int64(i)})
+		}
+
+		arena.New(a, int32(1))
+
+		if !arena.TypeStatsEnabled {
+			Convey("Then no statistics should be reported without the arenastats tag", func() {
+				So(arena.TypeStats(), ShouldBeEmpty)
+			})
+
+			return
+		}
+
+		Convey("Then they should be counted per type", func() {
+			stats := arena.TypeStats()
+
+			So(len(stats), ShouldBeGreaterThanOrEqualTo, 2)
+			So(stats[0], ShouldResemble, arena.TypeStat{Type: reflect.TypeFor[statPoint](), Count: 3, Bytes: 48})
+
+			found := false
+			for _, s := range stats {
+				if s.Type == reflect.TypeFor[int32]() {
+					So(s.Count, ShouldEqual, 1)
+					So(s.Bytes, ShouldEqual, 4)
+
+					found = true
+				}
+			}
+
+			So(found, ShouldBeTrue)
+		})
+
+		Convey("Then they should be cleared by ResetTypeStats", func() {
+			arena.ResetTypeStats()
+
+			So(arena.TypeStats(), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,36 @@
+//go:build go1.20
+
+package opt
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var _ fmt.Formatter = Option[int]{}
+
+// Format implements the [fmt.Formatter] interface, so that an Option never leaks its
+// internal representation.
+//
+// A None is formatted as "None", and a Some value as "Some(value)" with the value
+// formatted by the same verb and flags, e.g. %+v formats the field names of a struct.
+// The %#v verb formats the Go syntax, e.g. "opt.Some[int](1)" and "opt.None[int]()".
+func (o Option[T]) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		t := reflect.TypeOf((*T)(nil)).Elem()
+
+		if o.IsNone() {
+			_, _ = fmt.Fprintf(state, "opt.None[%v]()", t)
+		} else {
+			_, _ = fmt.Fprintf(state, "opt.Some[%v](%#v)", t, o.unwrap())
+		}
+
+		return
+	}
+
+	if o.IsNone() {
+		_, _ = fmt.Fprint(state, "None")
+	} else {
+		_, _ = fmt.Fprintf(state, "Some("+fmt.FormatString(state, verb)+")", o.unwrap())
+	}
+}
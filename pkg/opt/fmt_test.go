@@ -0,0 +1,45 @@
+//go:build go1.20
+
+package opt_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/opt"
+)
+
+type point struct{ X, Y int }
+
+func TestOption_Format(t *testing.T) {
+	Convey("Given options", t, func() {
+		some, none := Some(point{1, 2}), None[point]()
+
+		Convey("They should be formatted without their internals", func() {
+			So(fmt.Sprintf("%v", some), ShouldEqual, "Some({1 2})")
+			So(fmt.Sprintf("%+v", some), ShouldEqual, "Some({X:1 Y:2})")
+			So(fmt.Sprintf("%v", none), ShouldEqual, "None")
+			So(fmt.Sprintf("%+v", none), ShouldEqual, "None")
+		})
+
+		Convey("They should be formatted in Go syntax", func() {
+			So(fmt.Sprintf("%#v", some), ShouldEqual, "opt.Some[opt_test.point](opt_test.point{X:1, Y:2})")
+			So(fmt.Sprintf("%#v", none), ShouldEqual, "opt.None[opt_test.point]()")
+			So(fmt.Sprintf("%#v", Some("foo")), ShouldEqual, `opt.Some[string]("foo")`)
+		})
+
+		Convey("The verb and flags should apply to the value", func() {
+			So(fmt.Sprintf("%x", Some(255)), ShouldEqual, "Some(ff)")
+			So(fmt.Sprintf("%03d", Some(7)), ShouldEqual, "Some(007)")
+			So(fmt.Sprintf("%q", Some("foo")), ShouldEqual, `Some("foo")`)
+			So(fmt.Sprintf("%d", None[int]()), ShouldEqual, "None")
+		})
+
+		Convey("They should be formatted inside other values", func() {
+			So(fmt.Sprint([]Option[int]{Some(1), None[int]()}), ShouldEqual, "[Some(1) None]")
+			So(fmt.Sprintf("%+v", struct{ P Option[int] }{Some(1)}), ShouldEqual, "{P:Some(1)}")
+		})
+	})
+}
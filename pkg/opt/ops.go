@@ -184,6 +184,11 @@ func ZipWith[T, U, R any](x Option[T], y Option[U], f func(T, U) R) Option[R] {
 	return None[R]()
 }
 
+// Maps x and y Option to an Option of the result of function f, same as [ZipWith].
+//
+// If x is Some(s) and y is Some(o), this method returns Some(f(s, o)). Otherwise, None is returned.
+func Map2[T, U, R any](x Option[T], y Option[U], f func(T, U) R) Option[R] { return ZipWith(x, y, f) }
+
 // Unzips an option containing a tuple of two options.
 //
 // If x is Some((a, b)) this method returns (Some(a), Some(b)). Otherwise, (None, None) is returned.
@@ -230,6 +230,12 @@ func TestOps(t *testing.T) {
 			So(ZipWith(none, none, mul), ShouldEqual, none)
 		})
 
+		Convey("When map two optoins", func() {
+			So(Map2(some, some2, mul), ShouldEqual, Some(123*456))
+			So(Map2(some, none, mul), ShouldEqual, none)
+			So(Map2(none, some2, mul), ShouldEqual, none)
+		})
+
 		Convey("When unzip to two optoins", func() {
 			x, y := Unzip(Some(tuple.New2(123, "foobar")))
 			So(x, ShouldEqual, some)
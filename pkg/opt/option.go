@@ -39,7 +39,7 @@ func (o Option[T]) IsNone() bool { return o.val == nil }
 // Returns true if the option is a None or the value inside of it matches a predicate.
 func (o Option[T]) IsNoneOr(f func(T) bool) bool { return o.IsNone() || f(o.unwrap()) }
 
-// Returns the contained Some value, orp panics if the value is a None with a custom panic message provided by msg.
+// Returns the contained Some value, or panics if the value is a None with a custom panic message provided by msg.
 func (o Option[T]) Expect(msg string) T {
 	if o.IsNone() {
 		panic(msg)
@@ -48,6 +48,27 @@ func (o Option[T]) Expect(msg string) T {
 	return o.unwrap()
 }
 
+// Returns the contained Some value, or panics if the value is a None with a custom panic message
+// formatted according to format and args.
+func (o Option[T]) Expectf(format string, args ...any) T {
+	if o.IsNone() {
+		panic(fmt.Sprintf(format, args...))
+	}
+
+	return o.unwrap()
+}
+
+// Returns the value of a function returning a comma-ok pair, or panics if ok is false.
+//
+//	home := opt.MustSome(os.LookupEnv("HOME")) // Panics if HOME is not set.
+func MustSome[T any](value T, ok bool) T {
+	if !ok {
+		panic(fmt.Sprintf("called `opt.MustSome()` without a `%T` value", value))
+	}
+
+	return value
+}
+
 // Returns the contained Some value.
 func (o Option[T]) Unwrap() T {
 	return o.Expect("called `Option.Unwrap()` on a `None` value")
@@ -62,6 +83,9 @@ func (o Option[T]) UnwrapOr(def T) T {
 	return o.unwrap()
 }
 
+// Returns the contained Some value or a provided default, same as [Option.UnwrapOr].
+func (o Option[T]) GetOr(def T) T { return o.UnwrapOr(def) }
+
 // Returns the contained Some value or computes it from a closure.
 func (o Option[T]) UnwrapOrElse(f func() T) T {
 	if o.val == nil {
@@ -71,6 +95,22 @@ func (o Option[T]) UnwrapOrElse(f func() T) T {
 	return o.unwrap()
 }
 
+// Returns the contained Some value, or the zero value and err if the value is a None.
+//
+// It bridges an option into idiomatic Go error handling:
+//
+//	v, err := o.UnwrapOrErr(ErrNotFound)
+//	if err != nil {
+//	    return err
+//	}
+func (o Option[T]) UnwrapOrErr(err error) (v T, _ error) {
+	if o.val == nil {
+		return v, err
+	}
+
+	return o.unwrap(), nil
+}
+
 // Returns the contained Some value or a default.
 func (o Option[T]) UnwrapOrDefault() (v T) {
 	if o.val != nil {
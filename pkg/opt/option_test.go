@@ -1,6 +1,7 @@
 package opt_test
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -23,8 +24,14 @@ func TestOption(t *testing.T) {
 			So(some.Expect("some value"), ShouldEqual, 123)
 			So(some.Unwrap(), ShouldEqual, 123)
 			So(some.UnwrapOr(456), ShouldEqual, 123)
+			So(some.GetOr(456), ShouldEqual, 123)
 			So(some.UnwrapOrElse(func() int { return 456 }), ShouldEqual, 123)
 			So(some.UnwrapOrDefault(), ShouldEqual, 123)
+			So(some.Expectf("value of %s", "key"), ShouldEqual, 123)
+
+			v, err := some.UnwrapOrErr(errors.New("no value"))
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, 123)
 
 			n := 123
 			So(Wrap(&n), ShouldEqual, some)
@@ -43,10 +50,35 @@ func TestOption(t *testing.T) {
 			So(func() { none.Unwrap() }, ShouldPanic)
 			So(func() { none.Expect("no value") }, ShouldPanicWith, "no value")
 			So(none.UnwrapOr(456), ShouldEqual, 456)
+			So(none.GetOr(456), ShouldEqual, 456)
 			So(none.UnwrapOrElse(func() int { return 456 }), ShouldEqual, 456)
 			So(none.UnwrapOrDefault(), ShouldEqual, 0)
+			So(func() { none.Expectf("no value of %s", "key") }, ShouldPanicWith, "no value of key")
+
+			errNoValue := errors.New("no value")
+			v, err := none.UnwrapOrErr(errNoValue)
+			So(err, ShouldEqual, errNoValue)
+			So(v, ShouldEqual, 0)
 
 			So(Wrap[int](nil), ShouldEqual, none)
 		})
 	})
 }
+
+func TestMustSome(t *testing.T) {
+	Convey("Given a comma-ok lookup", t, func() {
+		m := map[string]int{"foo": 123}
+		lookup := func(key string) (v int, ok bool) {
+			v, ok = m[key]
+			return
+		}
+
+		Convey("It should return the value of a present key", func() {
+			So(MustSome(lookup("foo")), ShouldEqual, 123)
+		})
+
+		Convey("It should panic on a missing key", func() {
+			So(func() { MustSome(lookup("bar")) }, ShouldPanicWith, "called `opt.MustSome()` without a `int` value")
+		})
+	})
+}
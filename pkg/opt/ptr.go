@@ -0,0 +1,26 @@
+package opt
+
+// Converts a pointer-for-optional value, as used by protobuf or database/sql, into an Option.
+//
+// Returns None if p is nil, otherwise Some with a copy of the value p points to.
+// Unlike [Wrap], the option doesn't share the memory p points to.
+func FromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+
+	return Some(*p)
+}
+
+// Converts the option into a pointer-for-optional value, as used by protobuf or database/sql.
+//
+// Returns nil if the option is a None, otherwise a pointer to a copy of the contained value.
+func (o Option[T]) Ptr() *T {
+	if o.IsNone() {
+		return nil
+	}
+
+	v := o.unwrap()
+
+	return &v
+}
@@ -0,0 +1,63 @@
+package opt_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/opt"
+)
+
+func ExampleFromPtr() {
+	type Request struct {
+		Name  string
+		Limit *int
+	}
+
+	limit := 10
+
+	fmt.Println(FromPtr(Request{"foo", &limit}.Limit))
+	fmt.Println(FromPtr(Request{"bar", nil}.Limit))
+
+	// Output:
+	// Some(10)
+	// None
+}
+
+func TestPtr(t *testing.T) {
+	Convey("Given a pointer", t, func() {
+		n := 123
+
+		Convey("When converting it to an option", func() {
+			o := FromPtr(&n)
+
+			So(o, ShouldEqual, Some(123))
+			So(FromPtr[int](nil), ShouldEqual, None[int]())
+
+			Convey("Then the option should not share the memory", func() {
+				n = 456
+
+				So(o.Unwrap(), ShouldEqual, 123)
+			})
+		})
+	})
+
+	Convey("Given an option", t, func() {
+		some := Some(123)
+
+		Convey("When converting it to a pointer", func() {
+			p := some.Ptr()
+
+			So(p, ShouldNotBeNil)
+			So(*p, ShouldEqual, 123)
+			So(None[int]().Ptr(), ShouldBeNil)
+
+			Convey("Then the pointer should not share the memory", func() {
+				*p = 456
+
+				So(some.Unwrap(), ShouldEqual, 123)
+			})
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build go1.21
+
+package opt
+
+import "log/slog"
+
+var _ slog.LogValuer = Option[int]{}
+
+// LogValue implements the [slog.LogValuer] interface, so that an Option is logged
+// as "Some(value)" or "None" instead of its internal representation.
+//
+// The value is resolved first, so a value implementing [slog.LogValuer] itself,
+// e.g. to redact a secret, is logged by its own LogValue.
+func (o Option[T]) LogValue() slog.Value {
+	if o.IsNone() {
+		return slog.StringValue("None")
+	}
+
+	return slog.StringValue("Some(" + slog.AnyValue(o.unwrap()).Resolve().String() + ")")
+}
@@ -0,0 +1,41 @@
+//go:build go1.21
+
+package opt_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/opt"
+)
+
+type secret string
+
+func (secret) LogValue() slog.Value { return slog.StringValue("***") }
+
+func TestOption_LogValue(t *testing.T) {
+	Convey("Given a logger", t, func() {
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+
+				return a
+			},
+		}))
+
+		Convey("When logging options", func() {
+			logger.Info("msg", "some", Some(123), "none", None[int](), "secret", Some(secret("password")))
+
+			Convey("Then they should be logged without their internals", func() {
+				So(buf.String(), ShouldEqual, "level=INFO msg=msg some=Some(123) none=None secret=Some(***)\n")
+			})
+		})
+	})
+}
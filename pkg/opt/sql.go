@@ -0,0 +1,46 @@
+//go:build go1.22
+
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+var (
+	_ sql.Scanner   = (*Option[int64])(nil)
+	_ driver.Valuer = Option[int64]{}
+)
+
+// Scan implements the [sql.Scanner] interface, so that a nullable column can be scanned
+// straight into an Option.
+//
+// A NULL value is scanned as None, any other value is converted to T like [sql.Null] does,
+// which supports the common types such as string, int64, float64, bool and time.Time.
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+
+	if n.Valid {
+		*o = Some(n.V)
+	} else {
+		*o = None[T]()
+	}
+
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface, so that an Option can be used as a query argument.
+//
+// A None is passed as NULL, a Some value is converted with [driver.DefaultParameterConverter],
+// so for example an Option[int] is passed as an int64.
+func (o Option[T]) Value() (driver.Value, error) {
+	if o.IsNone() {
+		return nil, nil
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(o.unwrap())
+}
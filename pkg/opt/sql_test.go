@@ -0,0 +1,127 @@
+//go:build go1.22
+
+package opt_test
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/opt"
+)
+
+func TestOption_Scan(t *testing.T) {
+	Convey("Given an option", t, func() {
+		Convey("When scanning a NULL value", func() {
+			o := Some("foo")
+
+			So(o.Scan(nil), ShouldBeNil)
+			So(o.IsNone(), ShouldBeTrue)
+		})
+
+		Convey("When scanning a string", func() {
+			var o Option[string]
+
+			So(o.Scan("foo"), ShouldBeNil)
+			So(o, ShouldResemble, Some("foo"))
+
+			So(o.Scan([]byte("bar")), ShouldBeNil)
+			So(o, ShouldResemble, Some("bar"))
+		})
+
+		Convey("When scanning a number", func() {
+			var i Option[int64]
+
+			So(i.Scan(int64(123)), ShouldBeNil)
+			So(i, ShouldResemble, Some(int64(123)))
+
+			So(i.Scan("456"), ShouldBeNil)
+			So(i, ShouldResemble, Some(int64(456)))
+
+			var f Option[float64]
+
+			So(f.Scan(1.5), ShouldBeNil)
+			So(f, ShouldResemble, Some(1.5))
+
+			var n Option[int]
+
+			So(n.Scan(int64(7)), ShouldBeNil)
+			So(n, ShouldResemble, Some(7))
+		})
+
+		Convey("When scanning a bool", func() {
+			var o Option[bool]
+
+			So(o.Scan(true), ShouldBeNil)
+			So(o, ShouldResemble, Some(true))
+
+			So(o.Scan(int64(0)), ShouldBeNil)
+			So(o, ShouldResemble, Some(false))
+		})
+
+		Convey("When scanning a time", func() {
+			now := time.Now()
+
+			var o Option[time.Time]
+
+			So(o.Scan(now), ShouldBeNil)
+			So(o.Unwrap().Equal(now), ShouldBeTrue)
+		})
+
+		Convey("When scanning a value of a mismatched type", func() {
+			o := Some(int64(123))
+
+			So(o.Scan("foo"), ShouldNotBeNil)
+			So(o, ShouldResemble, Some(int64(123)))
+		})
+	})
+}
+
+func TestOption_Value(t *testing.T) {
+	Convey("Given an option", t, func() {
+		Convey("When it is None", func() {
+			v, err := None[string]().Value()
+
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+		})
+
+		Convey("When it is Some", func() {
+			for _, tc := range []struct {
+				o driver.Valuer
+				v driver.Value
+			}{
+				{Some("foo"), "foo"},
+				{Some(int64(123)), int64(123)},
+				{Some(123), int64(123)},
+				{Some(uint8(8)), int64(8)},
+				{Some(1.5), 1.5},
+				{Some(float32(0.5)), 0.5},
+				{Some(true), true},
+				{Some([]byte("bar")), []byte("bar")},
+			} {
+				v, err := tc.o.Value()
+
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, tc.v)
+			}
+		})
+
+		Convey("When it is a time", func() {
+			now := time.Now()
+
+			v, err := Some(now).Value()
+
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, now)
+		})
+
+		Convey("When it is an unsupported type", func() {
+			_, err := Some(struct{}{}).Value()
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,235 @@
+package tuple
+
+// Binder1 converts between a struct S and a [Tuple1] without reflection.
+//
+// A binder is built from a function that returns pointers to the struct fields,
+// which is used both to read the fields into a tuple and to write a tuple back
+// into the fields. This keeps hot paths, such as scanning SQL rows into composite
+// keys, free of reflection and allocations.
+//
+// Example:
+//
+//	type User struct {
+//		ID   int64
+//		Name string
+//	}
+//
+//	b := tuple.Bind2(func(u *User) (*int64, *string) { return &u.ID, &u.Name })
+//
+//	t := b.FromStruct(&User{1, "foo"}) // (1, foo)
+//
+//	var u User
+//	b.ToStruct(&u, t)
+type Binder1[S, T0 any] func(s *S) *T0
+
+// Bind1 returns a [Binder1] which uses f to locate the fields of the struct.
+func Bind1[S, T0 any](f func(s *S) *T0) Binder1[S, T0] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder1[S, T0]) FromStruct(s *S) Tuple1[T0] {
+	f0 := b(s)
+
+	return Tuple1[T0]{*f0}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder1[S, T0]) ToStruct(s *S, t Tuple1[T0]) {
+	f0 := b(s)
+
+	*f0 = t.V0
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder1[S, T0]) Fields(s *S) []any {
+	f0 := b(s)
+
+	return []any{f0}
+}
+
+// Binder2 converts between a struct S and a [Tuple2] without reflection.
+//
+// See [Binder1] for details.
+type Binder2[S, T0, T1 any] func(s *S) (*T0, *T1)
+
+// Bind2 returns a [Binder2] which uses f to locate the fields of the struct.
+func Bind2[S, T0, T1 any](f func(s *S) (*T0, *T1)) Binder2[S, T0, T1] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder2[S, T0, T1]) FromStruct(s *S) Tuple2[T0, T1] {
+	f0, f1 := b(s)
+
+	return Tuple2[T0, T1]{*f0, *f1}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder2[S, T0, T1]) ToStruct(s *S, t Tuple2[T0, T1]) {
+	f0, f1 := b(s)
+
+	*f0, *f1 = t.V0, t.V1
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder2[S, T0, T1]) Fields(s *S) []any {
+	f0, f1 := b(s)
+
+	return []any{f0, f1}
+}
+
+// Binder3 converts between a struct S and a [Tuple3] without reflection.
+//
+// See [Binder1] for details.
+type Binder3[S, T0, T1, T2 any] func(s *S) (*T0, *T1, *T2)
+
+// Bind3 returns a [Binder3] which uses f to locate the fields of the struct.
+func Bind3[S, T0, T1, T2 any](f func(s *S) (*T0, *T1, *T2)) Binder3[S, T0, T1, T2] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder3[S, T0, T1, T2]) FromStruct(s *S) Tuple3[T0, T1, T2] {
+	f0, f1, f2 := b(s)
+
+	return Tuple3[T0, T1, T2]{*f0, *f1, *f2}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder3[S, T0, T1, T2]) ToStruct(s *S, t Tuple3[T0, T1, T2]) {
+	f0, f1, f2 := b(s)
+
+	*f0, *f1, *f2 = t.V0, t.V1, t.V2
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder3[S, T0, T1, T2]) Fields(s *S) []any {
+	f0, f1, f2 := b(s)
+
+	return []any{f0, f1, f2}
+}
+
+// Binder4 converts between a struct S and a [Tuple4] without reflection.
+//
+// See [Binder1] for details.
+type Binder4[S, T0, T1, T2, T3 any] func(s *S) (*T0, *T1, *T2, *T3)
+
+// Bind4 returns a [Binder4] which uses f to locate the fields of the struct.
+func Bind4[S, T0, T1, T2, T3 any](f func(s *S) (*T0, *T1, *T2, *T3)) Binder4[S, T0, T1, T2, T3] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder4[S, T0, T1, T2, T3]) FromStruct(s *S) Tuple4[T0, T1, T2, T3] {
+	f0, f1, f2, f3 := b(s)
+
+	return Tuple4[T0, T1, T2, T3]{*f0, *f1, *f2, *f3}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder4[S, T0, T1, T2, T3]) ToStruct(s *S, t Tuple4[T0, T1, T2, T3]) {
+	f0, f1, f2, f3 := b(s)
+
+	*f0, *f1, *f2, *f3 = t.V0, t.V1, t.V2, t.V3
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder4[S, T0, T1, T2, T3]) Fields(s *S) []any {
+	f0, f1, f2, f3 := b(s)
+
+	return []any{f0, f1, f2, f3}
+}
+
+// Binder5 converts between a struct S and a [Tuple5] without reflection.
+//
+// See [Binder1] for details.
+type Binder5[S, T0, T1, T2, T3, T4 any] func(s *S) (*T0, *T1, *T2, *T3, *T4)
+
+// Bind5 returns a [Binder5] which uses f to locate the fields of the struct.
+func Bind5[S, T0, T1, T2, T3, T4 any](f func(s *S) (*T0, *T1, *T2, *T3, *T4)) Binder5[S, T0, T1, T2, T3, T4] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder5[S, T0, T1, T2, T3, T4]) FromStruct(s *S) Tuple5[T0, T1, T2, T3, T4] {
+	f0, f1, f2, f3, f4 := b(s)
+
+	return Tuple5[T0, T1, T2, T3, T4]{*f0, *f1, *f2, *f3, *f4}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder5[S, T0, T1, T2, T3, T4]) ToStruct(s *S, t Tuple5[T0, T1, T2, T3, T4]) {
+	f0, f1, f2, f3, f4 := b(s)
+
+	*f0, *f1, *f2, *f3, *f4 = t.V0, t.V1, t.V2, t.V3, t.V4
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder5[S, T0, T1, T2, T3, T4]) Fields(s *S) []any {
+	f0, f1, f2, f3, f4 := b(s)
+
+	return []any{f0, f1, f2, f3, f4}
+}
+
+// Binder6 converts between a struct S and a [Tuple6] without reflection.
+//
+// See [Binder1] for details.
+type Binder6[S, T0, T1, T2, T3, T4, T5 any] func(s *S) (*T0, *T1, *T2, *T3, *T4, *T5)
+
+// Bind6 returns a [Binder6] which uses f to locate the fields of the struct.
+func Bind6[S, T0, T1, T2, T3, T4, T5 any](f func(s *S) (*T0, *T1, *T2, *T3, *T4, *T5)) Binder6[S, T0, T1, T2, T3, T4, T5] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder6[S, T0, T1, T2, T3, T4, T5]) FromStruct(s *S) Tuple6[T0, T1, T2, T3, T4, T5] {
+	f0, f1, f2, f3, f4, f5 := b(s)
+
+	return Tuple6[T0, T1, T2, T3, T4, T5]{*f0, *f1, *f2, *f3, *f4, *f5}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder6[S, T0, T1, T2, T3, T4, T5]) ToStruct(s *S, t Tuple6[T0, T1, T2, T3, T4, T5]) {
+	f0, f1, f2, f3, f4, f5 := b(s)
+
+	*f0, *f1, *f2, *f3, *f4, *f5 = t.V0, t.V1, t.V2, t.V3, t.V4, t.V5
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder6[S, T0, T1, T2, T3, T4, T5]) Fields(s *S) []any {
+	f0, f1, f2, f3, f4, f5 := b(s)
+
+	return []any{f0, f1, f2, f3, f4, f5}
+}
+
+// Binder7 converts between a struct S and a [Tuple7] without reflection.
+//
+// See [Binder1] for details.
+type Binder7[S, T0, T1, T2, T3, T4, T5, T6 any] func(s *S) (*T0, *T1, *T2, *T3, *T4, *T5, *T6)
+
+// Bind7 returns a [Binder7] which uses f to locate the fields of the struct.
+func Bind7[S, T0, T1, T2, T3, T4, T5, T6 any](f func(s *S) (*T0, *T1, *T2, *T3, *T4, *T5, *T6)) Binder7[S, T0, T1, T2, T3, T4, T5, T6] {
+	return f
+}
+
+// FromStruct reads the fields of s into a tuple.
+func (b Binder7[S, T0, T1, T2, T3, T4, T5, T6]) FromStruct(s *S) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	f0, f1, f2, f3, f4, f5, f6 := b(s)
+
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{*f0, *f1, *f2, *f3, *f4, *f5, *f6}
+}
+
+// ToStruct writes the elements of t into the fields of s.
+func (b Binder7[S, T0, T1, T2, T3, T4, T5, T6]) ToStruct(s *S, t Tuple7[T0, T1, T2, T3, T4, T5, T6]) {
+	f0, f1, f2, f3, f4, f5, f6 := b(s)
+
+	*f0, *f1, *f2, *f3, *f4, *f5, *f6 = t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6
+}
+
+// Fields returns the pointers to the fields of s, e.g. as destinations of [database/sql.Rows.Scan].
+func (b Binder7[S, T0, T1, T2, T3, T4, T5, T6]) Fields(s *S) []any {
+	f0, f1, f2, f3, f4, f5, f6 := b(s)
+
+	return []any{f0, f1, f2, f3, f4, f5, f6}
+}
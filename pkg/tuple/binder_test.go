@@ -0,0 +1,74 @@
+package tuple_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/tuple"
+)
+
+type user struct {
+	ID    int64
+	Name  string
+	Email string
+}
+
+func ExampleBind2() {
+	b := Bind2(func(u *user) (*int64, *string) { return &u.ID, &u.Name })
+
+	t := b.FromStruct(&user{ID: 1, Name: "foo"})
+	fmt.Println(t)
+
+	var u user
+	b.ToStruct(&u, New2(int64(2), "bar"))
+	fmt.Println(u.ID, u.Name)
+
+	// Output:
+	// (1, foo)
+	// 2 bar
+}
+
+func TestBinder(t *testing.T) {
+	Convey("Given a binder for a struct", t, func() {
+		b := Bind3(func(u *user) (*int64, *string, *string) { return &u.ID, &u.Name, &u.Email })
+
+		Convey("When converting a struct to a tuple", func() {
+			u := user{1, "foo", "foo@example.com"}
+
+			So(b.FromStruct(&u), ShouldResemble, New3(int64(1), "foo", "foo@example.com"))
+		})
+
+		Convey("When converting a tuple to a struct", func() {
+			var u user
+			b.ToStruct(&u, New3(int64(2), "bar", "bar@example.com"))
+
+			So(u, ShouldResemble, user{2, "bar", "bar@example.com"})
+		})
+
+		Convey("When getting the fields of a struct", func() {
+			var u user
+			fields := b.Fields(&u)
+
+			So(fields, ShouldHaveLength, 3)
+
+			*fields[0].(*int64) = 3
+			*fields[1].(*string) = "baz"
+
+			So(u.ID, ShouldEqual, 3)
+			So(u.Name, ShouldEqual, "baz")
+		})
+
+		Convey("When converting in a round trip", func() {
+			u := user{4, "qux", "qux@example.com"}
+
+			n := testing.AllocsPerRun(100, func() {
+				b.ToStruct(&u, b.FromStruct(&u))
+			})
+
+			So(n, ShouldEqual, 0)
+			So(u, ShouldResemble, user{4, "qux", "qux@example.com"})
+		})
+	})
+}
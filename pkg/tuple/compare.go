@@ -0,0 +1,192 @@
+//go:build go1.21
+
+package tuple
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Compare1 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare1[T0 cmp.Ordered](a, b Tuple1[T0]) int {
+	return cmp.Compare(a.V0, b.V0)
+}
+
+// Less1 returns true if a is lexicographically less than b, see [Compare1].
+func Less1[T0 cmp.Ordered](a, b Tuple1[T0]) bool { return Compare1(a, b) < 0 }
+
+// Compare2 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare2[T0, T1 cmp.Ordered](a, b Tuple2[T0, T1]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V1, b.V1)
+}
+
+// Less2 returns true if a is lexicographically less than b, see [Compare2].
+func Less2[T0, T1 cmp.Ordered](a, b Tuple2[T0, T1]) bool { return Compare2(a, b) < 0 }
+
+// Compare3 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare3[T0, T1, T2 cmp.Ordered](a, b Tuple3[T0, T1, T2]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V1, b.V1); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V2, b.V2)
+}
+
+// Less3 returns true if a is lexicographically less than b, see [Compare3].
+func Less3[T0, T1, T2 cmp.Ordered](a, b Tuple3[T0, T1, T2]) bool { return Compare3(a, b) < 0 }
+
+// Compare4 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare4[T0, T1, T2, T3 cmp.Ordered](a, b Tuple4[T0, T1, T2, T3]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V1, b.V1); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V2, b.V2); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V3, b.V3)
+}
+
+// Less4 returns true if a is lexicographically less than b, see [Compare4].
+func Less4[T0, T1, T2, T3 cmp.Ordered](a, b Tuple4[T0, T1, T2, T3]) bool { return Compare4(a, b) < 0 }
+
+// Compare5 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare5[T0, T1, T2, T3, T4 cmp.Ordered](a, b Tuple5[T0, T1, T2, T3, T4]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V1, b.V1); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V2, b.V2); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V3, b.V3); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V4, b.V4)
+}
+
+// Less5 returns true if a is lexicographically less than b, see [Compare5].
+func Less5[T0, T1, T2, T3, T4 cmp.Ordered](a, b Tuple5[T0, T1, T2, T3, T4]) bool {
+	return Compare5(a, b) < 0
+}
+
+// Compare6 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare6[T0, T1, T2, T3, T4, T5 cmp.Ordered](a, b Tuple6[T0, T1, T2, T3, T4, T5]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V1, b.V1); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V2, b.V2); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V3, b.V3); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V4, b.V4); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V5, b.V5)
+}
+
+// Less6 returns true if a is lexicographically less than b, see [Compare6].
+func Less6[T0, T1, T2, T3, T4, T5 cmp.Ordered](a, b Tuple6[T0, T1, T2, T3, T4, T5]) bool {
+	return Compare6(a, b) < 0
+}
+
+// Compare7 compares two tuples lexicographically, element by element.
+//
+// It returns -1 if a is less than b, 0 if they are equal, and +1 if a is greater than b.
+func Compare7[T0, T1, T2, T3, T4, T5, T6 cmp.Ordered](a, b Tuple7[T0, T1, T2, T3, T4, T5, T6]) int {
+	if c := cmp.Compare(a.V0, b.V0); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V1, b.V1); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V2, b.V2); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V3, b.V3); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V4, b.V4); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.V5, b.V5); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.V6, b.V6)
+}
+
+// Less7 returns true if a is lexicographically less than b, see [Compare7].
+func Less7[T0, T1, T2, T3, T4, T5, T6 cmp.Ordered](a, b Tuple7[T0, T1, T2, T3, T4, T5, T6]) bool {
+	return Compare7(a, b) < 0
+}
+
+// Sorter adapts a slice and a less function to [sort.Interface].
+type Sorter[T any] struct {
+	S        []T
+	LessFunc func(a, b T) bool
+}
+
+var _ sort.Interface = Sorter[int]{}
+
+func (s Sorter[T]) Len() int           { return len(s.S) }
+func (s Sorter[T]) Less(i, j int) bool { return s.LessFunc(s.S[i], s.S[j]) }
+func (s Sorter[T]) Swap(i, j int)      { s.S[i], s.S[j] = s.S[j], s.S[i] }
+
+// SortSlice sorts a slice of tuples in place with the given less function, such as
+// one of the LessN functions, keeping the equal tuples in their original order.
+//
+// Example:
+//
+//	rows := []tuple.Tuple2[string, int]{tuple.New2("b", 1), tuple.New2("a", 2), tuple.New2("a", 1)}
+//
+//	tuple.SortSlice(rows, tuple.Less2) // [(a, 1) (a, 2) (b, 1)]
+func SortSlice[T any](s []T, less func(a, b T) bool) {
+	sort.Stable(Sorter[T]{s, less})
+}
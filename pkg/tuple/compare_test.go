@@ -0,0 +1,54 @@
+//go:build go1.21
+
+package tuple_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/tuple"
+)
+
+func ExampleSortSlice() {
+	rows := []Tuple2[string, int]{New2("b", 1), New2("a", 2), New2("a", 1)}
+
+	SortSlice(rows, Less2)
+
+	fmt.Println(rows)
+
+	// Output:
+	// [(a, 1) (a, 2) (b, 1)]
+}
+
+func TestCompare(t *testing.T) {
+	Convey("Given tuples of ordered elements", t, func() {
+		So(Compare1(New1(1), New1(2)), ShouldEqual, -1)
+		So(Compare1(New1("b"), New1("a")), ShouldEqual, 1)
+
+		So(Compare3(New3(1, "a", 1.5), New3(1, "a", 1.5)), ShouldEqual, 0)
+		So(Compare3(New3(1, "a", 1.5), New3(1, "b", 0.5)), ShouldEqual, -1)
+		So(Compare3(New3(2, "a", 1.5), New3(1, "b", 0.5)), ShouldEqual, 1)
+
+		So(Less7(New7(1, 2, 3, 4, 5, 6, 7), New7(1, 2, 3, 4, 5, 6, 8)), ShouldBeTrue)
+		So(Less7(New7(1, 2, 3, 4, 5, 6, 7), New7(1, 2, 3, 4, 5, 6, 7)), ShouldBeFalse)
+	})
+
+	Convey("Given a slice of tuples", t, func() {
+		rows := []Tuple2[int, string]{New2(2, "x"), New2(1, "z"), New2(2, "a"), New2(1, "z")}
+
+		Convey("Then it can be sorted through sort.Interface", func() {
+			sort.Sort(Sorter[Tuple2[int, string]]{rows, Less2[int, string]})
+
+			So(rows, ShouldResemble, []Tuple2[int, string]{New2(1, "z"), New2(1, "z"), New2(2, "a"), New2(2, "x")})
+		})
+
+		Convey("Then it can be sorted by other columns", func() {
+			SortSlice(rows, func(a, b Tuple2[int, string]) bool { return a.V1 < b.V1 })
+
+			So(rows, ShouldResemble, []Tuple2[int, string]{New2(2, "a"), New2(2, "x"), New2(1, "z"), New2(1, "z")})
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package tuple
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrArityMismatch = errors.New("arity mismatch")
+	ErrTypeMismatch  = errors.New("type mismatch")
+)
+
+// Dyn is a tuple whose arity is only known at runtime, such as a row of query results.
+//
+// Put and Del return a new tuple, leaving the original one unchanged. Use the To0..To7
+// functions to convert it to the typed forms when the arity matches.
+type Dyn []any
+
+var _ Tuple = Dyn(nil)
+
+// Of returns a dynamically sized tuple of the given values.
+func Of(values ...any) Dyn { return Dyn(values) }
+
+func (t Dyn) Len() int { return len(t) }
+
+func (t Dyn) String() string {
+	var b strings.Builder
+
+	b.WriteByte('(')
+	for i, v := range t {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%v", v)
+	}
+	b.WriteByte(')')
+
+	return b.String()
+}
+
+func (t Dyn) Get(i int) any {
+	if i < 0 || i >= len(t) {
+		panic(indexOutOfRangeError(i, t))
+	}
+
+	return t[i]
+}
+
+func (t Dyn) Put(i int, v any) (new Tuple, old any) {
+	if i < 0 || i >= len(t) {
+		panic(indexOutOfRangeError(i, t))
+	}
+
+	d := append(Dyn(nil), t...)
+	d[i] = v
+
+	return d, t[i]
+}
+
+func (t Dyn) Del(i int) Tuple {
+	if i < 0 || i >= len(t) {
+		panic(indexOutOfRangeError(i, t))
+	}
+
+	d := make(Dyn, 0, len(t)-1)
+	d = append(d, t[:i]...)
+	d = append(d, t[i+1:]...)
+
+	return d
+}
+
+func arityMismatchError(t Tuple, n int) error {
+	return fmt.Errorf("length %d, expected %d, %w", t.Len(), n, ErrArityMismatch)
+}
+
+func elem[T any](t Tuple, i int) (v T, err error) {
+	x := t.Get(i)
+	if x == nil {
+		return // A nil element converts to the zero value, e.g. a NULL column.
+	}
+
+	v, ok := x.(T)
+	if !ok {
+		err = fmt.Errorf("element %d of type %T, expected %T, %w", i, x, v, ErrTypeMismatch)
+	}
+
+	return
+}
+
+// To0 converts t to a [Tuple0] if it is empty.
+func To0(t Tuple) (Tuple0, error) {
+	if t.Len() != 0 {
+		return Tuple0{}, arityMismatchError(t, 0)
+	}
+
+	return Tuple0{}, nil
+}
+
+// To1 converts t to a [Tuple1] if the arity and the element types match.
+func To1[T0 any](t Tuple) (r Tuple1[T0], err error) {
+	if t.Len() != 1 {
+		return r, arityMismatchError(t, 1)
+	}
+
+	r.V0, err = elem[T0](t, 0)
+
+	return
+}
+
+// To2 converts t to a [Tuple2] if the arity and the element types match.
+func To2[T0, T1 any](t Tuple) (r Tuple2[T0, T1], err error) {
+	if t.Len() != 2 {
+		return r, arityMismatchError(t, 2)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+
+	r.V1, err = elem[T1](t, 1)
+
+	return
+}
+
+// To3 converts t to a [Tuple3] if the arity and the element types match.
+func To3[T0, T1, T2 any](t Tuple) (r Tuple3[T0, T1, T2], err error) {
+	if t.Len() != 3 {
+		return r, arityMismatchError(t, 3)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+	if r.V1, err = elem[T1](t, 1); err != nil {
+		return
+	}
+
+	r.V2, err = elem[T2](t, 2)
+
+	return
+}
+
+// To4 converts t to a [Tuple4] if the arity and the element types match.
+func To4[T0, T1, T2, T3 any](t Tuple) (r Tuple4[T0, T1, T2, T3], err error) {
+	if t.Len() != 4 {
+		return r, arityMismatchError(t, 4)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+	if r.V1, err = elem[T1](t, 1); err != nil {
+		return
+	}
+	if r.V2, err = elem[T2](t, 2); err != nil {
+		return
+	}
+
+	r.V3, err = elem[T3](t, 3)
+
+	return
+}
+
+// To5 converts t to a [Tuple5] if the arity and the element types match.
+func To5[T0, T1, T2, T3, T4 any](t Tuple) (r Tuple5[T0, T1, T2, T3, T4], err error) {
+	if t.Len() != 5 {
+		return r, arityMismatchError(t, 5)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+	if r.V1, err = elem[T1](t, 1); err != nil {
+		return
+	}
+	if r.V2, err = elem[T2](t, 2); err != nil {
+		return
+	}
+	if r.V3, err = elem[T3](t, 3); err != nil {
+		return
+	}
+
+	r.V4, err = elem[T4](t, 4)
+
+	return
+}
+
+// To6 converts t to a [Tuple6] if the arity and the element types match.
+func To6[T0, T1, T2, T3, T4, T5 any](t Tuple) (r Tuple6[T0, T1, T2, T3, T4, T5], err error) {
+	if t.Len() != 6 {
+		return r, arityMismatchError(t, 6)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+	if r.V1, err = elem[T1](t, 1); err != nil {
+		return
+	}
+	if r.V2, err = elem[T2](t, 2); err != nil {
+		return
+	}
+	if r.V3, err = elem[T3](t, 3); err != nil {
+		return
+	}
+	if r.V4, err = elem[T4](t, 4); err != nil {
+		return
+	}
+
+	r.V5, err = elem[T5](t, 5)
+
+	return
+}
+
+// To7 converts t to a [Tuple7] if the arity and the element types match.
+func To7[T0, T1, T2, T3, T4, T5, T6 any](t Tuple) (r Tuple7[T0, T1, T2, T3, T4, T5, T6], err error) {
+	if t.Len() != 7 {
+		return r, arityMismatchError(t, 7)
+	}
+
+	if r.V0, err = elem[T0](t, 0); err != nil {
+		return
+	}
+	if r.V1, err = elem[T1](t, 1); err != nil {
+		return
+	}
+	if r.V2, err = elem[T2](t, 2); err != nil {
+		return
+	}
+	if r.V3, err = elem[T3](t, 3); err != nil {
+		return
+	}
+	if r.V4, err = elem[T4](t, 4); err != nil {
+		return
+	}
+	if r.V5, err = elem[T5](t, 5); err != nil {
+		return
+	}
+
+	r.V6, err = elem[T6](t, 6)
+
+	return
+}
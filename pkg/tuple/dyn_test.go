@@ -0,0 +1,109 @@
+package tuple_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/tuple"
+)
+
+func ExampleOf() {
+	t := Of("hello", 42, true)
+
+	fmt.Println(t)
+	fmt.Println(t.Len(), t.Get(1))
+	fmt.Println(t.Put(1, 123))
+	fmt.Println(t.Del(0))
+	fmt.Println(To3[string, int, bool](t))
+
+	// Output:
+	// (hello, 42, true)
+	// 3 42
+	// (hello, 123, true) 42
+	// (42, true)
+	// (hello, 42, true) <nil>
+}
+
+func TestDyn(t *testing.T) {
+	Convey("Given a dynamic tuple", t, func() {
+		d := Of("hello", 42)
+
+		Convey("Then it should behave like a typed tuple", func() {
+			typed := New2("hello", 42)
+
+			So(d.String(), ShouldEqual, typed.String())
+			So(d.Len(), ShouldEqual, typed.Len())
+			So(d.Get(0), ShouldEqual, typed.Get(0))
+			So(d.Get(1), ShouldEqual, typed.Get(1))
+		})
+
+		Convey("When putting or deleting an element", func() {
+			p, old := d.Put(0, "world")
+			q := d.Del(1)
+
+			Convey("Then the original tuple should be unchanged", func() {
+				So(old, ShouldEqual, "hello")
+				So(p.String(), ShouldEqual, "(world, 42)")
+				So(q.String(), ShouldEqual, "(hello)")
+				So(d.String(), ShouldEqual, "(hello, 42)")
+			})
+		})
+
+		Convey("When accessing an element out of range", func() {
+			So(func() { d.Get(2) }, ShouldPanic)
+			So(func() { d.Get(-1) }, ShouldPanic)
+			So(func() { d.Put(2, nil) }, ShouldPanic)
+			So(func() { d.Del(2) }, ShouldPanic)
+		})
+
+		Convey("When converting to a typed tuple", func() {
+			Convey("Then matching arity and types should succeed", func() {
+				r, err := To2[string, int](d)
+
+				So(err, ShouldBeNil)
+				So(r, ShouldResemble, New2("hello", 42))
+			})
+
+			Convey("Then a mismatched arity should fail", func() {
+				_, err := To3[string, int, int](d)
+
+				So(err, ShouldWrap, ErrArityMismatch)
+			})
+
+			Convey("Then a mismatched type should fail", func() {
+				_, err := To2[string, string](d)
+
+				So(err, ShouldWrap, ErrTypeMismatch)
+			})
+
+			Convey("Then a nil element should convert to the zero value", func() {
+				r, err := To2[string, int](Of(nil, 1))
+
+				So(err, ShouldBeNil)
+				So(r, ShouldResemble, New2("", 1))
+			})
+		})
+
+		Convey("When converting a typed tuple", func() {
+			r, err := To2[string, int](New2("hello", 42))
+
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, New2("hello", 42))
+		})
+	})
+
+	Convey("Given an empty dynamic tuple", t, func() {
+		d := Of()
+
+		So(d.String(), ShouldEqual, "()")
+		So(d.Len(), ShouldEqual, 0)
+
+		_, err := To0(d)
+		So(err, ShouldBeNil)
+
+		_, err = To0(Of(1))
+		So(err, ShouldWrap, ErrArityMismatch)
+	})
+}
@@ -0,0 +1,40 @@
+package tuple
+
+// Spread1 calls fn with the element of t, and returns its result.
+func Spread1[T0, R any](t Tuple1[T0], fn func(T0) R) R {
+	return fn(t.V0)
+}
+
+// Spread2 calls fn with the elements of t as its arguments, and returns its result.
+//
+// Example:
+//
+//	sum := tuple.Spread2(tuple.New2(1, 2), func(a, b int) int { return a + b })
+func Spread2[T0, T1, R any](t Tuple2[T0, T1], fn func(T0, T1) R) R {
+	return fn(t.V0, t.V1)
+}
+
+// Spread3 calls fn with the elements of t as its arguments, and returns its result.
+func Spread3[T0, T1, T2, R any](t Tuple3[T0, T1, T2], fn func(T0, T1, T2) R) R {
+	return fn(t.V0, t.V1, t.V2)
+}
+
+// Spread4 calls fn with the elements of t as its arguments, and returns its result.
+func Spread4[T0, T1, T2, T3, R any](t Tuple4[T0, T1, T2, T3], fn func(T0, T1, T2, T3) R) R {
+	return fn(t.V0, t.V1, t.V2, t.V3)
+}
+
+// Spread5 calls fn with the elements of t as its arguments, and returns its result.
+func Spread5[T0, T1, T2, T3, T4, R any](t Tuple5[T0, T1, T2, T3, T4], fn func(T0, T1, T2, T3, T4) R) R {
+	return fn(t.V0, t.V1, t.V2, t.V3, t.V4)
+}
+
+// Spread6 calls fn with the elements of t as its arguments, and returns its result.
+func Spread6[T0, T1, T2, T3, T4, T5, R any](t Tuple6[T0, T1, T2, T3, T4, T5], fn func(T0, T1, T2, T3, T4, T5) R) R {
+	return fn(t.V0, t.V1, t.V2, t.V3, t.V4, t.V5)
+}
+
+// Spread7 calls fn with the elements of t as its arguments, and returns its result.
+func Spread7[T0, T1, T2, T3, T4, T5, T6, R any](t Tuple7[T0, T1, T2, T3, T4, T5, T6], fn func(T0, T1, T2, T3, T4, T5, T6) R) R {
+	return fn(t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6)
+}
@@ -0,0 +1,54 @@
+package tuple_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/tuple"
+)
+
+func ExampleSpread2() {
+	t := New2("answer", 42)
+
+	fmt.Println(Spread2(t, func(name string, v int) string { return name + "=" + strconv.Itoa(v) }))
+
+	// Output:
+	// answer=42
+}
+
+func TestSpread(t *testing.T) {
+	Convey("Given tuples of all arities", t, func() {
+		sum := func(vs ...int) (n int) {
+			for _, v := range vs {
+				n += v
+			}
+
+			return
+		}
+
+		So(Spread1(New1(1), func(a int) int { return sum(a) }), ShouldEqual, 1)
+		So(Spread2(New2(1, 2), func(a, b int) int { return sum(a, b) }), ShouldEqual, 3)
+		So(Spread3(New3(1, 2, 3), func(a, b, c int) int { return sum(a, b, c) }), ShouldEqual, 6)
+		So(Spread4(New4(1, 2, 3, 4), func(a, b, c, d int) int { return sum(a, b, c, d) }), ShouldEqual, 10)
+		So(Spread5(New5(1, 2, 3, 4, 5), func(a, b, c, d, e int) int {
+			return sum(a, b, c, d, e)
+		}), ShouldEqual, 15)
+		So(Spread6(New6(1, 2, 3, 4, 5, 6), func(a, b, c, d, e, f int) int {
+			return sum(a, b, c, d, e, f)
+		}), ShouldEqual, 21)
+		So(Spread7(New7(1, 2, 3, 4, 5, 6, 7), func(a, b, c, d, e, f, g int) int {
+			return sum(a, b, c, d, e, f, g)
+		}), ShouldEqual, 28)
+	})
+
+	Convey("Given a tuple of mixed types", t, func() {
+		s := Spread3(New3("x", 2, true), func(s string, n int, ok bool) string {
+			return fmt.Sprintf("%s%d%t", s, n, ok)
+		})
+
+		So(s, ShouldEqual, "x2true")
+	})
+}
@@ -82,6 +82,8 @@ func (t Tuple1[T0]) Put0(v T0) (new Tuple1[T0], old T0) {
 	return Tuple1[T0]{v}, t.V0
 }
 
+func (t Tuple1[T0]) With0(v T0) Tuple1[T0] { return Tuple1[T0]{v} }
+
 func (t Tuple1[T0]) Del(i int) Tuple {
 	if i == 0 {
 		return t.Del0()
@@ -136,6 +138,9 @@ func (t Tuple2[T0, T1]) Put1(v T1) (new Tuple2[T0, T1], old T1) {
 	return Tuple2[T0, T1]{t.V0, v}, t.V1
 }
 
+func (t Tuple2[T0, T1]) With0(v T0) Tuple2[T0, T1] { return Tuple2[T0, T1]{v, t.V1} }
+func (t Tuple2[T0, T1]) With1(v T1) Tuple2[T0, T1] { return Tuple2[T0, T1]{t.V0, v} }
+
 func (t Tuple2[T0, T1]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -205,6 +210,10 @@ func (t Tuple3[T0, T1, T2]) Put2(v T2) (new Tuple3[T0, T1, T2], old T2) {
 	return Tuple3[T0, T1, T2]{t.V0, t.V1, v}, t.V2
 }
 
+func (t Tuple3[T0, T1, T2]) With0(v T0) Tuple3[T0, T1, T2] { return Tuple3[T0, T1, T2]{v, t.V1, t.V2} }
+func (t Tuple3[T0, T1, T2]) With1(v T1) Tuple3[T0, T1, T2] { return Tuple3[T0, T1, T2]{t.V0, v, t.V2} }
+func (t Tuple3[T0, T1, T2]) With2(v T2) Tuple3[T0, T1, T2] { return Tuple3[T0, T1, T2]{t.V0, t.V1, v} }
+
 func (t Tuple3[T0, T1, T2]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -293,6 +302,19 @@ func (t Tuple4[T0, T1, T2, T3]) Put3(v T3) (new Tuple4[T0, T1, T2, T3], old T3)
 	return Tuple4[T0, T1, T2, T3]{t.V0, t.V1, t.V2, v}, t.V3
 }
 
+func (t Tuple4[T0, T1, T2, T3]) With0(v T0) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{v, t.V1, t.V2, t.V3}
+}
+func (t Tuple4[T0, T1, T2, T3]) With1(v T1) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{t.V0, v, t.V2, t.V3}
+}
+func (t Tuple4[T0, T1, T2, T3]) With2(v T2) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{t.V0, t.V1, v, t.V3}
+}
+func (t Tuple4[T0, T1, T2, T3]) With3(v T3) Tuple4[T0, T1, T2, T3] {
+	return Tuple4[T0, T1, T2, T3]{t.V0, t.V1, t.V2, v}
+}
+
 func (t Tuple4[T0, T1, T2, T3]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -401,6 +423,22 @@ func (t Tuple5[T0, T1, T2, T3, T4]) Put4(v T4) (new Tuple5[T0, T1, T2, T3, T4],
 	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, t.V2, t.V3, v}, t.V4
 }
 
+func (t Tuple5[T0, T1, T2, T3, T4]) With0(v T0) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{v, t.V1, t.V2, t.V3, t.V4}
+}
+func (t Tuple5[T0, T1, T2, T3, T4]) With1(v T1) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, v, t.V2, t.V3, t.V4}
+}
+func (t Tuple5[T0, T1, T2, T3, T4]) With2(v T2) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, v, t.V3, t.V4}
+}
+func (t Tuple5[T0, T1, T2, T3, T4]) With3(v T3) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, t.V2, v, t.V4}
+}
+func (t Tuple5[T0, T1, T2, T3, T4]) With4(v T4) Tuple5[T0, T1, T2, T3, T4] {
+	return Tuple5[T0, T1, T2, T3, T4]{t.V0, t.V1, t.V2, t.V3, v}
+}
+
 func (t Tuple5[T0, T1, T2, T3, T4]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -525,6 +563,25 @@ func (t Tuple6[T0, T1, T2, T3, T4, T5]) Put5(v T5) (new Tuple6[T0, T1, T2, T3, T
 	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, t.V3, t.V4, v}, t.V5
 }
 
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With0(v T0) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{v, t.V1, t.V2, t.V3, t.V4, t.V5}
+}
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With1(v T1) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, v, t.V2, t.V3, t.V4, t.V5}
+}
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With2(v T2) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, v, t.V3, t.V4, t.V5}
+}
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With3(v T3) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, v, t.V4, t.V5}
+}
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With4(v T4) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, t.V3, v, t.V5}
+}
+func (t Tuple6[T0, T1, T2, T3, T4, T5]) With5(v T5) Tuple6[T0, T1, T2, T3, T4, T5] {
+	return Tuple6[T0, T1, T2, T3, T4, T5]{t.V0, t.V1, t.V2, t.V3, t.V4, v}
+}
+
 func (t Tuple6[T0, T1, T2, T3, T4, T5]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -662,6 +719,28 @@ func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) Put6(v T6) (new Tuple7[T0, T1, T2, T
 	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, v}, t.V6
 }
 
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With0(v T0) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{v, t.V1, t.V2, t.V3, t.V4, t.V5, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With1(v T1) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, v, t.V2, t.V3, t.V4, t.V5, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With2(v T2) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, v, t.V3, t.V4, t.V5, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With3(v T3) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, v, t.V4, t.V5, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With4(v T4) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, v, t.V5, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With5(v T5) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, t.V4, v, t.V6}
+}
+func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) With6(v T6) Tuple7[T0, T1, T2, T3, T4, T5, T6] {
+	return Tuple7[T0, T1, T2, T3, T4, T5, T6]{t.V0, t.V1, t.V2, t.V3, t.V4, t.V5, v}
+}
+
 func (t Tuple7[T0, T1, T2, T3, T4, T5, T6]) Del(i int) Tuple {
 	switch i {
 	case 0:
@@ -790,3 +790,38 @@ func TestTuple(t *testing.T) {
 		})
 	})
 }
+
+func ExampleTuple3_With1() {
+	t := New3("hello", 42, 3.14)
+
+	fmt.Println(t.With1(7))
+	fmt.Println(t)
+
+	// Output:
+	// (hello, 7, 3.14)
+	// (hello, 42, 3.14)
+}
+
+func TestTuple_With(t *testing.T) {
+	Convey("Given a tuple", t, func() {
+		t := New7("hello", 42, 3.14, io.EOF, true, 'c', "world")
+
+		Convey("When setting each element", func() {
+			So(t.With0("foo"), ShouldEqual, New7("foo", 42, 3.14, io.EOF, true, 'c', "world"))
+			So(t.With1(7), ShouldEqual, New7("hello", 7, 3.14, io.EOF, true, 'c', "world"))
+			So(t.With2(2.71), ShouldEqual, New7("hello", 42, 2.71, io.EOF, true, 'c', "world"))
+			So(t.With3(io.ErrUnexpectedEOF), ShouldEqual, New7("hello", 42, 3.14, io.ErrUnexpectedEOF, true, 'c', "world"))
+			So(t.With4(false), ShouldEqual, New7("hello", 42, 3.14, io.EOF, false, 'c', "world"))
+			So(t.With5('x'), ShouldEqual, New7("hello", 42, 3.14, io.EOF, true, 'x', "world"))
+			So(t.With6("gopher"), ShouldEqual, New7("hello", 42, 3.14, io.EOF, true, 'c', "gopher"))
+
+			Convey("The original tuple is unchanged", func() {
+				So(t, ShouldEqual, New7("hello", 42, 3.14, io.EOF, true, 'c', "world"))
+			})
+		})
+
+		Convey("When chaining the setters", func() {
+			So(New2("a", 1).With0("b").With1(2), ShouldEqual, New2("b", 2))
+		})
+	})
+}
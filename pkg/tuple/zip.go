@@ -0,0 +1,91 @@
+package tuple
+
+// Zip2 pairs up the elements of as and bs.
+//
+// The result is as long as the shorter of the two slices, the extra elements of
+// the longer one are ignored.
+func Zip2[A, B any](as []A, bs []B) []Tuple2[A, B] {
+	n := len(as)
+	if len(bs) < n {
+		n = len(bs)
+	}
+
+	r := make([]Tuple2[A, B], n)
+
+	for i := range r {
+		r[i] = Tuple2[A, B]{as[i], bs[i]}
+	}
+
+	return r
+}
+
+// Unzip2 splits the pairs of ts into two slices of their first and second elements.
+func Unzip2[A, B any](ts []Tuple2[A, B]) ([]A, []B) {
+	as := make([]A, len(ts))
+	bs := make([]B, len(ts))
+
+	for i, t := range ts {
+		as[i], bs[i] = t.V0, t.V1
+	}
+
+	return as, bs
+}
+
+// Zip3 groups the elements of as, bs and cs into triples.
+//
+// The result is as long as the shortest of the three slices, the extra elements
+// of the longer ones are ignored.
+func Zip3[A, B, C any](as []A, bs []B, cs []C) []Tuple3[A, B, C] {
+	n := len(as)
+	if len(bs) < n {
+		n = len(bs)
+	}
+	if len(cs) < n {
+		n = len(cs)
+	}
+
+	r := make([]Tuple3[A, B, C], n)
+
+	for i := range r {
+		r[i] = Tuple3[A, B, C]{as[i], bs[i], cs[i]}
+	}
+
+	return r
+}
+
+// Unzip3 splits the triples of ts into three slices of their elements.
+func Unzip3[A, B, C any](ts []Tuple3[A, B, C]) ([]A, []B, []C) {
+	as := make([]A, len(ts))
+	bs := make([]B, len(ts))
+	cs := make([]C, len(ts))
+
+	for i, t := range ts {
+		as[i], bs[i], cs[i] = t.V0, t.V1, t.V2
+	}
+
+	return as, bs, cs
+}
+
+// ToMap converts the key-value pairs of ts into a map.
+//
+// If a key appears more than once, the last value wins.
+func ToMap[K comparable, V any](ts []Tuple2[K, V]) map[K]V {
+	m := make(map[K]V, len(ts))
+
+	for _, t := range ts {
+		m[t.V0] = t.V1
+	}
+
+	return m
+}
+
+// FromMap converts the entries of m into key-value pairs, in unspecified order.
+func FromMap[K comparable, V any](m map[K]V) []Tuple2[K, V] {
+	ts := make([]Tuple2[K, V], 0, len(m))
+
+	for k, v := range m {
+		ts = append(ts, Tuple2[K, V]{k, v})
+	}
+
+	return ts
+}
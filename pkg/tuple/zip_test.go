@@ -0,0 +1,86 @@
+package tuple_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/tuple"
+)
+
+func ExampleZip2() {
+	ts := Zip2([]string{"a", "b", "c"}, []int{1, 2})
+
+	fmt.Println(ts)
+	fmt.Println(Unzip2(ts))
+	fmt.Println(ToMap(ts))
+
+	// Output:
+	// [(a, 1) (b, 2)]
+	// [a b] [1 2]
+	// map[a:1 b:2]
+}
+
+func TestZip(t *testing.T) {
+	Convey("Given some columns", t, func() {
+		names := []string{"foo", "bar", "baz"}
+		ages := []int{1, 2, 3}
+		ok := []bool{true, false, true}
+
+		Convey("When zipping them into pairs", func() {
+			ts := Zip2(names, ages)
+
+			So(ts, ShouldResemble, []Tuple2[string, int]{New2("foo", 1), New2("bar", 2), New2("baz", 3)})
+
+			Convey("Then unzipping should give the columns back", func() {
+				n, a := Unzip2(ts)
+
+				So(n, ShouldResemble, names)
+				So(a, ShouldResemble, ages)
+			})
+		})
+
+		Convey("When zipping them into triples", func() {
+			ts := Zip3(names, ages, ok[:2])
+
+			So(ts, ShouldResemble, []Tuple3[string, int, bool]{New3("foo", 1, true), New3("bar", 2, false)})
+
+			Convey("Then unzipping should give the truncated columns back", func() {
+				n, a, o := Unzip3(ts)
+
+				So(n, ShouldResemble, names[:2])
+				So(a, ShouldResemble, ages[:2])
+				So(o, ShouldResemble, ok[:2])
+			})
+		})
+
+		Convey("When zipping empty columns", func() {
+			So(Zip2(names, []int(nil)), ShouldBeEmpty)
+
+			n, a := Unzip2[string, int](nil)
+
+			So(n, ShouldBeEmpty)
+			So(a, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given some key-value pairs", t, func() {
+		ts := []Tuple2[string, int]{New2("foo", 1), New2("bar", 2), New2("foo", 3)}
+
+		Convey("When converting them to a map", func() {
+			m := ToMap(ts)
+
+			So(m, ShouldResemble, map[string]int{"foo": 3, "bar": 2})
+
+			Convey("Then converting it back should give the distinct pairs", func() {
+				r := FromMap(m)
+
+				sort.Slice(r, func(i, j int) bool { return r[i].V0 < r[j].V0 })
+
+				So(r, ShouldResemble, []Tuple2[string, int]{New2("bar", 2), New2("foo", 3)})
+			})
+		})
+	})
+}
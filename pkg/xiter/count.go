@@ -25,3 +25,35 @@ func Count2[K, V any](x ...iter.Seq2[K, V]) (n int) {
 
 	return
 }
+
+// CountBy returns the number of elements that satisfy the predicate f.
+func CountBy[T any](x iter.Seq[T], f func(T) bool) (n int) {
+	for v := range x {
+		if f(v) {
+			n += 1
+		}
+	}
+
+	return
+}
+
+// CountByFunc returns the number of elements that satisfy the predicate f.
+func CountByFunc[T any](f func(T) bool) ReductionFunc[T, int] {
+	return bind2(CountBy, f)
+}
+
+// CountBy2 returns the number of key-values that satisfy the predicate f.
+func CountBy2[K, V any](x iter.Seq2[K, V], f func(K, V) bool) (n int) {
+	for k, v := range x {
+		if f(k, v) {
+			n += 1
+		}
+	}
+
+	return
+}
+
+// CountBy2Func returns the number of key-values that satisfy the predicate f.
+func CountBy2Func[K, V any](f func(K, V) bool) Reduction2Func[K, V, int] {
+	return bind2(CountBy2, f)
+}
@@ -27,3 +27,43 @@ func ExampleCount2() {
 
 	// Output: 2
 }
+
+func ExampleCountBy() {
+	s := slices.Values([]int{1, 2, 3, 4, 5})
+	n := CountBy(s, func(n int) bool { return n%2 == 1 })
+
+	fmt.Println(n)
+
+	// Output: 3
+}
+
+func ExampleCountByFunc() {
+	countOdd := CountByFunc(func(n int) bool { return n%2 == 1 })
+
+	s := slices.Values([]int{1, 2, 3, 4, 5})
+	n := countOdd(s)
+
+	fmt.Println(n)
+
+	// Output: 3
+}
+
+func ExampleCountBy2() {
+	s := maps.All(map[string]int{"foo": 1, "bar": 2, "hello": 3})
+	n := CountBy2(s, func(k string, v int) bool { return len(k) == 3 })
+
+	fmt.Println(n)
+
+	// Output: 2
+}
+
+func ExampleCountBy2Func() {
+	countShort := CountBy2Func(func(k string, v int) bool { return len(k) == 3 })
+
+	s := maps.All(map[string]int{"foo": 1, "bar": 2, "hello": 3})
+	n := countShort(s)
+
+	fmt.Println(n)
+
+	// Output: 2
+}
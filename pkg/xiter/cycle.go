@@ -2,13 +2,36 @@
 
 package xiter
 
-import "iter"
+import (
+	"iter"
+
+	"github.com/flier/goutil/pkg/tuple"
+)
 
 // Cycle repeats an iterator endlessly.
+//
+// The first pass over x is buffered and the following passes replay the buffer,
+// so x is only iterated once, which makes Cycle work with single-use sequences
+// such as [FromChan], at the cost of keeping all the elements of x in memory.
+// Cycle of an empty sequence is empty.
 func Cycle[T any](x iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
+		var buf []T
+
+		for v := range x {
+			if !yield(v) {
+				return
+			}
+
+			buf = append(buf, v)
+		}
+
+		if len(buf) == 0 {
+			return
+		}
+
 		for {
-			for v := range x {
+			for _, v := range buf {
 				if !yield(v) {
 					return
 				}
@@ -17,12 +40,26 @@ func Cycle[T any](x iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
-// Cycle2 repeats an iterator endlessly.
+// Cycle2 repeats an iterator endlessly, buffering it like [Cycle].
 func Cycle2[K, V any](x iter.Seq2[K, V]) iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
+		var buf []tuple.Tuple2[K, V]
+
+		for k, v := range x {
+			if !yield(k, v) {
+				return
+			}
+
+			buf = append(buf, tuple.New2(k, v))
+		}
+
+		if len(buf) == 0 {
+			return
+		}
+
 		for {
-			for k, v := range x {
-				if !yield(k, v) {
+			for _, t := range buf {
+				if !yield(t.Unpack()) {
 					return
 				}
 			}
@@ -26,3 +26,18 @@ func ExampleCycle2() {
 	fmt.Println(slices.Collect(Pairs(take6(c))))
 	// Output: [(0, 1) (1, 2) (2, 3) (0, 1) (1, 2) (2, 3)]
 }
+
+func ExampleCycle_chan() {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	// The channel can only be drained once, the following passes replay the buffer.
+	fmt.Println(slices.Collect(Take(Cycle(FromChan(ch)), 7)))
+	fmt.Println(slices.Collect(Cycle(Empty[int]())))
+	// Output:
+	// [1 2 3 1 2 3 1]
+	// []
+}
@@ -6,7 +6,11 @@
 //
 //	func Chars(b []byte) iter.Seq[rune]
 //
-// [Cycle] repeats an iterator endlessly.
+// [CSVRecords] returns a fallible iterator over the CSV records read from r.
+//
+//	func CSVRecords(r io.Reader) iter.Seq2[[]string, error]
+//
+// [Cycle] repeats an iterator endlessly, replaying a buffer of its first pass.
 //
 //	func Cycle[T any](x iter.Seq[T]) iter.Seq[T]
 //
@@ -22,10 +26,18 @@
 //
 //	func FromIndexBy[T Number](n T, f func(T) T) iter.Seq[T]
 //
+// [FromOption] returns an iterator that yields the value of the option if it is some, or nothing if it is none.
+//
+//	func FromOption[T any](o opt.Option[T]) iter.Seq[T]
+//
 // [FromChan] returns an iterator that yields values from the provided channel ch.
 //
 //	func FromChan[T any](ch <-chan T) iter.Seq[T]
 //
+// [Generate] returns an iterator that yields the values returned by fn, until fn returns false or the context is done.
+//
+//	func Generate[T any](ctx context.Context, fn func() (T, bool)) iter.Seq[T]
+//
 // [Iterate] creates an infinite iterator by repeatedly applying the given function f to the initial value init.
 //
 //	func Iterate[T any](init T, f func(T) T) iter.Seq[T]
@@ -34,6 +46,10 @@
 //
 //	func Lines(r io.ReadCloser) iter.Seq[string]
 //
+// [Of] returns an iterator over the given values, in order.
+//
+//	func Of[T any](vs ...T) iter.Seq[T]
+//
 // [Once] creates an iterator that yields an element exactly once.
 //
 //	func Once[T any](v T) iter.Seq[T]
@@ -50,6 +66,10 @@
 //
 //	func RangeFrom[T Integer](start T) iter.Seq[T]
 //
+// [RangeStep] returns a sequence of numbers from start (inclusive) to stop (exclusive) by step.
+//
+//	func RangeStep[T Number](start, stop, step T) iter.Seq[T]
+//
 // [RangeTo] returns a sequence of numbers from 0 (inclusive) to the given stop value n (exclusive).
 //
 //	func RangeTo[T Integer](stop T) iter.Seq[T]
@@ -70,10 +90,26 @@
 //
 //	func Resource[S, T any](start func() (S, error), next func(S) (T, error), stop func(S)) iter.Seq[T]
 //
+// [RetrySeq] returns an iterator over the sequence opened by fn, retrying fn with the given backoff policy while it fails.
+//
+//	func RetrySeq[T any](fn func() (iter.Seq[T], error), policy Backoff) iter.Seq2[T, error]
+//
+// [ScanLines] returns a fallible iterator over the lines read from r.
+//
+//	func ScanLines(r io.Reader) iter.Seq2[[]byte, error]
+//
+// [ScanTokens] returns a fallible iterator over the tokens of the given [bufio.Scanner].
+//
+//	func ScanTokens(s *bufio.Scanner) iter.Seq2[[]byte, error]
+//
 // [Successors] creates a new iterator where each successive item is computed based on the preceding one.
 //
 //	func Successors[T any](v T, f func(T) (T, bool)) iter.Seq[T]
 //
+// [Ticker] returns an iterator that yields the time of each tick with the period d, until the context is done.
+//
+//	func Ticker(ctx context.Context, d time.Duration) iter.Seq[time.Time]
+//
 // # Mapping
 //
 // [Accumulate] makes an iterator that returns accumulated sums.
@@ -106,6 +142,10 @@
 //
 //	func ChunkByKey[T any, B comparable](x iter.Seq[T], f func(T) B) iter.Seq[[]T]
 //
+// [Debounce] creates an iterator which yields the last element of each burst of timed elements.
+//
+//	func Debounce[T any](x iter.Seq2[T, time.Time], d time.Duration) iter.Seq2[T, time.Time]
+//
 // [Dedup] creates an iterator that only emits elements if they are different from the last emitted element.
 //
 //	func Dedup[T comparable](x iter.Seq[T]) iter.Seq[T]
@@ -123,6 +163,10 @@
 //
 //	func Enumerate[T any](x iter.Seq[T]) iter.Seq2[int, T]
 //
+// [ExpandRuns] creates an iterator which repeats each element as many times as the length of its run.
+//
+//	func ExpandRuns[T any](x iter.Seq2[T, int]) iter.Seq[T]
+//
 // [Filter] creates an iterator which uses a function f to determine if an element should be yielded.
 //
 //	func Filter[T any](x iter.Seq[T], f func(T) bool) iter.Seq[T]
@@ -139,6 +183,10 @@
 //
 //	func Inspect[T any](x iter.Seq[T], opts ...inspect.Option) iter.Seq[T
 //
+// [Interleave] creates an iterator which yields one element of each sequence in turn, going on with the remaining sequences once some of them are exhausted.
+//
+//	func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T]
+//
 // [Intersperse] creates a new iterator which places a separator between adjacent items of the original iterator.
 //
 //	func Intersperse[T any](x iter.Seq[T], sep T) iter.Seq[T]
@@ -159,6 +207,22 @@
 //
 //	func Swap[K, V any](x iter.Seq2[K, V]) iter.Seq2[V, K]
 //
+// [MapKeys] takes a function and creates an iterator which calls that function f on each key.
+//
+//	func MapKeys[K, V, O any](x iter.Seq2[K, V], f func(K) O) iter.Seq2[O, V]
+//
+// [MapValues] takes a function and creates an iterator which calls that function f on each value.
+//
+//	func MapValues[K, V, O any](x iter.Seq2[K, V], f func(V) O) iter.Seq2[K, O]
+//
+// [FilterKeys] creates an iterator which uses a function f to determine if a key-value should be yielded by its key.
+//
+//	func FilterKeys[K, V any](x iter.Seq2[K, V], f func(K) bool) iter.Seq2[K, V]
+//
+// [FilterValues] creates an iterator which uses a function f to determine if a key-value should be yielded by its value.
+//
+//	func FilterValues[K, V any](x iter.Seq2[K, V], f func(V) bool) iter.Seq2[K, V]
+//
 // [Map] takes a function and creates an iterator which calls that function f on each element.
 //
 //	func Map[T, O any](x iter.Seq[T], f func(T) O) iter.Seq[O]
@@ -171,6 +235,14 @@
 //
 //	func MapWhile[T, O any](x iter.Seq[T], f func(T) (O, bool)) iter.Seq[O]
 //
+// [Memo] records the elements on the first pass and replays them from the cache on subsequent iterations.
+//
+//	func Memo[T any](x iter.Seq[T]) (seq iter.Seq[T], stop func())
+//
+// [OnError] returns an iterator over the values of x whose error is nil, passing the errors to the handler.
+//
+//	func OnError[T any](x iter.Seq2[T, error], handler func(error) bool) iter.Seq[T]
+//
 // [Pairs] returns an iterator of pairs from the given iterator of key-values.
 //
 //	func Pairs[K, V any](x iter.Seq2[K, V]) iter.Seq[tuple.Tuple2[K, V]]
@@ -188,6 +260,14 @@
 //
 //	func Pipeline[T any](s iter.Seq[T], x ...Mapper[T, T]) iter.Seq[T]
 //
+// [RoundRobin] creates an iterator which yields one element of each sequence in turn, until all of them are exhausted.
+//
+//	func RoundRobin[T any](seqs ...iter.Seq[T]) iter.Seq[T]
+//
+// [RunLength] creates an iterator which yields each run of consecutive equal elements and its length.
+//
+//	func RunLength[T comparable](x iter.Seq[T]) iter.Seq2[T, int]
+//
 // [Scan] applies the provided function f to each element in the input iterator x,
 // yielding a new iterator of the results of applying f.
 //
@@ -205,6 +285,18 @@
 //
 //	func StepBy[T any](x iter.Seq[T], n int) iter.Seq[T]
 //
+// [Sorted] creates an iterator that yields the elements of x in ascending order.
+//
+//	func Sorted[T cmp.Ordered](x iter.Seq[T]) iter.Seq[T]
+//
+// [SortedBy] creates an iterator that yields the elements of x in ascending order using the given comparison function.
+//
+//	func SortedBy[T any](x iter.Seq[T], f func(T, T) int) iter.Seq[T]
+//
+// [SortedByKey] creates an iterator that yields the elements of x in ascending order of the keys extracted by f.
+//
+//	func SortedByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) iter.Seq[T]
+//
 // [Take] creates an iterator that yields the first n elements, or fewer if the underlying iterator ends sooner.
 //
 //	func Take[T any](x iter.Seq[T], n int) iter.Seq[T]
@@ -213,6 +305,14 @@
 //
 //	func TakeWhile[T any](x iter.Seq[T], f func(T) bool) iter.Seq[T]
 //
+// [Throttle] creates an iterator which drops the elements pulled within d of the last yielded one.
+//
+//	func Throttle[T any](x iter.Seq[T], d time.Duration) iter.Seq[T]
+//
+// [TopK] creates an iterator that yields the k largest elements of x in descending order using the given comparison function.
+//
+//	func TopK[T any](x iter.Seq[T], k int, f func(T, T) int) iter.Seq[T]
+//
 // [Uniq] creates a stream that only emits elements if they are unique.
 //
 //	func Uniq[T comparable](x iter.Seq[T]) iter.Seq[T]
@@ -235,6 +335,28 @@
 //
 //	func Unzip[K, V any](x iter.Seq2[K, V]) tuple.Tuple2[iter.Seq[K], iter.Seq[V]]
 //
+// # Error Handling
+//
+// [Try] is a fallible iterator, which yields either a value with a nil error, or the zero value with a non-nil error.
+//
+//	type Try[T any] = iter.Seq2[T, error]
+//
+// [Ok] lifts an infallible iterator into a fallible one, yielding each element with a nil error.
+//
+//	func Ok[T any](x iter.Seq[T]) iter.Seq2[T, error]
+//
+// [MapTry] creates a fallible iterator which calls the fallible function f on each value whose error is nil.
+//
+//	func MapTry[T, U any](x iter.Seq2[T, error], f func(T) (U, error)) iter.Seq2[U, error]
+//
+// [FilterTry] creates a fallible iterator which uses a fallible predicate f to determine if a value should be yielded.
+//
+//	func FilterTry[T any](x iter.Seq2[T, error], f func(T) (bool, error)) iter.Seq2[T, error]
+//
+// [FirstErr] returns an iterator over the values which stops at the first error, and a function returning that error.
+//
+//	func FirstErr[T any](x iter.Seq2[T, error]) (iter.Seq[T], func() error)
+//
 // # Reduction
 //
 // [All] returns true if all elements in the provided sequence x satisfy the predicate function f.
@@ -245,6 +367,10 @@
 //
 //	func Any[T any](x iter.Seq[T], f func(T) bool) bool
 //
+// [AppendTo] appends the elements of the iterator to dst, and returns the extended slice.
+//
+//	func AppendTo[T any](x iter.Seq[T], dst []T) []T
+//
 // [Compare] compares the elements of tow iterators.
 //
 //	func Compare[T cmp.Ordered](l, r iter.Seq[T]) int
@@ -253,10 +379,26 @@
 //
 //	func CompareBy[T any](l, r iter.Seq[T], f func(T, T) int) int
 //
+// [CollectMap] collects the key-value pairs from the given iterator into a new map.
+//
+//	func CollectMap[K comparable, V any](x iter.Seq2[K, V]) map[K]V
+//
+// [CopyToChan] sends the elements of the iterator to the channel ch, until the iterator is exhausted or the context is done.
+//
+//	func CopyToChan[T any](ctx context.Context, x iter.Seq[T], ch chan<- T) error
+//
+// [CopyToMap] copies the key-value pairs of the iterator into the map m.
+//
+//	func CopyToMap[K comparable, V any](x iter.Seq2[K, V], m map[K]V)
+//
 // [Count] returns the number of iterations.
 //
 //	func Count[T any](x ...iter.Seq[T]) (n int)
 //
+// [CountBy] returns the number of elements that satisfy the predicate f.
+//
+//	func CountBy[T any](x iter.Seq[T], f func(T) bool) (n int)
+//
 // [Find] searches for an element of an iterator that satisfies a predicate f.
 //
 //	func Find[T any](x iter.Seq[T], f func(T) bool) opt.Option[T]
@@ -277,6 +419,14 @@
 //
 //	func ForEach[T any](x iter.Seq[T], f func(T))
 //
+// [FormatSeq] writes the elements of the sequence to w in their default format, placing the separator sep between them.
+//
+//	func FormatSeq[T any](w io.Writer, x iter.Seq[T], sep string) (n int, err error)
+//
+// [FormatSeq2] writes the key-value pairs of the sequence to w in their default format.
+//
+//	func FormatSeq2[K, V any](w io.Writer, x iter.Seq2[K, V], sep, kvSep string) (n int, err error)
+//
 // [GroupBy] makes a map that returns consecutive keys and groups from the sequence.
 //
 //	func GroupBy[T comparable](x iter.Seq[T]) map[T][]T
@@ -285,6 +435,10 @@
 //
 //	func GroupByKey[T any, K comparable](x iter.Seq[T], f func(T) K) map[K][]T
 //
+// [Histogram] counts the elements of an iterator into the fixed buckets delimited by the ascending bounds.
+//
+//	func Histogram[T Number](x iter.Seq[T], bounds ...T) []int
+//
 // [IsSorted] reports whether x is sorted in ascending order.
 //
 //	func IsSorted[T cmp.Ordered](x iter.Seq[T]) bool
@@ -297,6 +451,10 @@
 //
 //	func IsSortedByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) bool
 //
+// [JoinString] concatenates the strings of the sequence, placing the separator sep between them.
+//
+//	func JoinString(x iter.Seq[string], sep string) string
+//
 // [Last] returns the last element.
 //
 //	func Last[T any](x iter.Seq[T]) opt.Option[T]
@@ -313,6 +471,18 @@
 //
 //	func MaxByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) (r T)
 //
+// [MaxBy2] returns the key-value that gives the maximum value with respect to the specified comparison function.
+//
+//	func MaxBy2[K, V any](x iter.Seq2[K, V], f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) (r tuple.Tuple2[K, V])
+//
+// [MaxByKey2] returns the key-value that gives the maximum value from the specified function.
+//
+//	func MaxByKey2[K, V any, B cmp.Ordered](x iter.Seq2[K, V], f func(K, V) B) (r tuple.Tuple2[K, V])
+//
+// [Mean] returns the arithmetic mean of the elements of an iterator.
+//
+//	func Mean[T Number](x iter.Seq[T]) float64
+//
 // [Min] returns the minimum element of an iterator.
 //
 //	func Min[T cmp.Ordered](x iter.Seq[T]) (r T)
@@ -325,6 +495,14 @@
 //
 //	func MinByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) (r T)
 //
+// [MinBy2] returns the key-value that gives the minimum value with respect to the specified comparison function.
+//
+//	func MinBy2[K, V any](x iter.Seq2[K, V], f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) (r tuple.Tuple2[K, V])
+//
+// [MinByKey2] returns the key-value that gives the minimum value from the specified function.
+//
+//	func MinByKey2[K, V any, B cmp.Ordered](x iter.Seq2[K, V], f func(K, V) B) (r tuple.Tuple2[K, V])
+//
 // [MinMax] returns the minimum and maximum elements of an iterator.
 //
 //	func MinMax[T cmp.Ordered](x iter.Seq[T]) tuple.Tuple2[T, T]
@@ -364,4 +542,16 @@
 // [SumBy] sums the element that gives the value from the specified function.
 //
 //	func SumBy[T any, B Number](x iter.Seq[T], f func(T) B) (r B)
+//
+// [SumBy2] sums the key-value that gives the value from the specified function.
+//
+//	func SumBy2[K, V any, B Number](x iter.Seq2[K, V], f func(K, V) B) (r B)
+//
+// [Variance] returns the population variance of the elements of an iterator.
+//
+//	func Variance[T Number](x iter.Seq[T]) float64
+//
+// [WriteTo] writes the byte slices of the iterator to w, in order.
+//
+//	func WriteTo(x iter.Seq[[]byte], w io.Writer) (n int64, err error)
 package xiter
@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+// AppendTo appends the elements of the iterator to dst, and returns the extended slice.
+func AppendTo[T any](x iter.Seq[T], dst []T) []T {
+	for v := range x {
+		dst = append(dst, v)
+	}
+
+	return dst
+}
+
+// CopyToMap copies the key-value pairs of the iterator into the map m, which must not be nil.
+//
+// If a key is yielded more than once, the last value wins.
+func CopyToMap[K comparable, V any](x iter.Seq2[K, V], m map[K]V) {
+	for k, v := range x {
+		m[k] = v
+	}
+}
+
+// CopyToChan sends the elements of the iterator to the channel ch, until the iterator
+// is exhausted or the context is done.
+//
+// It returns the error of the context if it is done before all the elements are sent.
+// The channel is not closed.
+func CopyToChan[T any](ctx context.Context, x iter.Seq[T], ch chan<- T) error {
+	for v := range x {
+		select {
+		case ch <- v:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// WriteTo writes the byte slices of the iterator to w, in order.
+//
+// It stops at the first write error, and returns the number of bytes written and the error.
+// A short write without an error is reported as [io.ErrShortWrite].
+func WriteTo(x iter.Seq[[]byte], w io.Writer) (n int64, err error) {
+	for b := range x {
+		m, err := w.Write(b)
+		n += int64(m)
+
+		if err != nil {
+			return n, err
+		}
+
+		if m < len(b) {
+			return n, io.ErrShortWrite
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,92 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleAppendTo() {
+	dst := []int{0}
+	dst = AppendTo(Filter(Range(1, 10), func(n int) bool { return n%3 == 0 }), dst)
+
+	fmt.Println(dst)
+	// Output: [0 3 6 9]
+}
+
+func ExampleCopyToMap() {
+	m := map[string]int{"foo": 1}
+	CopyToMap(maps.All(map[string]int{"bar": 2, "baz": 3}), m)
+
+	fmt.Println(m)
+	// Output: map[bar:2 baz:3 foo:1]
+}
+
+func ExampleWriteTo() {
+	var b bytes.Buffer
+
+	n, err := WriteTo(Intersperse(slices.Values([][]byte{[]byte("foo"), []byte("bar")}), []byte(", ")), &b)
+
+	fmt.Println(b.String(), n, err)
+	// Output: foo, bar 8 <nil>
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(b []byte) (int, error) { return len(b) / 2, nil }
+
+func TestDrain(t *testing.T) {
+	Convey("CopyToChan", t, func() {
+		Convey("Should send all the elements", func() {
+			ch := make(chan int, 3)
+
+			So(CopyToChan(context.Background(), Range(0, 3), ch), ShouldBeNil)
+
+			close(ch)
+
+			So(slices.Collect(FromChan(ch)), ShouldResemble, []int{0, 1, 2})
+		})
+
+		Convey("Should stop when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			ch := make(chan int, 1)
+
+			err := CopyToChan(ctx, Map(Range(0, 10), func(v int) int {
+				if v == 1 {
+					cancel()
+				}
+
+				return v
+			}), ch)
+
+			So(err, ShouldEqual, context.Canceled)
+			So(len(ch), ShouldEqual, 1)
+		})
+	})
+
+	Convey("WriteTo", t, func() {
+		Convey("Should stop at the first write error", func() {
+			n, err := WriteTo(slices.Values([][]byte{[]byte("abcd"), []byte("efgh")}), &failingWriter{n: 6})
+
+			So(err, ShouldNotBeNil)
+			So(n, ShouldEqual, 6)
+		})
+
+		Convey("Should report short writes", func() {
+			n, err := WriteTo(slices.Values([][]byte{[]byte("abcd")}), shortWriter{})
+
+			So(err, ShouldEqual, io.ErrShortWrite)
+			So(n, ShouldEqual, 2)
+		})
+	})
+}
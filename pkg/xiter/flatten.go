@@ -5,12 +5,15 @@ package xiter
 import "iter"
 
 // Flatten creates an iterator that flattens nested iterators.
+//
+// The nested iterators are consumed lazily, one after another, and none is
+// started once the iteration stops.
 func Flatten[T iter.Seq[V], V any](x iter.Seq[T]) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		for v := range x {
 			for i := range v {
 				if !yield(i) {
-					break
+					return
 				}
 			}
 		}
@@ -23,7 +26,7 @@ func Flatten2[T iter.Seq2[K, V], K, V any](x iter.Seq[T]) iter.Seq2[K, V] {
 		for i := range x {
 			for k, v := range i {
 				if !yield(k, v) {
-					break
+					return
 				}
 			}
 		}
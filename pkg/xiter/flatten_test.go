@@ -7,8 +7,10 @@ import (
 	"iter"
 	"maps"
 	"slices"
+	"testing"
 
 	. "github.com/flier/goutil/pkg/xiter"
+	. "github.com/smartystreets/goconvey/convey"
 )
 
 func ExampleFlatten() {
@@ -30,3 +32,86 @@ func ExampleFlatten2() {
 	fmt.Println(maps.Collect(f))
 	// Output: map[0:4 1:5 2:3]
 }
+
+func TestFlatten(t *testing.T) {
+	Convey("Given nested iterators", t, func() {
+		var started []int
+
+		nested := func(n int) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				started = append(started, n)
+
+				for i := 0; i < n; i++ {
+					if !yield(n*10 + i) {
+						return
+					}
+				}
+			}
+		}
+
+		s := Map(slices.Values([]int{1, 2, 3}), nested)
+
+		Convey("When stopping in the middle of a nested iterator", func() {
+			var got []int
+
+			for v := range Flatten(s) {
+				got = append(got, v)
+
+				if len(got) == 2 {
+					break
+				}
+			}
+
+			Convey("Then the following iterators should not be started", func() {
+				So(got, ShouldResemble, []int{10, 20})
+				So(started, ShouldResemble, []int{1, 2})
+			})
+		})
+
+		Convey("When flat mapping lazily", func() {
+			var got []int
+
+			for v := range FlatMap(slices.Values([]int{1, 2, 3}), nested) {
+				got = append(got, v)
+
+				if len(got) == 3 {
+					break
+				}
+			}
+
+			Convey("Then the mapping should stop with the iteration", func() {
+				So(got, ShouldResemble, []int{10, 20, 21})
+				So(started, ShouldResemble, []int{1, 2})
+			})
+		})
+
+		Convey("When stopping a flattened key-value iterator", func() {
+			s := slices.Values([]iter.Seq2[int, string]{
+				slices.All([]string{"a", "b"}),
+				slices.All([]string{"c"}),
+			})
+
+			var got []string
+
+			for _, v := range Flatten2(s) {
+				got = append(got, v)
+
+				break
+			}
+
+			So(got, ShouldResemble, []string{"a"})
+
+			got = nil
+
+			for _, v := range FlatMap2(maps.All(map[int]int{1: 2}), func(k, n int) iter.Seq2[int, string] {
+				return slices.All([]string{"x", "y"}[:n])
+			}) {
+				got = append(got, v)
+
+				break
+			}
+
+			So(got, ShouldResemble, []string{"x"})
+		})
+	})
+}
@@ -2,7 +2,29 @@
 
 package xiter
 
-import "iter"
+import (
+	"iter"
+
+	"github.com/flier/goutil/pkg/opt"
+)
+
+// Of returns an iterator over the given values, in order.
+//
+// It is a shorthand for slices.Values([]T{...}) to feed literals into a pipeline.
+func Of[T any](vs ...T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromOption returns an iterator that yields the value of o if it is some, or nothing if it is none.
+func FromOption[T any](o opt.Option[T]) iter.Seq[T] {
+	return o.Iter()
+}
 
 // FromIndex returns an infinite iterator of numbers starting from the given index n.
 func FromIndex[T Number](n T) iter.Seq[T] {
@@ -7,9 +7,32 @@ import (
 	"maps"
 	"slices"
 
+	"github.com/flier/goutil/pkg/opt"
 	. "github.com/flier/goutil/pkg/xiter"
 )
 
+func ExampleOf() {
+	fmt.Println(slices.Collect(Of(1, 2, 3)))
+	fmt.Println(slices.Collect(Of[int]()))
+
+	for v := range Of("a", "b", "c") {
+		fmt.Println(v)
+
+		break
+	}
+
+	// Output:
+	// [1 2 3]
+	// []
+	// a
+}
+
+func ExampleFromOption() {
+	fmt.Println(slices.Collect(Chain(FromOption(opt.Some(1)), FromOption(opt.None[int]()), Once(3))))
+	// Output:
+	// [1 3]
+}
+
 func ExampleFromIndex() {
 	s := FromIndex(1)
 
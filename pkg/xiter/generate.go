@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Generate returns an iterator that yields the values returned by fn, until fn returns false
+// or the context is done.
+//
+// The context is checked before each call to fn, which isn't interrupted by the context.
+func Generate[T any](ctx context.Context, fn func() (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ctx.Err() == nil {
+			v, ok := fn()
+			if !ok || !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// Ticker returns an iterator that yields the time of each tick of a [time.Ticker]
+// with the period d, until the context is done.
+//
+// Like a [time.Ticker], the ticks are dropped while the consumer is slow.
+// The ticker is stopped when the iteration ends.
+//
+// It panics if d is not positive.
+func Ticker(ctx context.Context, d time.Duration) iter.Seq[time.Time] {
+	if d <= 0 {
+		panic("xiter: non-positive interval for Ticker")
+	}
+
+	return func(yield func(time.Time) bool) {
+		t := time.NewTicker(d)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-t.C:
+				if ctx.Err() != nil || !yield(now) {
+					return
+				}
+			}
+		}
+	}
+}
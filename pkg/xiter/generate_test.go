@@ -0,0 +1,85 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleGenerate() {
+	n := 0
+
+	s := Generate(context.Background(), func() (int, bool) {
+		n++
+
+		return n * n, n <= 4
+	})
+
+	fmt.Println(slices.Collect(s))
+
+	// Output:
+	// [1 4 9 16]
+}
+
+func TestGenerate(t *testing.T) {
+	Convey("Given a generator with a context", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		n := 0
+
+		s := Generate(ctx, func() (int, bool) {
+			n++
+
+			return n, true
+		})
+
+		Convey("Then it stops when the context is canceled", func() {
+			var got []int
+
+			for v := range s {
+				if got = append(got, v); v == 3 {
+					cancel()
+				}
+			}
+
+			So(got, ShouldResemble, []int{1, 2, 3})
+		})
+
+		Convey("Then it stops when the consumer breaks", func() {
+			So(slices.Collect(Take(s, 2)), ShouldResemble, []int{1, 2})
+		})
+	})
+}
+
+func TestTicker(t *testing.T) {
+	Convey("Given a ticker with a context", t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		Convey("Then it yields ticks until the consumer breaks", func() {
+			start := time.Now()
+
+			So(slices.Collect(Take(Ticker(ctx, time.Millisecond), 3)), ShouldHaveLength, 3)
+			So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 3*time.Millisecond)
+		})
+
+		Convey("Then it stops when the context is done", func() {
+			cancel()
+
+			So(slices.Collect(Ticker(ctx, time.Millisecond)), ShouldBeEmpty)
+		})
+
+		Convey("Then a non-positive interval panics", func() {
+			So(func() { Ticker(ctx, 0) }, ShouldPanic)
+		})
+	})
+}
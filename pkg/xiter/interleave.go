@@ -0,0 +1,57 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"iter"
+)
+
+// Interleave creates an iterator which yields one element of each sequence in turn,
+// going on with the remaining sequences once some of them are exhausted.
+//
+// It is the same as [RoundRobin].
+//
+// Example:
+//
+//	Interleave(slices.Values([]int{1, 2}), slices.Values([]int{3, 4, 5})) // 1, 3, 2, 4, 5
+func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return RoundRobin(seqs...)
+}
+
+// RoundRobin creates an iterator which yields one element of each sequence in turn,
+// skipping the exhausted sequences until all of them are exhausted.
+//
+// Example:
+//
+//	RoundRobin(slices.Values([]int{1, 2}), slices.Values([]int{3, 4, 5})) // 1, 3, 2, 4, 5
+func RoundRobin[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		next := make([]func() (T, bool), 0, len(seqs))
+
+		for _, seq := range seqs {
+			n, stop := iter.Pull(seq)
+			defer stop()
+
+			next = append(next, n)
+		}
+
+		for len(next) > 0 {
+			active := next[:0]
+
+			for _, n := range next {
+				v, ok := n()
+				if !ok {
+					continue
+				}
+
+				if !yield(v) {
+					return
+				}
+
+				active = append(active, n)
+			}
+
+			next = active
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleInterleave() {
+	fmt.Println(slices.Collect(Interleave(slices.Values([]int{1, 2}), slices.Values([]int{3, 4, 5}))))
+
+	// Output:
+	// [1 3 2 4 5]
+}
+
+func ExampleRoundRobin() {
+	fmt.Println(slices.Collect(RoundRobin(
+		slices.Values([]string{"a1", "a2", "a3"}),
+		slices.Values([]string{"b1"}),
+		slices.Values([]string{"c1", "c2"}),
+	)))
+
+	// Output:
+	// [a1 b1 c1 a2 c2 a3]
+}
+
+func TestInterleave(t *testing.T) {
+	Convey("Given some sequences", t, func() {
+		a := slices.Values([]int{1, 2, 3})
+		b := slices.Values([]int{4, 5, 6})
+		c := slices.Values([]int{7})
+
+		Convey("Interleave goes on with the sequences which aren't exhausted", func() {
+			So(slices.Collect(Interleave(a, b)), ShouldResemble, []int{1, 4, 2, 5, 3, 6})
+			So(slices.Collect(Interleave(a, c, b)), ShouldResemble, []int{1, 7, 4, 2, 5, 3, 6})
+			So(slices.Collect(Interleave(c, a)), ShouldResemble, []int{7, 1, 2, 3})
+			So(slices.Collect(Interleave(a)), ShouldResemble, []int{1, 2, 3})
+			So(slices.Collect(Interleave[int]()), ShouldBeEmpty)
+		})
+
+		Convey("RoundRobin goes on until all the sequences are exhausted", func() {
+			So(slices.Collect(RoundRobin(a, c, b)), ShouldResemble, []int{1, 7, 4, 2, 5, 3, 6})
+			So(slices.Collect(RoundRobin(Empty[int](), a)), ShouldResemble, []int{1, 2, 3})
+			So(slices.Collect(RoundRobin[int]()), ShouldBeEmpty)
+		})
+
+		Convey("Stopping early stops the sequences", func() {
+			stopped := 0
+			seq := func(yield func(int) bool) {
+				defer func() { stopped++ }()
+
+				for i := 0; yield(i); i++ {
+				}
+			}
+
+			So(slices.Collect(Take(RoundRobin(iter.Seq[int](seq), seq), 3)), ShouldResemble, []int{0, 0, 1})
+			So(stopped, ShouldEqual, 2)
+
+			So(slices.Collect(Take(Interleave(iter.Seq[int](seq), seq), 3)), ShouldResemble, []int{0, 0, 1})
+			So(stopped, ShouldEqual, 4)
+		})
+	})
+}
@@ -0,0 +1,89 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// JoinString concatenates the strings of the sequence, placing the separator sep between them.
+func JoinString(x iter.Seq[string], sep string) string {
+	var b strings.Builder
+
+	first := true
+
+	for s := range x {
+		if first {
+			first = false
+		} else {
+			b.WriteString(sep)
+		}
+
+		b.WriteString(s)
+	}
+
+	return b.String()
+}
+
+// JoinStringFunc concatenates the strings of the sequence, placing the separator sep between them.
+func JoinStringFunc(sep string) ReductionFunc[string, string] {
+	return bind2(JoinString, sep)
+}
+
+// FormatSeq writes the elements of the sequence to w in their default format, placing the separator sep between them.
+//
+// It stops at the first write error, and returns the number of bytes written and the error.
+func FormatSeq[T any](w io.Writer, x iter.Seq[T], sep string) (n int, err error) {
+	first := true
+
+	for v := range x {
+		var m int
+
+		if first {
+			first = false
+		} else if m, err = io.WriteString(w, sep); err != nil {
+			return n + m, err
+		} else {
+			n += m
+		}
+
+		if m, err = fmt.Fprint(w, v); err != nil {
+			return n + m, err
+		}
+
+		n += m
+	}
+
+	return
+}
+
+// FormatSeq2 writes the key-value pairs of the sequence to w in their default format, placing the separator kvSep
+// between a key and its value, and the separator sep between the pairs.
+//
+// It stops at the first write error, and returns the number of bytes written and the error.
+func FormatSeq2[K, V any](w io.Writer, x iter.Seq2[K, V], sep, kvSep string) (n int, err error) {
+	first := true
+
+	for k, v := range x {
+		var m int
+
+		if first {
+			first = false
+		} else if m, err = io.WriteString(w, sep); err != nil {
+			return n + m, err
+		} else {
+			n += m
+		}
+
+		if m, err = fmt.Fprint(w, k, kvSep, v); err != nil {
+			return n + m, err
+		}
+
+		n += m
+	}
+
+	return
+}
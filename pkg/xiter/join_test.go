@@ -0,0 +1,122 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/flier/goutil/pkg/opt"
+	. "github.com/flier/goutil/pkg/xiter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func ExampleJoinString() {
+	s := slices.Values([]string{"foo", "bar", "baz"})
+
+	fmt.Println(JoinString(s, ", "))
+	// Output: foo, bar, baz
+}
+
+func ExampleJoinStringFunc() {
+	join := JoinStringFunc(", ")
+
+	fmt.Println(join(slices.Values([]string{"foo", "bar", "baz"})))
+	// Output: foo, bar, baz
+}
+
+func ExampleFormatSeq() {
+	_, _ = FormatSeq(os.Stdout, Range(1, 5), ", ")
+	// Output: 1, 2, 3, 4
+}
+
+func ExampleFormatSeq2() {
+	var b strings.Builder
+	_, _ = FormatSeq2(&b, slices.All([]string{"foo", "bar", "baz"}), ", ", "=")
+
+	fmt.Println(b.String())
+	// Output: 0=foo, 1=bar, 2=baz
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errors.New("short write")
+	}
+
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestJoinString(t *testing.T) {
+	Convey("JoinString", t, func() {
+		So(JoinString(Empty[string](), ","), ShouldEqual, "")
+		So(JoinString(slices.Values([]string{"foo"}), ","), ShouldEqual, "foo")
+		So(JoinString(slices.Values([]string{"", ""}), ","), ShouldEqual, ",")
+	})
+}
+
+func TestFormatSeq(t *testing.T) {
+	Convey("FormatSeq", t, func() {
+		Convey("Should report the number of bytes written", func() {
+			var b strings.Builder
+
+			n, err := FormatSeq(&b, slices.Values([]any{1, "two", 3.5, nil}), " | ")
+
+			So(err, ShouldBeNil)
+			So(b.String(), ShouldEqual, "1 | two | 3.5 | <nil>")
+			So(n, ShouldEqual, b.Len())
+		})
+
+		Convey("Should format the options without their internals", func() {
+			var b strings.Builder
+
+			_, err := FormatSeq(&b, slices.Values([]opt.Option[int]{opt.Some(1), opt.None[int]()}), ", ")
+
+			So(err, ShouldBeNil)
+			So(b.String(), ShouldEqual, "Some(1), None")
+		})
+
+		Convey("Should stop at the first write error", func() {
+			n, err := FormatSeq(&failingWriter{n: 4}, Range(100, 200), ",")
+
+			So(err, ShouldNotBeNil)
+			So(n, ShouldEqual, 4)
+		})
+	})
+
+	Convey("FormatSeq2", t, func() {
+		Convey("Should write key-value pairs", func() {
+			var b strings.Builder
+
+			n, err := FormatSeq2(&b, slices.All([]string{"foo", "bar"}), "; ", ": ")
+
+			So(err, ShouldBeNil)
+			So(b.String(), ShouldEqual, "0: foo; 1: bar")
+			So(n, ShouldEqual, b.Len())
+		})
+
+		Convey("Should handle empty sequence", func() {
+			var b strings.Builder
+
+			n, err := FormatSeq2(&b, Empty2[int, int](), ",", "=")
+
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 0)
+		})
+
+		Convey("Should stop at the first write error", func() {
+			n, err := FormatSeq2(&failingWriter{n: 6}, slices.All([]int{10, 20, 30}), ",", "=")
+
+			So(err, ShouldNotBeNil)
+			So(n, ShouldEqual, 6)
+		})
+	})
+}
@@ -38,3 +38,88 @@ func Swap[K, V any](x iter.Seq2[K, V]) iter.Seq2[V, K] {
 		}
 	}
 }
+
+// MapKeys takes a function and creates an iterator which calls that function f on each key.
+func MapKeys[K, V, O any](x iter.Seq2[K, V], f func(K) O) iter.Seq2[O, V] {
+	return func(yield func(O, V) bool) {
+		for k, v := range x {
+			if !yield(f(k), v) {
+				break
+			}
+		}
+	}
+}
+
+// MapKeysFunc takes a function and creates an iterator which calls that function f on each key.
+func MapKeysFunc[K, V, O any](f func(K) O) MappingKeyFunc[K, V, O] {
+	return bind2(MapKeys[K, V, O], f)
+}
+
+// MapValues takes a function and creates an iterator which calls that function f on each value.
+func MapValues[K, V, O any](x iter.Seq2[K, V], f func(V) O) iter.Seq2[K, O] {
+	return func(yield func(K, O) bool) {
+		for k, v := range x {
+			if !yield(k, f(v)) {
+				break
+			}
+		}
+	}
+}
+
+// MapValuesFunc takes a function and creates an iterator which calls that function f on each value.
+func MapValuesFunc[K, V, O any](f func(V) O) MappingValueFunc[K, V, O] {
+	return bind2(MapValues[K, V, O], f)
+}
+
+// FilterKeys creates an iterator which uses a function f to determine if a key-value should be yielded by its key.
+func FilterKeys[K, V any](x iter.Seq2[K, V], f func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range x {
+			if !f(k) {
+				continue
+			}
+
+			if !yield(k, v) {
+				break
+			}
+		}
+	}
+}
+
+// FilterKeysFunc creates an iterator which uses a function f to determine if a key-value should be yielded by its key.
+func FilterKeysFunc[K, V any](f func(K) bool) MappingValueFunc[K, V, V] {
+	return bind2(FilterKeys[K, V], f)
+}
+
+// FilterValues creates an iterator which uses a function f to determine if a key-value should be yielded by its value.
+func FilterValues[K, V any](x iter.Seq2[K, V], f func(V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range x {
+			if !f(v) {
+				continue
+			}
+
+			if !yield(k, v) {
+				break
+			}
+		}
+	}
+}
+
+// FilterValuesFunc creates an iterator which uses a function f to determine if a key-value should be yielded by its value.
+func FilterValuesFunc[K, V any](f func(V) bool) MappingValueFunc[K, V, V] {
+	return bind2(FilterValues[K, V], f)
+}
+
+// CollectMap collects the key-value pairs from the given iterator into a new map.
+//
+// If a key is yielded more than once, the last value wins.
+func CollectMap[K comparable, V any](x iter.Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+
+	for k, v := range x {
+		m[k] = v
+	}
+
+	return m
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
 
 	. "github.com/flier/goutil/pkg/xiter"
 )
@@ -36,3 +37,56 @@ func ExampleSwap() {
 	// Output:
 	// map[bar:foo world:hello]
 }
+
+func ExampleMapKeys() {
+	s := maps.All(map[string]int{"foo": 1, "hello": 2})
+	m := MapKeys(s, strings.ToUpper)
+
+	fmt.Println(CollectMap(m))
+	// Output:
+	// map[FOO:1 HELLO:2]
+}
+
+func ExampleMapValues() {
+	s := maps.All(map[string]int{"foo": 1, "hello": 2})
+	m := MapValues(s, func(v int) int { return v * 10 })
+
+	fmt.Println(CollectMap(m))
+	// Output:
+	// map[foo:10 hello:20]
+}
+
+func ExampleFilterKeys() {
+	s := maps.All(map[string]int{"foo": 1, "hello": 2, "far": 3})
+	m := FilterKeys(s, func(k string) bool { return strings.HasPrefix(k, "f") })
+
+	fmt.Println(CollectMap(m))
+	// Output:
+	// map[far:3 foo:1]
+}
+
+func ExampleFilterValues() {
+	s := maps.All(map[string]int{"foo": 1, "hello": 2, "far": 3})
+	m := FilterValues(s, func(v int) bool { return v%2 == 1 })
+
+	fmt.Println(CollectMap(m))
+	// Output:
+	// map[far:3 foo:1]
+}
+
+func ExampleCollectMap() {
+	s := Zip(slices.Values([]string{"foo", "bar", "foo"}), slices.Values([]int{1, 2, 3}))
+
+	fmt.Println(CollectMap(s))
+	// Output:
+	// map[bar:2 foo:3]
+}
+
+func ExampleMapKeysFunc() {
+	s := slices.All([]string{"foo", "bar"})
+	m := MapKeysFunc[int, string](func(i int) int { return i + 1 }).MapKey(s)
+
+	fmt.Println(CollectMap(m))
+	// Output:
+	// map[1:foo 2:bar]
+}
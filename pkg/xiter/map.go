@@ -71,12 +71,14 @@ func MapValueFunc[K, V, O any](f func(K, V) O) MappingValueFunc[K, V, O] {
 }
 
 // FlatMap creates an iterator that works like Map, but flattens nested iterator.
+//
+// f is only called when the iteration reaches the next element of x.
 func FlatMap[T, O any](x iter.Seq[T], f func(T) iter.Seq[O]) iter.Seq[O] {
 	return func(yield func(O) bool) {
 		for v := range x {
 			for i := range f(v) {
 				if !yield(i) {
-					break
+					return
 				}
 			}
 		}
@@ -94,7 +96,7 @@ func FlatMap2[K, V, O any](x iter.Seq2[K, V], f func(K, V) iter.Seq2[K, O]) iter
 		for k, v := range x {
 			for k, o := range f(k, v) {
 				if !yield(k, o) {
-					break
+					return
 				}
 			}
 		}
@@ -5,6 +5,8 @@ package xiter
 import (
 	"cmp"
 	"iter"
+
+	"github.com/flier/goutil/pkg/tuple"
 )
 
 // Max returns the maximum element of an iterator.
@@ -79,3 +81,56 @@ func MaxByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) (r T) {
 func MaxByKeyFunc[T any, B cmp.Ordered](f func(T) B) ReductionFunc[T, T] {
 	return bind2(MaxByKey, f)
 }
+
+// MaxBy2 returns the key-value that gives the maximum value with respect to the specified comparison function.
+//
+// If several key-values are equally maximum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MaxBy2[K, V any](x iter.Seq2[K, V], f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) (r tuple.Tuple2[K, V]) {
+	first := true
+
+	for k, v := range x {
+		if t := tuple.New2(k, v); first || f(r, t) <= 0 {
+			r = t
+			first = false
+		}
+	}
+
+	return
+}
+
+// MaxBy2Func returns the key-value that gives the maximum value with respect to the specified comparison function.
+//
+// If several key-values are equally maximum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MaxBy2Func[K, V any](f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) Reduction2Func[K, V, tuple.Tuple2[K, V]] {
+	return bind2(MaxBy2, f)
+}
+
+// MaxByKey2 returns the key-value that gives the maximum value from the specified function.
+//
+// If several key-values are equally maximum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MaxByKey2[K, V any, B cmp.Ordered](x iter.Seq2[K, V], f func(K, V) B) (r tuple.Tuple2[K, V]) {
+	var m B
+
+	first := true
+
+	for k, v := range x {
+		if b := f(k, v); first || m <= b {
+			r = tuple.New2(k, v)
+			m = b
+			first = false
+		}
+	}
+
+	return
+}
+
+// MaxByKey2Func returns the key-value that gives the maximum value from the specified function.
+//
+// If several key-values are equally maximum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MaxByKey2Func[K, V any, B cmp.Ordered](f func(K, V) B) Reduction2Func[K, V, tuple.Tuple2[K, V]] {
+	return bind2(MaxByKey2, f)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/flier/goutil/pkg/tuple"
 	. "github.com/flier/goutil/pkg/xiter"
 )
 
@@ -52,3 +53,39 @@ func ExampleMaxByFunc() {
 	fmt.Println(w)
 	// Output: foo
 }
+
+func ExampleMaxBy2() {
+	s := slices.All([]string{"foo", "bar", "baz"})
+	w := MaxBy2(s, func(a, b tuple.Tuple2[int, string]) int { return cmp.Compare(a.V1, b.V1) })
+
+	fmt.Println(w)
+	// Output: (0, foo)
+}
+
+func ExampleMaxBy2Func() {
+	max := MaxBy2Func(func(a, b tuple.Tuple2[int, string]) int { return cmp.Compare(a.V1, b.V1) })
+
+	s := slices.All([]string{"foo", "bar", "baz"})
+	w := max(s)
+
+	fmt.Println(w)
+	// Output: (0, foo)
+}
+
+func ExampleMaxByKey2() {
+	s := slices.All([]string{"foo", "bar", "hello", "world"})
+	w := MaxByKey2(s, func(i int, k string) int { return len(k) })
+
+	fmt.Println(w)
+	// Output: (3, world)
+}
+
+func ExampleMaxByKey2Func() {
+	maxLen := MaxByKey2Func(func(i int, k string) int { return len(k) })
+
+	s := slices.All([]string{"foo", "bar", "hello", "world"})
+	w := maxLen(s)
+
+	fmt.Println(w)
+	// Output: (3, world)
+}
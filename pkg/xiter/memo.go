@@ -0,0 +1,108 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/flier/goutil/pkg/tuple"
+)
+
+// Memo returns an iterator that records the elements of x on the first pass and
+// replays them from the cache on subsequent iterations, and a function stopping x.
+//
+// The elements are pulled from x lazily, only when an iteration goes past the end
+// of the cache, so x is consumed at most once, even by concurrent or nested iterations.
+//
+// x is held open in a suspended coroutine until it is exhausted, so stop must be
+// called once the sequence is no longer used, unless it has been iterated to the end.
+// After stop, the iterator only replays the cached elements.
+func Memo[T any](x iter.Seq[T]) (seq iter.Seq[T], stop func()) {
+	m := &memo[T]{src: x}
+
+	return func(yield func(T) bool) {
+		for i := 0; ; i++ {
+			v, ok := m.get(i)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}, m.close
+}
+
+// Memo2 returns an iterator that records the key-value pairs of x on the first pass
+// and replays them from the cache on subsequent iterations, and a function stopping x.
+//
+// The key-value pairs are pulled from x lazily, only when an iteration goes past the
+// end of the cache, so x is consumed at most once, even by concurrent or nested iterations.
+//
+// x is held open in a suspended coroutine until it is exhausted, so stop must be
+// called once the sequence is no longer used, unless it has been iterated to the end.
+// After stop, the iterator only replays the cached key-value pairs.
+func Memo2[K, V any](x iter.Seq2[K, V]) (seq iter.Seq2[K, V], stop func()) {
+	m := &memo[tuple.Tuple2[K, V]]{src: Pairs(x)}
+
+	return func(yield func(K, V) bool) {
+		for i := 0; ; i++ {
+			kv, ok := m.get(i)
+			if !ok || !yield(kv.Unpack()) {
+				return
+			}
+		}
+	}, m.close
+}
+
+type memo[T any] struct {
+	mu    sync.Mutex
+	src   iter.Seq[T]
+	next  func() (T, bool)
+	stop  func()
+	cache []T
+	done  bool
+}
+
+// get returns the i-th element, pulling it from the source if it isn't cached yet.
+//
+// The lock is not held while yielding, so the sequence can be iterated in a nested loop.
+func (m *memo[T]) get(i int) (v T, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i >= len(m.cache) {
+		if m.done {
+			return
+		}
+
+		if m.next == nil {
+			m.next, m.stop = iter.Pull(m.src)
+		}
+
+		if v, ok = m.next(); !ok {
+			m.finish()
+
+			return
+		}
+
+		m.cache = append(m.cache, v)
+	}
+
+	return m.cache[i], true
+}
+
+// close stops the source, keeping the elements cached so far.
+func (m *memo[T]) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.finish()
+}
+
+// finish stops the source if it has been pulled, and forgets it.
+func (m *memo[T]) finish() {
+	if m.stop != nil {
+		m.stop()
+	}
+
+	m.done, m.src, m.next, m.stop = true, nil, nil, nil
+}
@@ -0,0 +1,104 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	. "github.com/flier/goutil/pkg/xiter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func ExampleMemo() {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	s, stop := Memo(FromChan(ch))
+	defer stop()
+
+	fmt.Println(slices.Collect(s))
+	fmt.Println(slices.Collect(s))
+	// Output:
+	// [1 2 3]
+	// [1 2 3]
+}
+
+func ExampleMemo2() {
+	s, stop := Memo2(maps.All(map[string]int{"foo": 1}))
+	defer stop()
+
+	fmt.Println(maps.Collect(s))
+	fmt.Println(maps.Collect(s))
+	// Output:
+	// map[foo:1]
+	// map[foo:1]
+}
+
+func TestMemo(t *testing.T) {
+	Convey("Given a memoized sequence", t, func() {
+		var pulled int
+
+		s, stop := Memo(Map(Range(0, 5), func(i int) int {
+			pulled++
+
+			return i
+		}))
+		defer stop()
+
+		So(pulled, ShouldEqual, 0)
+
+		Convey("When stopping the first iteration early", func() {
+			So(slices.Collect(Take(s, 2)), ShouldResemble, []int{0, 1})
+			So(pulled, ShouldEqual, 2)
+
+			Convey("Then stopping it should keep the cached elements only", func() {
+				stop()
+				stop()
+
+				So(slices.Collect(s), ShouldResemble, []int{0, 1})
+				So(pulled, ShouldEqual, 2)
+			})
+
+			Convey("Then the next iteration should replay the cache and continue lazily", func() {
+				So(slices.Collect(Take(s, 3)), ShouldResemble, []int{0, 1, 2})
+				So(pulled, ShouldEqual, 3)
+
+				So(slices.Collect(s), ShouldResemble, []int{0, 1, 2, 3, 4})
+				So(pulled, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When iterating in a nested loop", func() {
+			var pairs [][2]int
+
+			for i := range s {
+				for j := range s {
+					if j > i {
+						break
+					}
+
+					pairs = append(pairs, [2]int{i, j})
+				}
+			}
+
+			Convey("Then the source should only be consumed once", func() {
+				So(len(pairs), ShouldEqual, 15)
+				So(pulled, ShouldEqual, 5)
+			})
+		})
+	})
+
+	Convey("Given a memoized empty sequence", t, func() {
+		s, stop := Memo(Empty[int]())
+		defer stop()
+
+		So(slices.Collect(s), ShouldBeEmpty)
+		So(slices.Collect(s), ShouldBeEmpty)
+	})
+}
@@ -5,6 +5,8 @@ package xiter
 import (
 	"cmp"
 	"iter"
+
+	"github.com/flier/goutil/pkg/tuple"
 )
 
 // Min returns the minimum element of an iterator.
@@ -83,3 +85,56 @@ func MinByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) (r T) {
 func MinByKeyFunc[T any, B cmp.Ordered](f func(T) B) ReductionFunc[T, T] {
 	return bind2(MinByKey, f)
 }
+
+// MinBy2 returns the key-value that gives the minimum value with respect to the specified comparison function.
+//
+// If several key-values are equally minimum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MinBy2[K, V any](x iter.Seq2[K, V], f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) (r tuple.Tuple2[K, V]) {
+	first := true
+
+	for k, v := range x {
+		if t := tuple.New2(k, v); first || f(r, t) >= 0 {
+			r = t
+			first = false
+		}
+	}
+
+	return
+}
+
+// MinBy2Func returns the key-value that gives the minimum value with respect to the specified comparison function.
+//
+// If several key-values are equally minimum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MinBy2Func[K, V any](f func(tuple.Tuple2[K, V], tuple.Tuple2[K, V]) int) Reduction2Func[K, V, tuple.Tuple2[K, V]] {
+	return bind2(MinBy2, f)
+}
+
+// MinByKey2 returns the key-value that gives the minimum value from the specified function.
+//
+// If several key-values are equally minimum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MinByKey2[K, V any, B cmp.Ordered](x iter.Seq2[K, V], f func(K, V) B) (r tuple.Tuple2[K, V]) {
+	var m B
+
+	first := true
+
+	for k, v := range x {
+		if b := f(k, v); first || m >= b {
+			r = tuple.New2(k, v)
+			m = b
+			first = false
+		}
+	}
+
+	return
+}
+
+// MinByKey2Func returns the key-value that gives the minimum value from the specified function.
+//
+// If several key-values are equally minimum, the last key-value is returned.
+// If the iterator is empty, an empty value is returned.
+func MinByKey2Func[K, V any, B cmp.Ordered](f func(K, V) B) Reduction2Func[K, V, tuple.Tuple2[K, V]] {
+	return bind2(MinByKey2, f)
+}
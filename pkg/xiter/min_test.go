@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/flier/goutil/pkg/tuple"
 	. "github.com/flier/goutil/pkg/xiter"
 )
 
@@ -52,3 +53,39 @@ func ExampleMinByFunc() {
 	fmt.Println(w)
 	// Output: bar
 }
+
+func ExampleMinBy2() {
+	s := slices.All([]string{"foo", "bar", "baz"})
+	w := MinBy2(s, func(a, b tuple.Tuple2[int, string]) int { return cmp.Compare(a.V1, b.V1) })
+
+	fmt.Println(w)
+	// Output: (1, bar)
+}
+
+func ExampleMinBy2Func() {
+	min := MinBy2Func(func(a, b tuple.Tuple2[int, string]) int { return cmp.Compare(a.V1, b.V1) })
+
+	s := slices.All([]string{"foo", "bar", "baz"})
+	w := min(s)
+
+	fmt.Println(w)
+	// Output: (1, bar)
+}
+
+func ExampleMinByKey2() {
+	s := slices.All([]string{"foo", "bar", "hello", "world"})
+	w := MinByKey2(s, func(i int, k string) int { return len(k) })
+
+	fmt.Println(w)
+	// Output: (1, bar)
+}
+
+func ExampleMinByKey2Func() {
+	minLen := MinByKey2Func(func(i int, k string) int { return len(k) })
+
+	s := slices.All([]string{"foo", "bar", "hello", "world"})
+	w := minLen(s)
+
+	fmt.Println(w)
+	// Output: (1, bar)
+}
@@ -35,3 +35,31 @@ func RangeTo[T Integer](stop T) iter.Seq[T] {
 		}
 	}
 }
+
+// RangeStep returns a sequence of numbers from start (inclusive) to stop (exclusive) by step.
+//
+// The sequence counts down if step is negative. The numbers are computed as start+i*step,
+// so that floating-point steps don't accumulate rounding errors.
+//
+// It panics if step is zero.
+func RangeStep[T Number](start, stop, step T) iter.Seq[T] {
+	if step == 0 {
+		panic("xiter: RangeStep with zero step")
+	}
+
+	return func(yield func(T) bool) {
+		for i, prev := T(0), start; ; i++ {
+			v := start + i*step
+
+			if step > 0 && (v >= stop || v < prev) || step < 0 && (v <= stop || v > prev) {
+				break
+			}
+
+			if !yield(v) {
+				break
+			}
+
+			prev = v
+		}
+	}
+}
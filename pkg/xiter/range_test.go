@@ -67,3 +67,16 @@ func ExampleRangeTo() {
 	// []
 	// []
 }
+
+func ExampleRangeStep() {
+	fmt.Println(slices.Collect(RangeStep(0, 10, 3)))
+	fmt.Println(slices.Collect(RangeStep(5, 0, -2)))
+	fmt.Println(slices.Collect(RangeStep(0, 1, 0.25)))
+	fmt.Println(slices.Collect(RangeStep[uint8](250, 255, 4)))
+
+	// Output:
+	// [0 3 6 9]
+	// [5 3 1]
+	// [0 0.25 0.5 0.75]
+	// [250 254]
+}
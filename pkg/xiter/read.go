@@ -0,0 +1,69 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+)
+
+// ScanTokens returns a fallible iterator over the tokens of the given [bufio.Scanner].
+//
+// The iteration stops after yielding the error of the scanner, if any. The token is
+// only valid until the next one is yielded, since the scanner reuses its buffer,
+// so it must be copied to be retained, e.g. into an arena.
+func ScanTokens(s *bufio.Scanner) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for s.Scan() {
+			if !yield(s.Bytes(), nil) {
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// ScanLines returns a fallible iterator over the lines read from r, stripped of
+// their end-of-line marker, see [ScanTokens].
+func ScanLines(r io.Reader) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		ScanTokens(bufio.NewScanner(r))(yield)
+	}
+}
+
+// CSVRecords returns a fallible iterator over the CSV records read from r.
+//
+// A malformed record is yielded as a [csv.ParseError] and the iteration continues
+// with the next one, while the iteration stops after yielding any other error.
+func CSVRecords(r io.Reader) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		cr := csv.NewReader(r)
+
+		for {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+
+			if err != nil {
+				var perr *csv.ParseError
+
+				if !yield(nil, err) || !errors.As(err, &perr) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
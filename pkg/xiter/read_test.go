@@ -0,0 +1,148 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleScanLines() {
+	lines, errf := FirstErr(ScanLines(strings.NewReader("foo\nbar\r\nbaz")))
+
+	for line := range lines {
+		fmt.Println(string(line))
+	}
+
+	fmt.Println(errf())
+
+	// Output:
+	// foo
+	// bar
+	// baz
+	// <nil>
+}
+
+func TestScanTokens(t *testing.T) {
+	Convey("Given a scanner of words", t, func() {
+		s := bufio.NewScanner(strings.NewReader("hello  world\n!"))
+		s.Split(bufio.ScanWords)
+
+		Convey("When iterating over the tokens", func() {
+			var words []string
+
+			for w, err := range ScanTokens(s) {
+				So(err, ShouldBeNil)
+
+				words = append(words, string(w))
+			}
+
+			So(words, ShouldResemble, []string{"hello", "world", "!"})
+		})
+
+		Convey("When stopping early", func() {
+			var words []string
+
+			for w := range Keys(ScanTokens(s)) {
+				words = append(words, string(w))
+
+				break
+			}
+
+			So(words, ShouldResemble, []string{"hello"})
+		})
+	})
+}
+
+func TestScanLines(t *testing.T) {
+	Convey("Given a reader failing after some lines", t, func() {
+		boom := errors.New("boom")
+		var lines []string
+		var errs []error
+
+		for line, err := range ScanLines(&failingReader{strings.NewReader("foo\nbar\n"), boom}) {
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				lines = append(lines, string(line))
+			}
+		}
+
+		Convey("Then the lines should be yielded before the error", func() {
+			So(lines, ShouldResemble, []string{"foo", "bar"})
+			So(errs, ShouldResemble, []error{boom})
+		})
+	})
+}
+
+func TestCSVRecords(t *testing.T) {
+	Convey("Given a CSV document", t, func() {
+		data := "name,qty\napple,1\nbanana,2,extra\ncherry,3\n"
+
+		Convey("When iterating over the records", func() {
+			var records [][]string
+			var errs []error
+
+			for rec, err := range CSVRecords(strings.NewReader("name,qty\napple,1\ncherry,3\n")) {
+				records = append(records, rec)
+				errs = append(errs, err)
+			}
+
+			So(records, ShouldResemble, [][]string{{"name", "qty"}, {"apple", "1"}, {"cherry", "3"}})
+			So(errs, ShouldResemble, []error{nil, nil, nil})
+		})
+
+		Convey("When a record is malformed", func() {
+			var perr *csv.ParseError
+
+			n := 0 // The records after it should still be yielded.
+
+			for rec, err := range CSVRecords(strings.NewReader(data)) {
+				if err != nil {
+					So(errors.As(err, &perr), ShouldBeTrue)
+					So(rec, ShouldBeNil)
+				}
+
+				n++
+			}
+
+			So(perr.Err, ShouldEqual, csv.ErrFieldCount)
+			So(perr.Line, ShouldEqual, 3)
+			So(n, ShouldEqual, 4)
+		})
+
+		Convey("When the reader fails", func() {
+			boom := errors.New("boom")
+
+			var errs []error
+
+			for _, err := range CSVRecords(&failingReader{strings.NewReader("a,b\n"), boom}) {
+				errs = append(errs, err)
+			}
+
+			So(errs, ShouldResemble, []error{nil, boom})
+		})
+	})
+}
+
+// failingReader reads from r, then fails with err.
+type failingReader struct {
+	r   *strings.Reader
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.r.Len() == 0 {
+		return 0, r.err
+	}
+
+	return r.r.Read(p)
+}
@@ -0,0 +1,115 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"iter"
+	"time"
+)
+
+// Backoff is the retry policy of [RetrySeq].
+//
+// The n-th retry waits Initial * Multiplier^n, capped to Max.
+//
+// The zero Backoff doesn't retry at all.
+type Backoff struct {
+	Initial    time.Duration // The delay before the first retry.
+	Max        time.Duration // The upper bound of the delay, zero means no bound.
+	Multiplier float64       // The growth factor of the delay, values below 1 keep it constant.
+	Retries    int           // The maximum number of retries, negative means retrying forever.
+}
+
+// Delay returns the delay before the n-th retry, counting from zero,
+// or false if the policy gives up.
+func (b Backoff) Delay(n int) (time.Duration, bool) {
+	if b.Retries >= 0 && n >= b.Retries {
+		return 0, false
+	}
+
+	d := b.Initial
+
+	for i := 0; i < n && b.Multiplier > 1; i++ {
+		if b.Max > 0 && d >= b.Max {
+			break
+		}
+
+		d = time.Duration(float64(d) * b.Multiplier)
+	}
+
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+
+	return d, true
+}
+
+// RetrySeq returns an iterator over the sequence opened by fn, retrying fn with the
+// given backoff policy while it fails.
+//
+// Each element is yielded with a nil error. If the policy gives up, the last error of fn
+// is yielded once with the zero value of T, and the iteration ends.
+//
+// Use [OnError] to handle the error in a pipeline.
+//
+// Example:
+//
+//	pages := xiter.RetrySeq(func() (iter.Seq[Item], error) {
+//	    return client.List(ctx)
+//	}, xiter.Backoff{Initial: 100 * time.Millisecond, Max: 5 * time.Second, Multiplier: 2, Retries: 5})
+//
+//	for item, err := range pages {
+//	    if err != nil {
+//	        return err
+//	    }
+//
+//	    ...
+//	}
+func RetrySeq[T any](fn func() (iter.Seq[T], error), policy Backoff) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for n := 0; ; n++ {
+			x, err := fn()
+			if err == nil {
+				for v := range x {
+					if !yield(v, nil) {
+						return
+					}
+				}
+
+				return
+			}
+
+			d, ok := policy.Delay(n)
+			if !ok {
+				var zero T
+
+				yield(zero, err)
+
+				return
+			}
+
+			time.Sleep(d)
+		}
+	}
+}
+
+// OnError returns an iterator over the values of x whose error is nil.
+//
+// The non-nil errors are passed to the handler, which returns true to skip
+// the element and continue, or false to stop the iteration.
+func OnError[T any](x iter.Seq2[T, error], handler func(error) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, err := range x {
+			if err != nil {
+				if !handler(err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
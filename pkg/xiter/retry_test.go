@@ -0,0 +1,151 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleRetrySeq() {
+	attempts := 0
+
+	seq := RetrySeq(func() (iter.Seq[int], error) {
+		if attempts++; attempts < 3 {
+			return nil, errors.New("unavailable")
+		}
+
+		return slices.Values([]int{1, 2, 3}), nil
+	}, Backoff{Initial: time.Millisecond, Multiplier: 2, Retries: 5})
+
+	for v, err := range seq {
+		fmt.Println(v, err)
+	}
+
+	fmt.Println(attempts)
+
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// 3 <nil>
+	// 3
+}
+
+func ExampleOnError() {
+	seq := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, errors.New("bad")) && yield(2, nil)
+	}
+
+	for v := range OnError(seq, func(err error) bool {
+		fmt.Println("skip:", err)
+
+		return true
+	}) {
+		fmt.Println(v)
+	}
+
+	// Output:
+	// 1
+	// skip: bad
+	// 2
+}
+
+func TestBackoff(t *testing.T) {
+	Convey("Given a backoff policy", t, func() {
+		b := Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Multiplier: 2, Retries: 4}
+
+		Convey("The delay grows until the upper bound", func() {
+			var delays []time.Duration
+
+			for n := 0; ; n++ {
+				d, ok := b.Delay(n)
+				if !ok {
+					break
+				}
+
+				delays = append(delays, d)
+			}
+
+			So(delays, ShouldResemble, []time.Duration{
+				10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond,
+			})
+		})
+
+		Convey("A constant backoff keeps the initial delay", func() {
+			b := Backoff{Initial: time.Second, Retries: -1}
+
+			d, ok := b.Delay(100)
+			So(ok, ShouldBeTrue)
+			So(d, ShouldEqual, time.Second)
+		})
+
+		Convey("The zero backoff doesn't retry", func() {
+			_, ok := Backoff{}.Delay(0)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestRetrySeq(t *testing.T) {
+	Convey("Given a source which always fails", t, func() {
+		errUnavailable := errors.New("unavailable")
+		attempts := 0
+
+		seq := RetrySeq(func() (iter.Seq[int], error) {
+			attempts++
+
+			return nil, errUnavailable
+		}, Backoff{Retries: 2})
+
+		Convey("The last error is yielded after the retries", func() {
+			var errs []error
+
+			for v, err := range seq {
+				So(v, ShouldBeZeroValue)
+
+				errs = append(errs, err)
+			}
+
+			So(errs, ShouldResemble, []error{errUnavailable})
+			So(attempts, ShouldEqual, 3)
+		})
+
+		Convey("OnError can stop the iteration", func() {
+			var handled error
+
+			n := 0
+			for range OnError(seq, func(err error) bool { handled = err; return false }) {
+				n++
+			}
+
+			So(n, ShouldEqual, 0)
+			So(handled, ShouldEqual, errUnavailable)
+		})
+	})
+
+	Convey("Given a source which succeeds", t, func() {
+		seq := RetrySeq(func() (iter.Seq[int], error) {
+			return slices.Values([]int{1, 2, 3}), nil
+		}, Backoff{})
+
+		Convey("Stopping early stops the source", func() {
+			var got []int
+
+			for v := range OnError(seq, func(error) bool { return true }) {
+				if got = append(got, v); len(got) == 2 {
+					break
+				}
+			}
+
+			So(got, ShouldResemble, []int{1, 2})
+		})
+	})
+}
@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package xiter
+
+import "iter"
+
+// RunLength creates an iterator which yields each run of consecutive equal elements of x
+// as the element and the length of the run.
+//
+// On a sorted sequence, e.g. the keys of a tree scan, it compresses the duplicates,
+// while on a categorical one it counts the elements of each consecutive run, see
+// [Histogram] to count them overall. [ExpandRuns] is the inverse.
+func RunLength[T comparable](x iter.Seq[T]) iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		var prev T
+
+		n := 0
+
+		for v := range x {
+			if n > 0 && prev == v {
+				n++
+
+				continue
+			}
+
+			if n > 0 && !yield(prev, n) {
+				return
+			}
+
+			prev, n = v, 1
+		}
+
+		if n > 0 {
+			yield(prev, n)
+		}
+	}
+}
+
+// ExpandRuns creates an iterator which yields each element of x repeated as many times
+// as the length of its run, the inverse of [RunLength].
+//
+// The runs of a non-positive length are skipped.
+func ExpandRuns[T any](x iter.Seq2[T, int]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, n := range x {
+			for i := 0; i < n; i++ {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleRunLength() {
+	s := slices.Values([]string{"a", "a", "b", "c", "c", "c", "a"})
+
+	for v, n := range RunLength(s) {
+		fmt.Println(v, n)
+	}
+
+	// Output:
+	// a 2
+	// b 1
+	// c 3
+	// a 1
+}
+
+func ExampleExpandRuns() {
+	runs := maps.All(map[string]int{"a": 3})
+
+	fmt.Println(slices.Collect(ExpandRuns(runs)))
+	// Output: [a a a]
+}
+
+func TestRunLength(t *testing.T) {
+	Convey("Given a sequence with runs", t, func() {
+		s := []int{1, 1, 2, 3, 3, 3}
+
+		Convey("When encoding it", func() {
+			var runs [][2]int
+
+			for v, n := range RunLength(slices.Values(s)) {
+				runs = append(runs, [2]int{v, n})
+			}
+
+			So(runs, ShouldResemble, [][2]int{{1, 2}, {2, 1}, {3, 3}})
+
+			Convey("Then expanding the runs restores it", func() {
+				So(slices.Collect(ExpandRuns(RunLength(slices.Values(s)))), ShouldResemble, s)
+			})
+		})
+
+		Convey("When stopping early", func() {
+			var vs []int
+
+			for v := range RunLength(slices.Values(s)) {
+				vs = append(vs, v)
+
+				if v == 2 {
+					break
+				}
+			}
+
+			So(vs, ShouldResemble, []int{1, 2})
+			So(slices.Collect(Take(ExpandRuns(RunLength(slices.Values(s))), 4)), ShouldResemble, []int{1, 1, 2, 3})
+		})
+	})
+
+	Convey("Given an empty sequence", t, func() {
+		So(Count2(RunLength(Empty[int]())), ShouldEqual, 0)
+	})
+
+	Convey("Given runs of non-positive length", t, func() {
+		runs := Zip(slices.Values([]int{1, 2, 3}), slices.Values([]int{0, -1, 2}))
+
+		So(slices.Collect(ExpandRuns(runs)), ShouldResemble, []int{3, 3})
+	})
+}
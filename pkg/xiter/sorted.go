@@ -5,8 +5,160 @@ package xiter
 import (
 	"cmp"
 	"iter"
+	"slices"
 )
 
+// Sorted creates an iterator that yields the elements of x in ascending order.
+//
+// The elements are only collected and sorted when the iteration starts, and again
+// for every iteration.
+func Sorted[T cmp.Ordered](x iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := slices.Collect(x)
+		slices.Sort(s)
+
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SortedBy creates an iterator that yields the elements of x in ascending order
+// using the given comparison function.
+//
+// The sort is stable, equal elements keep their original order.
+func SortedBy[T any](x iter.Seq[T], f func(T, T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := slices.Collect(x)
+		slices.SortStableFunc(s, f)
+
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SortedByFunc creates an iterator that yields the elements in ascending order
+// using the given comparison function.
+func SortedByFunc[T any](f func(T, T) int) MappingFunc[T, T] {
+	return bind2(SortedBy, f)
+}
+
+// SortedByKey creates an iterator that yields the elements of x in ascending order
+// of the keys extracted by the given function.
+//
+// The sort is stable, equal elements keep their original order. f is called once
+// per element.
+func SortedByKey[T any, B cmp.Ordered](x iter.Seq[T], f func(T) B) iter.Seq[T] {
+	type keyed struct {
+		key B
+		v   T
+	}
+
+	return func(yield func(T) bool) {
+		var s []keyed
+
+		for v := range x {
+			s = append(s, keyed{f(v), v})
+		}
+
+		slices.SortStableFunc(s, func(a, b keyed) int { return cmp.Compare(a.key, b.key) })
+
+		for _, e := range s {
+			if !yield(e.v) {
+				return
+			}
+		}
+	}
+}
+
+// SortedByKeyFunc creates an iterator that yields the elements in ascending order
+// of the keys extracted by the given function.
+func SortedByKeyFunc[T any, B cmp.Ordered](f func(T) B) MappingFunc[T, T] {
+	return bind2(SortedByKey, f)
+}
+
+// TopK creates an iterator that yields the k largest elements of x in descending
+// order using the given comparison function.
+//
+// Only k elements are kept in a heap while consuming x, instead of sorting all of them,
+// so it takes O(n log k) time and O(k) memory.
+func TopK[T any](x iter.Seq[T], k int, f func(T, T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if k <= 0 {
+			return
+		}
+
+		h := make([]T, 0, min(k, 64))
+
+		for v := range x {
+			if len(h) < k {
+				h = append(h, v)
+				siftUp(h, len(h)-1, f)
+			} else if f(v, h[0]) > 0 {
+				h[0] = v
+				siftDown(h, 0, f)
+			}
+		}
+
+		// Pop the smallest to the end repeatedly, leaving h in descending order.
+		for n := len(h) - 1; n > 0; n-- {
+			h[0], h[n] = h[n], h[0]
+			siftDown(h[:n], 0, f)
+		}
+
+		for _, v := range h {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TopKFunc creates an iterator that yields the k largest elements in descending
+// order using the given comparison function.
+func TopKFunc[T any](k int, f func(T, T) int) MappingFunc[T, T] {
+	return bind23(TopK, k, f)
+}
+
+// siftUp restores the min-heap h after the element i has been added.
+func siftUp[T any](h []T, i int, f func(T, T) int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if f(h[i], h[p]) >= 0 {
+			return
+		}
+
+		h[i], h[p] = h[p], h[i]
+		i = p
+	}
+}
+
+// siftDown restores the min-heap h after the element i has been replaced.
+func siftDown[T any](h []T, i int, f func(T, T) int) {
+	for {
+		l := 2*i + 1
+		if l >= len(h) {
+			return
+		}
+
+		if r := l + 1; r < len(h) && f(h[r], h[l]) < 0 {
+			l = r
+		}
+
+		if f(h[l], h[i]) >= 0 {
+			return
+		}
+
+		h[i], h[l] = h[l], h[i]
+		i = l
+	}
+}
+
 // IsSorted reports whether x is sorted in ascending order.
 func IsSorted[T cmp.Ordered](x iter.Seq[T]) bool {
 	var last *T
@@ -3,10 +3,14 @@
 package xiter_test
 
 import (
+	"cmp"
 	"fmt"
+	"math/rand"
 	"slices"
+	"testing"
 
 	. "github.com/flier/goutil/pkg/xiter"
+	. "github.com/smartystreets/goconvey/convey"
 )
 
 func ExampleIsSorted() {
@@ -79,3 +83,79 @@ func ExampleIsSortedByKeyFunc() {
 	// Output:
 	// false
 }
+
+func ExampleSorted() {
+	fmt.Println(slices.Collect(Sorted(slices.Values([]int{3, 1, 2}))))
+	// Output: [1 2 3]
+}
+
+func ExampleSortedBy() {
+	s := slices.Values([]string{"bb", "a", "ccc", "dd"})
+
+	fmt.Println(slices.Collect(SortedBy(s, func(x, y string) int { return len(x) - len(y) })))
+	// Output: [a bb dd ccc]
+}
+
+func ExampleSortedByKey() {
+	type User struct {
+		Name string
+		Age  int
+	}
+
+	s := slices.Values([]User{{"tom", 12}, {"joe", 8}, {"ann", 12}})
+
+	fmt.Println(slices.Collect(SortedByKey(s, func(u User) int { return u.Age })))
+	// Output: [{joe 8} {tom 12} {ann 12}]
+}
+
+func ExampleTopK() {
+	s := slices.Values([]int{5, 1, 9, 3, 7, 9, 2})
+
+	fmt.Println(slices.Collect(TopK(s, 3, cmp.Compare[int])))
+	// Output: [9 9 7]
+}
+
+func TestTopK(t *testing.T) {
+	Convey("Given a random sequence", t, func() {
+		rng := rand.New(rand.NewSource(42))
+
+		s := make([]int, 1000)
+		for i := range s {
+			s[i] = rng.Intn(100)
+		}
+
+		want := slices.Clone(s)
+		slices.Sort(want)
+		slices.Reverse(want)
+
+		Convey("Then TopK should yield the largest elements in descending order", func() {
+			for _, k := range []int{0, 1, 2, 10, 999, 1000, 2000} {
+				got := slices.Collect(TopK(slices.Values(s), k, cmp.Compare[int]))
+
+				if k == 0 {
+					So(got, ShouldBeEmpty)
+				} else {
+					So(got, ShouldResemble, want[:min(k, len(want))])
+				}
+			}
+		})
+
+		Convey("Then TopKFunc with a reversed comparison should yield the smallest elements", func() {
+			got := slices.Collect(TopKFunc(5, func(x, y int) int { return y - x }).Map(slices.Values(s)))
+
+			So(got, ShouldResemble, slices.Collect(Sorted(slices.Values(s)))[:5])
+		})
+
+		Convey("Then stopping early should be supported", func() {
+			for v := range Sorted(slices.Values(s)) {
+				So(v, ShouldEqual, want[len(want)-1])
+				break
+			}
+
+			for v := range TopK(slices.Values(s), 10, cmp.Compare[int]) {
+				So(v, ShouldEqual, want[0])
+				break
+			}
+		})
+	})
+}
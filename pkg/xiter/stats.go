@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"iter"
+	"sort"
+)
+
+// Mean returns the arithmetic mean of the elements of an iterator.
+//
+// If the iterator is empty, zero is returned.
+func Mean[T Number](x iter.Seq[T]) float64 {
+	mean, _, _ := welford(x)
+
+	return mean
+}
+
+// Variance returns the population variance of the elements of an iterator.
+//
+// It is computed in a single pass with Welford's algorithm, which is numerically
+// stable even when the variance is small compared to the mean.
+// If the iterator is empty, zero is returned.
+func Variance[T Number](x iter.Seq[T]) float64 {
+	_, m2, n := welford(x)
+	if n == 0 {
+		return 0
+	}
+
+	return m2 / float64(n)
+}
+
+// welford returns the mean, the sum of the squared differences from the mean, and
+// the number of the elements.
+func welford[T Number](x iter.Seq[T]) (mean, m2 float64, n int) {
+	for v := range x {
+		n++
+
+		f := float64(v)
+		d := f - mean
+		mean += d / float64(n)
+		m2 += d * (f - mean)
+	}
+
+	return
+}
+
+// Histogram counts the elements of an iterator into the fixed buckets delimited by
+// the ascending bounds.
+//
+// It returns len(bounds)+1 counts, where the i-th bucket counts the elements v with
+// bounds[i-1] <= v < bounds[i], the first bucket the elements below bounds[0], and
+// the last bucket the elements greater than or equal to the last bound.
+//
+// Example:
+//
+//	Histogram(slices.Values([]int{1, 5, 10, 50, 100}), 10, 100) // [2 2 1]
+func Histogram[T Number](x iter.Seq[T], bounds ...T) []int {
+	counts := make([]int, len(bounds)+1)
+
+	for v := range x {
+		counts[sort.Search(len(bounds), func(i int) bool { return v < bounds[i] })]++
+	}
+
+	return counts
+}
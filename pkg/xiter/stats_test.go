@@ -0,0 +1,59 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleMean() {
+	fmt.Println(Mean(slices.Values([]int{2, 4, 4, 4, 5, 5, 7, 9})))
+
+	// Output:
+	// 5
+}
+
+func ExampleVariance() {
+	fmt.Println(Variance(slices.Values([]int{2, 4, 4, 4, 5, 5, 7, 9})))
+
+	// Output:
+	// 4
+}
+
+func ExampleHistogram() {
+	fmt.Println(Histogram(slices.Values([]int{1, 5, 10, 50, 100}), 10, 100))
+
+	// Output:
+	// [2 2 1]
+}
+
+func TestStats(t *testing.T) {
+	Convey("Given an empty sequence", t, func() {
+		x := Empty[float64]()
+
+		So(Mean(x), ShouldEqual, 0)
+		So(Variance(x), ShouldEqual, 0)
+		So(Histogram(x, 1, 2), ShouldResemble, []int{0, 0, 0})
+	})
+
+	Convey("Given a sequence with a large offset", t, func() {
+		x := slices.Values([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16})
+
+		So(Mean(x), ShouldEqual, 1e9+10)
+		So(Variance(x), ShouldAlmostEqual, 22.5, 1e-6)
+	})
+
+	Convey("Given a histogram without bounds", t, func() {
+		So(Histogram(slices.Values([]uint8{1, 2, 3})), ShouldResemble, []int{3})
+	})
+
+	Convey("Given values on the bounds", t, func() {
+		So(Histogram(slices.Values([]float64{-1, 0, 0.5, 1, 2}), 0, 1), ShouldResemble, []int{1, 2, 2})
+	})
+}
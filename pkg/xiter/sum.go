@@ -28,3 +28,17 @@ func SumBy[T any, B Number](x iter.Seq[T], f func(T) B) (r B) {
 func SumByFunc[T any, B Number](f func(T) B) ReductionFunc[T, B] {
 	return bind2(SumBy[T, B], f)
 }
+
+// SumBy2 sums the key-value that gives the value from the specified function.
+func SumBy2[K, V any, B Number](x iter.Seq2[K, V], f func(K, V) B) (r B) {
+	for k, v := range x {
+		r += f(k, v)
+	}
+
+	return
+}
+
+// SumBy2Func sums the key-value that gives the value from the specified function.
+func SumBy2Func[K, V any, B Number](f func(K, V) B) Reduction2Func[K, V, B] {
+	return bind2(SumBy2[K, V, B], f)
+}
@@ -41,3 +41,25 @@ func ExampleSumByFunc() {
 	// Output:
 	// 9
 }
+
+func ExampleSumBy2() {
+	s := slices.All([]string{"foo", "bar", "baz"})
+	n := SumBy2(s, func(i int, s string) int { return i * len(s) })
+
+	fmt.Println(n)
+
+	// Output:
+	// 9
+}
+
+func ExampleSumBy2Func() {
+	sumLen := SumBy2Func(func(i int, s string) int { return i * len(s) })
+
+	s := slices.All([]string{"foo", "bar", "baz"})
+	n := sumLen(s)
+
+	fmt.Println(n)
+
+	// Output:
+	// 9
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package xiter
+
+import (
+	"iter"
+	"time"
+)
+
+// Throttle creates an iterator which yields the first element of x, then drops the
+// elements pulled from x within d of the last yielded one, e.g. to limit the rate of
+// a bursty stream of change events.
+//
+// The elements are timed when they are pulled from x, so a slow consumer slows
+// the stream down, but never makes it drop more elements.
+//
+// It panics if d is not positive.
+func Throttle[T any](x iter.Seq[T], d time.Duration) iter.Seq[T] {
+	if d <= 0 {
+		panic("xiter: non-positive interval for Throttle")
+	}
+
+	return func(yield func(T) bool) {
+		var last time.Time
+
+		for v := range x {
+			if now := time.Now(); last.IsZero() || now.Sub(last) >= d {
+				last = now
+
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Debounce creates an iterator which yields the last element of each burst of x,
+// whose elements are timed by their time, such as the time of an event. A burst
+// ends when the next element is at least d later, or when x is exhausted.
+//
+// Since it can't wait for the next element, the last element of a burst is only
+// yielded once the next element is pulled, or the end of x is reached.
+//
+// It panics if d is not positive.
+func Debounce[T any](x iter.Seq2[T, time.Time], d time.Duration) iter.Seq2[T, time.Time] {
+	if d <= 0 {
+		panic("xiter: non-positive interval for Debounce")
+	}
+
+	return func(yield func(T, time.Time) bool) {
+		var (
+			prev     T
+			prevTime time.Time
+			pending  bool
+		)
+
+		for v, t := range x {
+			if pending && t.Sub(prevTime) >= d && !yield(prev, prevTime) {
+				return
+			}
+
+			prev, prevTime, pending = v, t, true
+		}
+
+		if pending {
+			yield(prev, prevTime)
+		}
+	}
+}
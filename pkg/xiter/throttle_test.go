@@ -0,0 +1,100 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleDebounce() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := Zip(slices.Values([]string{"a", "b", "c", "d", "e"}), slices.Values([]time.Time{
+		start,
+		start.Add(10 * time.Millisecond),
+		start.Add(20 * time.Millisecond),
+		start.Add(time.Second),
+		start.Add(time.Second + 10*time.Millisecond),
+	}))
+
+	for v, t := range Debounce(events, 100*time.Millisecond) {
+		fmt.Println(v, t.Sub(start))
+	}
+
+	// Output:
+	// c 20ms
+	// e 1.01s
+}
+
+func TestThrottle(t *testing.T) {
+	Convey("Given a burst of elements", t, func() {
+		burst := func(yield func(int) bool) {
+			for i := 0; i < 10; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+
+			yield(10)
+		}
+
+		Convey("When throttling it", func() {
+			Convey("Then the elements within the interval are dropped", func() {
+				So(slices.Collect(Throttle(burst, time.Hour)), ShouldResemble, []int{0})
+				So(slices.Collect(Throttle(burst, 10*time.Millisecond)), ShouldResemble, []int{0, 10})
+			})
+
+			Convey("Then it stops with the consumer", func() {
+				So(slices.Collect(Take(Throttle(burst, 10*time.Millisecond), 1)), ShouldResemble, []int{0})
+			})
+		})
+
+		Convey("Then a non-positive interval panics", func() {
+			So(func() { Throttle(burst, 0) }, ShouldPanic)
+		})
+	})
+}
+
+func TestDebounce(t *testing.T) {
+	Convey("Given timed elements", t, func() {
+		start := time.Now()
+		at := func(ms ...int) []time.Time {
+			ts := make([]time.Time, len(ms))
+			for i, m := range ms {
+				ts[i] = start.Add(time.Duration(m) * time.Millisecond)
+			}
+
+			return ts
+		}
+
+		events := Zip(slices.Values([]int{1, 2, 3, 4, 5, 6}), slices.Values(at(0, 5, 50, 55, 60, 200)))
+
+		Convey("When debouncing them", func() {
+			Convey("Then the last element of each burst is yielded", func() {
+				So(slices.Collect(Keys(Debounce(events, 20*time.Millisecond))), ShouldResemble, []int{2, 5, 6})
+				So(slices.Collect(Keys(Debounce(events, time.Second))), ShouldResemble, []int{6})
+				So(slices.Collect(Keys(Debounce(events, time.Millisecond))), ShouldResemble, []int{1, 2, 3, 4, 5, 6})
+			})
+
+			Convey("Then it stops with the consumer", func() {
+				So(slices.Collect(Take(Keys(Debounce(events, 20*time.Millisecond)), 1)), ShouldResemble, []int{2})
+			})
+		})
+
+		Convey("Then an empty sequence yields nothing", func() {
+			So(Count2(Debounce(Empty2[int, time.Time](), time.Second)), ShouldEqual, 0)
+		})
+
+		Convey("Then a non-positive interval panics", func() {
+			So(func() { Debounce(events, -time.Second) }, ShouldPanic)
+		})
+	})
+}
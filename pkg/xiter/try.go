@@ -0,0 +1,109 @@
+//go:build go1.23
+
+package xiter
+
+import "iter"
+
+// Ok lifts an infallible iterator into a fallible one, yielding each element with a nil error.
+//
+// The fallible iterators are of type iter.Seq2[T, error], aliased as [Try] since Go 1.24,
+// which yield either a value with a nil error, or the zero value with a non-nil error.
+// The adapters pass the errors through unchanged and keep iterating, the consumer decides
+// whether to stop at the first one, see [FirstErr] and [OnError].
+func Ok[T any](x iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v := range x {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// MapTry takes a fallible function and creates a fallible iterator which calls
+// that function f on each value of x whose error is nil.
+//
+// The errors of x and of f are yielded with the zero value of U.
+func MapTry[T, U any](x iter.Seq2[T, error], f func(T) (U, error)) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for v, err := range x {
+			var u U
+
+			if err == nil {
+				if u, err = f(v); err != nil {
+					var zero U
+
+					u = zero
+				}
+			}
+
+			if !yield(u, err) {
+				return
+			}
+		}
+	}
+}
+
+// FilterTry creates a fallible iterator which uses a fallible predicate f to determine
+// if a value of x whose error is nil should be yielded.
+//
+// The errors of x and of f are yielded with the zero value of T.
+func FilterTry[T any](x iter.Seq2[T, error], f func(T) (bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range x {
+			if err == nil {
+				var ok bool
+
+				if ok, err = f(v); err == nil && !ok {
+					continue
+				}
+			}
+
+			if err != nil {
+				var zero T
+
+				v = zero
+			}
+
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// FirstErr returns an iterator over the values of x which stops at the first error,
+// and a function returning that error, or nil, once the iteration is done.
+//
+// Example:
+//
+//	lines, errf := xiter.FirstErr(readLines(f))
+//
+//	for line := range lines {
+//	    ...
+//	}
+//
+//	if err := errf(); err != nil {
+//	    return err
+//	}
+func FirstErr[T any](x iter.Seq2[T, error]) (iter.Seq[T], func() error) {
+	var first error
+
+	return func(yield func(T) bool) {
+			first = nil
+
+			for v, err := range x {
+				if err != nil {
+					first = err
+
+					return
+				}
+
+				if !yield(v) {
+					return
+				}
+			}
+		}, func() error {
+			return first
+		}
+}
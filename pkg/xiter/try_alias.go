@@ -0,0 +1,11 @@
+//go:build go1.24
+
+package xiter
+
+import "iter"
+
+// Try is a fallible iterator, which yields either a value with a nil error,
+// or the zero value of T with a non-nil error.
+//
+// See [Ok] for the conventions of the adapters.
+type Try[T any] = iter.Seq2[T, error]
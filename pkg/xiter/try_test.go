@@ -0,0 +1,121 @@
+//go:build go1.23
+
+package xiter_test
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	. "github.com/flier/goutil/pkg/xiter"
+)
+
+func ExampleFirstErr() {
+	nums := MapTry(Ok(slices.Values([]string{"1", "2", "x", "4"})), strconv.Atoi)
+
+	values, errf := FirstErr(nums)
+
+	for v := range values {
+		fmt.Println(v)
+	}
+
+	fmt.Println(errf())
+
+	// Output:
+	// 1
+	// 2
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+type result[T any] struct {
+	v   T
+	err error
+}
+
+func collectTry[T any](x iter.Seq2[T, error]) (r []result[T]) {
+	for v, err := range x {
+		r = append(r, result[T]{v, err})
+	}
+
+	return
+}
+
+func TestTry(t *testing.T) {
+	errBad := errors.New("bad")
+	errOdd := errors.New("odd")
+
+	src := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, errBad) && yield(2, nil) && yield(3, nil)
+	}
+
+	Convey("Given a fallible iterator", t, func() {
+		Convey("Ok yields the elements with a nil error", func() {
+			So(collectTry(Ok(slices.Values([]int{1, 2}))), ShouldResemble, []result[int]{{1, nil}, {2, nil}})
+		})
+
+		Convey("MapTry maps the values and passes the errors through", func() {
+			r := collectTry(MapTry(src, func(v int) (string, error) {
+				if v%2 == 1 {
+					return "odd", errOdd
+				}
+
+				return strconv.Itoa(v), nil
+			}))
+
+			So(r, ShouldResemble, []result[string]{{"", errOdd}, {"", errBad}, {"2", nil}, {"", errOdd}})
+		})
+
+		Convey("FilterTry filters the values and passes the errors through", func() {
+			r := collectTry(FilterTry(src, func(v int) (bool, error) {
+				if v == 3 {
+					return true, errOdd
+				}
+
+				return v%2 == 0, nil
+			}))
+
+			So(r, ShouldResemble, []result[int]{{0, errBad}, {2, nil}, {0, errOdd}})
+		})
+
+		Convey("FirstErr stops at the first error", func() {
+			values, errf := FirstErr[int](src)
+
+			So(slices.Collect(values), ShouldResemble, []int{1})
+			So(errf(), ShouldEqual, errBad)
+
+			Convey("And is reset on the next iteration", func() {
+				for range values {
+					break
+				}
+
+				So(errf(), ShouldBeNil)
+			})
+		})
+
+		Convey("FirstErr reports nil without an error", func() {
+			values, errf := FirstErr(Ok(slices.Values([]int{1, 2})))
+
+			So(slices.Collect(values), ShouldResemble, []int{1, 2})
+			So(errf(), ShouldBeNil)
+		})
+
+		Convey("Stopping early stops the source", func() {
+			var got []int
+
+			for v := range OnError(MapTry(src, func(v int) (int, error) { return v * 10, nil }), func(error) bool { return true }) {
+				got = append(got, v)
+
+				if len(got) == 2 {
+					break
+				}
+			}
+
+			So(got, ShouldResemble, []int{10, 20})
+		})
+	})
+}
@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package xunsafe
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// AtomicLoadPointer atomically loads *p.
+func AtomicLoadPointer[T any](p **T) *T {
+	return (*T)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(p))))
+}
+
+// AtomicStorePointer atomically stores v into *p.
+func AtomicStorePointer[T any](p **T, v *T) {
+	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(p)), unsafe.Pointer(v))
+}
+
+// AtomicSwapPointer atomically stores v into *p and returns the previous value.
+func AtomicSwapPointer[T any](p **T, v *T) (old *T) {
+	return (*T)(atomic.SwapPointer((*unsafe.Pointer)(unsafe.Pointer(p)), unsafe.Pointer(v)))
+}
+
+// AtomicCompareAndSwapPointer executes the compare-and-swap operation for a pointer.
+func AtomicCompareAndSwapPointer[T any](p **T, old, new *T) (swapped bool) {
+	return atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(p)), unsafe.Pointer(old), unsafe.Pointer(new))
+}
+
+// The methods below operate on a slot holding an address, such as a child
+// pointer stored in arena memory.
+//
+// Unlike the pointer functions above, they don't emit write barriers, so they
+// must only be used for memory the garbage collector doesn't scan, and for
+// addresses which are kept alive by other means, such as their owning arena.
+
+// AtomicLoad atomically loads the address stored at a.
+func (a *Addr[T]) AtomicLoad() Addr[T] {
+	return Addr[T](atomic.LoadUintptr((*uintptr)(unsafe.Pointer(a))))
+}
+
+// AtomicStore atomically stores the address v at a.
+func (a *Addr[T]) AtomicStore(v Addr[T]) {
+	atomic.StoreUintptr((*uintptr)(unsafe.Pointer(a)), uintptr(v))
+}
+
+// AtomicSwap atomically stores the address v at a and returns the previous address.
+func (a *Addr[T]) AtomicSwap(v Addr[T]) (old Addr[T]) {
+	return Addr[T](atomic.SwapUintptr((*uintptr)(unsafe.Pointer(a)), uintptr(v)))
+}
+
+// AtomicCompareAndSwap executes the compare-and-swap operation for the address stored at a.
+func (a *Addr[T]) AtomicCompareAndSwap(old, new Addr[T]) (swapped bool) {
+	return atomic.CompareAndSwapUintptr((*uintptr)(unsafe.Pointer(a)), uintptr(old), uintptr(new))
+}
+
+// AtomicAdd atomically adds delta to the address stored at a, scaled by the size
+// of T, and returns the new address.
+//
+// This is useful to bump allocate from a shared cursor.
+func (a *Addr[T]) AtomicAdd(delta int) (new Addr[T]) {
+	var zero Addr[T]
+
+	return Addr[T](atomic.AddUintptr((*uintptr)(unsafe.Pointer(a)), uintptr(zero.Add(delta))))
+}
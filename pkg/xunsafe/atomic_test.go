@@ -0,0 +1,89 @@
+//go:build go1.23
+
+package xunsafe_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/flier/goutil/pkg/xunsafe"
+)
+
+func TestAtomicPointer(t *testing.T) {
+	Convey("Given a pointer slot", t, func() {
+		x, y := 1, 2
+		p := &x
+
+		So(xunsafe.AtomicLoadPointer(&p), ShouldPointTo, &x)
+
+		Convey("When storing a pointer", func() {
+			xunsafe.AtomicStorePointer(&p, &y)
+
+			So(p, ShouldPointTo, &y)
+		})
+
+		Convey("When swapping a pointer", func() {
+			So(xunsafe.AtomicSwapPointer(&p, &y), ShouldPointTo, &x)
+			So(p, ShouldPointTo, &y)
+		})
+
+		Convey("When comparing and swapping a pointer", func() {
+			So(xunsafe.AtomicCompareAndSwapPointer(&p, &y, &y), ShouldBeFalse)
+			So(p, ShouldPointTo, &x)
+
+			So(xunsafe.AtomicCompareAndSwapPointer(&p, &x, &y), ShouldBeTrue)
+			So(p, ShouldPointTo, &y)
+		})
+	})
+}
+
+func TestAddr_Atomic(t *testing.T) {
+	Convey("Given an address slot", t, func() {
+		var buf [16]int64
+
+		a := xunsafe.AddrOf(&buf[0])
+		b := xunsafe.AddrOf(&buf[1])
+
+		slot := a
+
+		So(slot.AtomicLoad(), ShouldEqual, a)
+
+		Convey("When storing an address", func() {
+			slot.AtomicStore(b)
+
+			So(slot, ShouldEqual, b)
+		})
+
+		Convey("When swapping an address", func() {
+			So(slot.AtomicSwap(b), ShouldEqual, a)
+			So(slot, ShouldEqual, b)
+		})
+
+		Convey("When comparing and swapping an address", func() {
+			So(slot.AtomicCompareAndSwap(b, b), ShouldBeFalse)
+			So(slot.AtomicCompareAndSwap(a, b), ShouldBeTrue)
+			So(slot, ShouldEqual, b)
+		})
+
+		Convey("When adding to an address concurrently", func() {
+			var wg sync.WaitGroup
+
+			for i := 0; i < 15; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					slot.AtomicAdd(1)
+				}()
+			}
+
+			wg.Wait()
+
+			So(slot, ShouldEqual, xunsafe.AddrOf(&buf[15]))
+			So(slot.AtomicAdd(-15), ShouldEqual, a)
+		})
+	})
+}